@@ -16,6 +16,8 @@ import (
 	"github.com/JohnG-Dev/army_builder_api/internal/database"
 	"github.com/JohnG-Dev/army_builder_api/internal/handlers"
 	"github.com/JohnG-Dev/army_builder_api/internal/middleware"
+	"github.com/JohnG-Dev/army_builder_api/internal/ruleset"
+	"github.com/JohnG-Dev/army_builder_api/internal/services"
 	"github.com/JohnG-Dev/army_builder_api/internal/state"
 )
 
@@ -31,8 +33,9 @@ func main() {
 	}
 
 	cfg := &config.Config{
-		Env:  env,
-		Port: port,
+		Env:         env,
+		Port:        port,
+		RulesetsDir: os.Getenv("RULESETS_DIR"),
 	}
 
 	dbURL := os.Getenv("DATABASE_URL")
@@ -58,11 +61,23 @@ func main() {
 
 	defer func() { _ = logger.Sync() }()
 
+	rulesets, err := ruleset.Builtin()
+	if cfg.RulesetsDir != "" {
+		rulesets, err = ruleset.LoadDir(cfg.RulesetsDir)
+	}
+	if err == nil {
+		err = services.CheckRulesets(rulesets)
+	}
+	if err != nil {
+		log.Fatalf("Unable to load rulesets: %v\n", err)
+	}
+
 	s := &state.State{
-		DB:     queries,
-		Cfg:    cfg,
-		Logger: logger,
-		Pool:   dbpool,
+		DB:       queries,
+		Cfg:      cfg,
+		Logger:   logger,
+		Pool:     dbpool,
+		Rulesets: rulesets,
 	}
 
 	fHandlers := &handlers.FactionsHandlers{S: s}
@@ -75,13 +90,18 @@ func main() {
 	bHandlers := &handlers.BattleFormationsHandlers{S: s}
 	eHandlers := &handlers.EnhancementsHandlers{S: s}
 	vHandlers := &handlers.ValidationHandlers{S: s}
+	armyHandlers := &handlers.ArmiesHandlers{S: s}
+	cHandlers := &handlers.CombatHandlers{S: s}
+	searchHandlers := &handlers.SearchHandlers{S: s}
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /games", gHandlers.GetGames)
 	mux.HandleFunc("GET /factions", fHandlers.GetFactions)
 	mux.HandleFunc("GET /factions/{id}", fHandlers.GetFactionByID)
+	mux.HandleFunc("GET /factions/{id}/roster", fHandlers.GetFactionRoster)
 	mux.HandleFunc("GET /units", uHandlers.GetUnits)
 	mux.HandleFunc("GET /units/{id}", uHandlers.GetUnitByID)
+	mux.HandleFunc("POST /units/{id}/resolve", uHandlers.ResolveUnitStats)
 	mux.HandleFunc("GET /manifestations", uHandlers.GetManifestations)
 	mux.HandleFunc("GET /manifestations/{id}", uHandlers.GetManifestationByID)
 	mux.HandleFunc("GET /units/nonmanifestations", uHandlers.GetNonManifestationUnits)
@@ -100,6 +120,17 @@ func main() {
 	mux.HandleFunc("GET /enhancements", eHandlers.GetEnhancements)
 	mux.HandleFunc("GET /enhancements/{id}", eHandlers.GetEnhancementByID)
 	mux.HandleFunc("POST /validate", vHandlers.ValidateArmy)
+	mux.HandleFunc("GET /armies", armyHandlers.GetArmies)
+	mux.HandleFunc("POST /armies", armyHandlers.CreateArmy)
+	mux.HandleFunc("POST /armies/import", armyHandlers.ImportArmy)
+	mux.HandleFunc("POST /armies/import/roster", armyHandlers.ImportArmyRoster)
+	mux.HandleFunc("GET /armies/{id}", armyHandlers.GetArmyByID)
+	mux.HandleFunc("GET /armies/{id}/export", armyHandlers.ExportArmy)
+	mux.HandleFunc("PUT /armies/{id}", armyHandlers.UpdateArmy)
+	mux.HandleFunc("DELETE /armies/{id}", armyHandlers.DeleteArmy)
+	mux.HandleFunc("POST /calculate/damage", cHandlers.CalculateDamage)
+	mux.HandleFunc("POST /simulate/combat", cHandlers.SimulateCombat)
+	mux.HandleFunc("GET /search", searchHandlers.Search)
 
 	wrappedMux := middleware.MiddlewareRequestID(mux)
 
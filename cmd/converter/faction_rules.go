@@ -0,0 +1,316 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+// EnhancementGroups maps catalogue selection entry group names to the
+// enhancement type stored for every upgrade inside them.
+var EnhancementGroups = map[string]string{
+	"Heroic Traits":      "Heroic Trait",
+	"Artefacts of Power": "Artefact of Power",
+	"Monstrous Traits":   "Monstrous Trait",
+}
+
+// LoreGroups maps the prefix of a catalogue lore group to the enhancement type
+// stored for each lore. The spells and prayers inside a lore become faction
+// abilities.
+var LoreGroups = map[string]string{
+	"Spell Lore":         "Spell Lore",
+	"Prayer Lore":        "Prayer Lore",
+	"Manifestation Lore": "Manifestation Lore",
+}
+
+const battleFormationsGroup = "Battle Formations"
+
+// keywordMarker finds the ^^keyword^^ phrases in BattleScribe rule text.
+var keywordMarker = regexp.MustCompile(`\^\^(.+?)\^\^`)
+
+// collectFactionRules fills a faction's battle formations, enhancements and
+// faction-scoped abilities (battle traits, spells, prayers) from a catalogue.
+func (c *Converter) collectFactionRules(cat Catalogue, faction *models.FactionSeed) {
+	seenAbilities := make(map[string]bool)
+	addAbilities := func(profiles []Profile) {
+		for _, p := range profiles {
+			if !isAbilityProfile(p) || seenAbilities[p.Name] {
+				continue
+			}
+			seenAbilities[p.Name] = true
+			faction.Abilities = append(faction.Abilities, c.mapAbility(p))
+		}
+	}
+
+	for _, entry := range c.rootEntries(cat) {
+		if strings.HasPrefix(entry.Name, "Battle Traits") {
+			addAbilities(c.collectAbilityProfiles(entry))
+		}
+	}
+
+	seenEnhancements := make(map[string]bool)
+	for _, group := range c.factionGroups(cat) {
+		switch {
+		case strings.HasPrefix(group.Name, battleFormationsGroup):
+			for _, entry := range c.groupEntries(group) {
+				faction.BattleFormations = append(faction.BattleFormations, models.BattleFormationSeed{
+					Name:        entry.Name,
+					Description: c.describeAbilities(entry),
+				})
+			}
+		case EnhancementGroups[group.Name] != "":
+			for _, e := range c.collectEnhancements(group, EnhancementGroups[group.Name], "") {
+				if seenEnhancements[e.Name] {
+					continue
+				}
+				seenEnhancements[e.Name] = true
+				faction.Enhancements = append(faction.Enhancements, e)
+			}
+		default:
+			loreType := loreGroupType(group.Name)
+			if loreType == "" {
+				continue
+			}
+			for _, lore := range c.groupEntries(group) {
+				var spellNames []string
+				for _, spell := range c.loreSpells(lore) {
+					spellNames = append(spellNames, spell.Name)
+					addAbilities([]Profile{spell})
+				}
+				if seenEnhancements[lore.Name] {
+					continue
+				}
+				seenEnhancements[lore.Name] = true
+				faction.Enhancements = append(faction.Enhancements, models.EnhancementSeed{
+					Name:            lore.Name,
+					EnhancementType: loreType,
+					Description:     strings.Join(spellNames, ", "),
+					Points:          c.parsePoints(lore.Costs),
+					IsUnique:        true,
+				})
+			}
+		}
+	}
+}
+
+func loreGroupType(groupName string) string {
+	for prefix, loreType := range LoreGroups {
+		if strings.HasPrefix(groupName, prefix) {
+			return loreType
+		}
+	}
+	return ""
+}
+
+// rootEntries returns the catalogue's top level entries with links resolved.
+func (c *Converter) rootEntries(cat Catalogue) []SelectionEntry {
+	var found []SelectionEntry
+	containers := [][]SelectionEntry{cat.SelectionEntries, cat.EntryLinks, cat.SharedEntries}
+	for _, container := range containers {
+		for _, entry := range container {
+			found = append(found, c.resolveEntry(entry))
+		}
+	}
+	return found
+}
+
+// factionGroups returns the catalogue's shared groups plus any group linked
+// from a root entry (the "Battle Formation" and lore selectors), de-duplicated
+// by ID.
+func (c *Converter) factionGroups(cat Catalogue) []SelectionEntry {
+	var found []SelectionEntry
+	seen := make(map[string]bool)
+	add := func(group SelectionEntry) {
+		if group.ID != "" && seen[group.ID] {
+			return
+		}
+		seen[group.ID] = true
+		found = append(found, group)
+	}
+
+	for _, group := range cat.SharedGroups {
+		add(group)
+	}
+	for _, entry := range append(cat.SelectionEntries, cat.EntryLinks...) {
+		for _, link := range entry.LinkEntries {
+			if link.Type == "selectionEntryGroup" {
+				add(c.resolveEntry(link))
+			}
+		}
+		for _, group := range entry.SelectionEntryGroups {
+			add(group)
+		}
+	}
+	return found
+}
+
+// resolveEntry follows an entryLink to the entry or group it targets.
+func (c *Converter) resolveEntry(entry SelectionEntry) SelectionEntry {
+	if entry.TargetID == "" {
+		return entry
+	}
+	target, ok := c.MasterEntries[entry.TargetID]
+	if !ok {
+		return entry
+	}
+	if target.Name == "" {
+		target.Name = entry.Name
+	}
+	return target
+}
+
+// groupEntries returns the selectable entries of a group, with links resolved.
+func (c *Converter) groupEntries(group SelectionEntry) []SelectionEntry {
+	var found []SelectionEntry
+	found = append(found, group.ChildEntries...)
+	for _, link := range group.LinkEntries {
+		if link.Type != "selectionEntryGroup" {
+			found = append(found, c.resolveEntry(link))
+		}
+	}
+	return found
+}
+
+// collectEnhancements walks an enhancement group and its sub groups. The
+// nearest "Enhancement Restrictions" rule applies to every upgrade below it.
+func (c *Converter) collectEnhancements(group SelectionEntry, enhancementType, restrictions string) []models.EnhancementSeed {
+	restrictions = enhancementRestrictions(group, restrictions)
+
+	var found []models.EnhancementSeed
+	for _, entry := range c.groupEntries(group) {
+		entryRestrictions := enhancementRestrictions(entry, restrictions)
+		found = append(found, models.EnhancementSeed{
+			Name:             entry.Name,
+			BattlescribeID:   battlescribeID(entry),
+			EnhancementType:  enhancementType,
+			Description:      c.describeAbilities(entry),
+			Restrictions:     cleanText(entryRestrictions),
+			RequiredKeywords: c.restrictionKeywords(entryRestrictions),
+			Points:           c.parsePoints(entry.Costs),
+			IsUnique:         isOncePerArmy(entry.Constraints),
+		})
+	}
+	for _, sub := range group.SelectionEntryGroups {
+		found = append(found, c.collectEnhancements(sub, enhancementType, restrictions)...)
+	}
+	return found
+}
+
+// enhancementRestrictions returns the raw rule text, keyword markers included.
+func enhancementRestrictions(entry SelectionEntry, inherited string) string {
+	for _, rule := range entry.Rules {
+		if rule.Name == "Enhancement Restrictions" {
+			return rule.Description
+		}
+	}
+	return inherited
+}
+
+// restrictionKeywords turns the ^^keyword^^ phrases of a restriction into
+// keyword groups; a bearer needs every keyword of at least one group. A phrase
+// such as "Ogor Mawtribes Heroes" names several keywords, so it is split
+// against the keywords known from the catalogues. Phrases that cannot be
+// split that way are dropped.
+func (c *Converter) restrictionKeywords(text string) [][]string {
+	var groups [][]string
+	for _, m := range keywordMarker.FindAllStringSubmatch(text, -1) {
+		group := c.splitKeywords(strings.Fields(strings.ToUpper(m[1])))
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// splitKeywords matches the longest known keyword at the start of words, also
+// trying the singular of a plural ("HEROES", "MONSTERS"), and recurses on the
+// rest.
+func (c *Converter) splitKeywords(words []string) []string {
+	if len(words) == 0 {
+		return nil
+	}
+
+	for end := len(words); end > 0; end-- {
+		phrase := strings.Join(words[:end], " ")
+		keyword, ok := c.knownKeyword(phrase)
+		if !ok {
+			continue
+		}
+		if end == len(words) {
+			return []string{keyword}
+		}
+		rest := c.splitKeywords(words[end:])
+		if rest != nil {
+			return append([]string{keyword}, rest...)
+		}
+	}
+
+	return nil
+}
+
+func (c *Converter) knownKeyword(phrase string) (string, bool) {
+	candidates := []string{phrase, strings.TrimSuffix(phrase, "ES"), strings.TrimSuffix(phrase, "S")}
+	for _, candidate := range candidates {
+		if c.KeywordNames[candidate] {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// isOncePerArmy reports whether an entry is limited to one selection per roster.
+func isOncePerArmy(constraints []Constraint) bool {
+	for _, cons := range constraints {
+		if cons.Type == "max" && cons.Value == "1" && cons.Field == "selections" && cons.Scope == "roster" {
+			return true
+		}
+	}
+	return false
+}
+
+// loreSpells returns the spell and prayer profiles of a lore. Lores link to a
+// group in the shared Lores catalogue; the manifestation warscrolls linked
+// from the same group carry their own abilities, which are skipped.
+func (c *Converter) loreSpells(lore SelectionEntry) []Profile {
+	var found []Profile
+	for _, link := range lore.LinkEntries {
+		if link.Type != "selectionEntryGroup" {
+			continue
+		}
+		group := c.resolveEntry(link)
+		for _, entry := range c.groupEntries(group) {
+			if entry.Type == "unit" || entry.Type == "model" {
+				continue
+			}
+			for _, p := range c.collectAbilityProfiles(entry) {
+				t := abilityType(p.TypeName)
+				if t == "Spell" || t == "Prayer" {
+					found = append(found, p)
+				}
+			}
+		}
+	}
+	return found
+}
+
+// describeAbilities joins the ability profiles of an entry into one
+// description, prefixing each with its name when there is more than one.
+func (c *Converter) describeAbilities(entry SelectionEntry) string {
+	var abilities []models.AbilitySeed
+	for _, p := range c.collectAbilityProfiles(entry) {
+		if isAbilityProfile(p) {
+			abilities = append(abilities, c.mapAbility(p))
+		}
+	}
+
+	if len(abilities) == 1 {
+		return abilities[0].Description
+	}
+
+	var parts []string
+	for _, a := range abilities {
+		parts = append(parts, a.Name+"\n"+a.Description)
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/battlescribe"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleProfileTypes maps game system profile types that describe rules rather
+// than abilities to the rule_type stored on the rule.
+var RuleProfileTypes = map[string]string{
+	"Battle Tactic Card": "battle_tactic",
+	"Grand Strategy":     "grand_strategy",
+}
+
+// processGameSystem writes the core rules, battle tactics and universal
+// abilities (Fly, Ward Save, ...) of a game system to <game_slug>/core_rules.yaml.
+func (c *Converter) processGameSystem(gs GameSystem, outDir string) {
+	gameSlug := strings.ToLower(strings.ReplaceAll(gs.Name, " ", "_"))
+
+	seed := models.SeedData{
+		GameName:           gs.Name,
+		Source:             "Battlescribe Data",
+		Factions:           []models.FactionSeed{},
+		BattlescribeID:     gs.ID,
+		BattlescribeForces: battlescribeForces(gs),
+	}
+
+	for _, r := range gs.SharedRules {
+		text := cleanText(r.Description)
+		if text == "" {
+			continue
+		}
+		seed.Rules = append(seed.Rules, models.RuleSeed{
+			Name:        r.Name,
+			RuleType:    "core",
+			Description: firstSentence(text),
+			Text:        text,
+		})
+	}
+
+	var profiles []Profile
+	for _, entry := range append(gs.SharedEntries, gs.SelectionEntries...) {
+		profiles = append(profiles, c.collectProfiles(entry)...)
+	}
+	seenRules := make(map[string]bool)
+	for _, p := range profiles {
+		ruleType, ok := RuleProfileTypes[p.TypeName]
+		if !ok || seenRules[p.Name] {
+			continue
+		}
+		seenRules[p.Name] = true
+		seed.Rules = append(seed.Rules, mapRuleProfile(p, ruleType))
+	}
+
+	seenAbilities := make(map[string]bool)
+	for _, p := range gs.SharedProfiles {
+		if !isAbilityProfile(p) || seenAbilities[p.Name] {
+			continue
+		}
+		seenAbilities[p.Name] = true
+		seed.GameAbilities = append(seed.GameAbilities, c.mapAbility(p))
+	}
+
+	finalOutDir := filepath.Join(filepath.Clean(outDir), filepath.Clean(gameSlug))
+	err := os.MkdirAll(finalOutDir, 0o750)
+	if err != nil {
+		fmt.Printf("Failed to create directory %s: %v\n", finalOutDir, err)
+		return
+	}
+
+	outPath := filepath.Join(finalOutDir, "core_rules.yaml")
+	yamlData, err := yaml.Marshal(seed)
+	if err != nil {
+		fmt.Printf("Error marshaling YAML for %s: %v\n", gs.Name, err)
+		return
+	}
+	err = os.WriteFile(outPath, yamlData, 0o600)
+	if err != nil {
+		fmt.Printf("Failed to write YAML for %s: %v\n", gs.Name, err)
+		return
+	}
+	fmt.Printf(" -> Saved to %s (%d rules, %d abilities)\n", outPath, len(seed.Rules), len(seed.GameAbilities))
+}
+
+// mapRuleProfile turns a rule-like profile (a battle tactic card) into a rule,
+// one "Characteristic: text" line per non-empty characteristic. The
+// description lists the heading of each line, e.g. the three tactic names on a
+// battle tactic card.
+func mapRuleProfile(p Profile, ruleType string) models.RuleSeed {
+	var lines, headings []string
+	for _, char := range p.Characteristics {
+		value := cleanText(char.Value)
+		if value == "" {
+			continue
+		}
+		lines = append(lines, char.Name+": "+value)
+
+		heading, _, _ := strings.Cut(value, "\n")
+		headings = append(headings, strings.TrimSuffix(strings.TrimSpace(heading), ":"))
+	}
+
+	return models.RuleSeed{
+		Name:        p.Name,
+		RuleType:    ruleType,
+		Description: strings.Join(headings, ", "),
+		Text:        strings.Join(lines, "\n"),
+	}
+}
+
+// firstSentence returns the text up to its first full stop or line break.
+func firstSentence(text string) string {
+	text, _, _ = strings.Cut(text, "\n")
+	i := strings.Index(text, ". ")
+	if i < 0 {
+		return text
+	}
+	return text[:i+1]
+}
+
+// battlescribeForces picks the force entries rosters of the game are built
+// from: the first visible force that adds other forces, and the regiment and
+// auxiliary forces it adds.
+func battlescribeForces(gs GameSystem) map[string]string {
+	var armies []ForceEntry
+	for _, force := range gs.ForceEntries {
+		if !force.Hidden {
+			armies = append(armies, force)
+		}
+	}
+	if len(armies) == 0 {
+		return nil
+	}
+
+	// Forces without a sort index sort last, as they do in BattleScribe.
+	sortIndex := func(f ForceEntry) int {
+		if f.SortIndex == 0 {
+			return int(^uint(0) >> 1)
+		}
+		return f.SortIndex
+	}
+	sort.SliceStable(armies, func(i, j int) bool {
+		ci, cj := len(armies[i].ForceEntryLinks) > 0, len(armies[j].ForceEntryLinks) > 0
+		if ci != cj {
+			return ci
+		}
+		return sortIndex(armies[i]) < sortIndex(armies[j])
+	})
+
+	army := armies[0]
+	forces := map[string]string{battlescribe.ForceArmy: army.ID}
+	for _, link := range army.ForceEntryLinks {
+		name := strings.ToLower(link.Name)
+		switch {
+		case strings.Contains(name, "regiment") && !strings.Contains(name, "renown"):
+			forces[battlescribe.ForceRegiment] = link.TargetID
+		case strings.HasPrefix(name, "auxil"):
+			// The data spells it "Auxillary Units".
+			forces[battlescribe.ForceAuxiliary] = link.TargetID
+		}
+	}
+	return forces
+}
@@ -3,9 +3,11 @@ package main
 import (
 	"encoding/xml"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/JohnG-Dev/army_builder_api/internal/models"
@@ -23,6 +25,12 @@ var StatMapping = map[string]string{
 	"Strength": "strength", "S": "strength",
 }
 
+// GameSystemRegistry names the BattleScribe game systems we know about, so a
+// catalogue can still be converted when its .gst is not present in data/raw.
+var GameSystemRegistry = map[string]string{
+	"e51d-b1a3-75fc-dc3g": "Age of Sigmar 4.0",
+}
+
 var AllegianceMap = map[string]string{
 	"STORMCAST": "ORDER", "CITIES": "ORDER", "DAUGHTERS": "ORDER", "FYRESLAYERS": "ORDER", "IDONETH": "ORDER", "KHARADRON": "ORDER", "LUMINETH": "ORDER", "SERAPHON": "ORDER", "SYLVANETH": "ORDER",
 	"KHORNE": "CHAOS", "TZEENTCH": "CHAOS", "NURGLE": "CHAOS", "SLAANESH": "CHAOS", "SKAVEN": "CHAOS", "SLAVES": "CHAOS", "BEASTS": "CHAOS", "HELSMITHS": "CHAOS",
@@ -30,35 +38,72 @@ var AllegianceMap = map[string]string{
 	"GLOOMSPITE": "DESTRUCTION", "IRONJAWZ": "DESTRUCTION", "KRULEBOYZ": "DESTRUCTION", "OGOR": "DESTRUCTION", "SONS": "DESTRUCTION", "ORRUK": "DESTRUCTION", "BONESPLITTERZ": "DESTRUCTION",
 }
 
+// AbilityPhases maps the phase names used in BattleScribe "Timing" text to the
+// phase stored on abilities. Order matters: the first match wins.
+var AbilityPhases = []struct {
+	Match string
+	Phase string
+}{
+	{"Deployment", "Deployment"},
+	{"Start of Battle Round", "Start of Battle Round"},
+	{"Start of", "Start of Turn"},
+	{"Hero Phase", "Hero"},
+	{"Movement Phase", "Movement"},
+	{"Shooting Phase", "Shooting"},
+	{"Charge Phase", "Charge"},
+	{"Combat Phase", "Combat"},
+	{"End of", "End of Turn"},
+	{"Reaction", "Reaction"},
+}
+
 type Converter struct {
-	MasterEntries map[string]SelectionEntry
-	GameSystems   map[string]string
+	MasterEntries  map[string]SelectionEntry
+	MasterProfiles map[string]Profile
+	Categories     map[string]string
+	KeywordNames   map[string]bool
+	GameSystems    map[string]string
+	CatalogueNames map[string]string
+	ForceEntries   map[string]ForceEntry
+	GameMappings   map[string]GameMapping
+	Weapons        WeaponMapping
+	Unmapped       map[string]int
 }
 
 func main() {
-	rawDir := "./data/raw"
-	outDir := "./data/factions"
+	if err := run("./data/raw", "./data/factions"); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	allFiles, err := os.ReadDir(rawDir)
+// run converts every catalogue under rawDir into seed YAML under outDir.
+func run(rawDir, outDir string) error {
+	allFiles, err := findRawFiles(rawDir)
 	if err != nil {
-		log.Fatalf("failed to read directory: %v", err)
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	gameMappings, err := loadGameMappings()
+	if err != nil {
+		return fmt.Errorf("failed to load game mappings: %w", err)
 	}
 
 	cv := &Converter{
-		MasterEntries: make(map[string]SelectionEntry),
-		GameSystems:   make(map[string]string),
+		MasterEntries:  make(map[string]SelectionEntry),
+		MasterProfiles: make(map[string]Profile),
+		Categories:     make(map[string]string),
+		KeywordNames:   make(map[string]bool),
+		GameSystems:    make(map[string]string),
+		CatalogueNames: make(map[string]string),
+		ForceEntries:   make(map[string]ForceEntry),
+		GameMappings:   gameMappings,
+		Unmapped:       make(map[string]int),
 	}
 
 	// --- PASS 1: INDEXING ---
-	for _, file := range allFiles {
-		name := file.Name()
-		if !strings.HasSuffix(name, ".cat") && !strings.HasSuffix(name, ".gst") {
-			continue
-		}
+	var gameSystems []GameSystem
+	for _, filePath := range allFiles {
+		name := filepath.Base(filePath)
 
-		cleanRawDir := filepath.Clean(rawDir)
-		cleanName := filepath.Clean(name)
-		filePath := filepath.Join(cleanRawDir, cleanName)
 		xmlData, err := os.ReadFile(filePath)
 		if err != nil {
 			fmt.Printf("Error reading %s for indexing: %v\n", name, err)
@@ -68,10 +113,14 @@ func main() {
 		if strings.HasSuffix(name, ".gst") {
 			var gs GameSystem
 			err = xml.Unmarshal(xmlData, &gs)
-			if err == nil {
-				cv.GameSystems[gs.ID] = gs.Name
-				cv.indexGameSystem(gs)
+			if err != nil {
+				fmt.Printf("Unmarshal error in game system %s: %v\n", name, err)
+				continue
 			}
+			cv.GameSystems[gs.ID] = gs.Name
+			cv.indexGameSystem(gs)
+			gameSystems = append(gameSystems, gs)
+			fmt.Printf("Found Game System: %s (%s)\n", gs.Name, gs.ID)
 		} else {
 			var catalogue Catalogue
 			err = xml.Unmarshal(xmlData, &catalogue)
@@ -81,18 +130,23 @@ func main() {
 		}
 	}
 
-	fmt.Printf("Indexing Complete. Master Map contains %d entries\n", len(cv.MasterEntries))
+	fmt.Printf("Indexing Complete. Master Map contains %d entries and %d profiles\n", len(cv.MasterEntries), len(cv.MasterProfiles))
 
 	// --- PASS 2: CONVERSION ---
-	for _, file := range allFiles {
-		name := file.Name()
+	for _, gs := range gameSystems {
+		fmt.Printf("Converting Game System: %s\n", gs.Name)
+		cv.processGameSystem(gs, outDir)
+	}
+
+	// Catalogues whose game system cannot be resolved are reported together
+	// once the rest are converted, and fail the run.
+	var unresolved []string
+	for _, filePath := range allFiles {
+		name := filepath.Base(filePath)
 		if strings.Contains(name, "Library") || !strings.HasSuffix(name, ".cat") {
 			continue
 		}
 
-		cleanRawDir := filepath.Clean(rawDir)
-		cleanFileName := filepath.Clean(name)
-		filePath := filepath.Join(cleanRawDir, cleanFileName)
 		xmlData, err := os.ReadFile(filePath)
 		if err != nil {
 			fmt.Printf("Error reading %s for conversion: %v\n", name, err)
@@ -106,11 +160,14 @@ func main() {
 			continue
 		}
 
-		gameName := cv.GameSystems[catalogue.GameSystemID]
-		if gameName == "" {
-			gameName = "Unknown Game"
+		gameName, err := cv.resolveGameName(catalogue.GameSystemID)
+		if err != nil {
+			fmt.Printf("Skipping %s: %v\n", name, err)
+			unresolved = append(unresolved, name)
+			continue
 		}
 		gameSlug := strings.ToLower(strings.ReplaceAll(gameName, " ", "_"))
+		cv.Weapons = cv.weaponMappingFor(gameSlug)
 
 		subFolder := "standard"
 		isAoR := false
@@ -141,16 +198,13 @@ func main() {
 				IsRegimentOfRenown: isRoR,
 				ParentFactionName:  parentName,
 				Units:              []models.UnitSeed{},
+				BattlescribeID:     catalogue.ID,
 			}},
 		}
 
-		upperName := strings.ToUpper(catalogue.Name)
-		for key, allegiance := range AllegianceMap {
-			if strings.Contains(upperName, key) {
-				seed.Factions[0].Allegiance = allegiance
-				break
-			}
-		}
+		seed.Factions[0].Allegiance = allegianceFor(catalogue.Name)
+
+		cv.collectFactionRules(catalogue, &seed.Factions[0])
 
 		var allUnits []SelectionEntry
 		allUnits = cv.findUnits(catalogue.SelectionEntries, allUnits)
@@ -163,14 +217,26 @@ func main() {
 			uniqueUnits[u.Name] = u
 		}
 
-		for _, entry := range uniqueUnits {
-			unit := cv.transformUnit(entry, name)
+		// Units are written in name order so regenerating the seed data
+		// only shows real changes.
+		unitNames := make([]string, 0, len(uniqueUnits))
+		for unitName := range uniqueUnits {
+			unitNames = append(unitNames, unitName)
+		}
+		sort.Strings(unitNames)
+
+		for _, unitName := range unitNames {
+			unit := cv.transformUnit(uniqueUnits[unitName], name)
 			if seed.Factions[0].Allegiance != "" {
 				unit.Keywords = append(unit.Keywords, seed.Factions[0].Allegiance)
 			}
 			seed.Factions[0].Units = append(seed.Factions[0].Units, unit)
 		}
 
+		if isAoR {
+			seed.Factions[0].AllowedUnits = allowedUnits(uniqueUnits)
+		}
+
 		factionSlug := strings.ToLower(strings.ReplaceAll(catalogue.Name, " ", "_"))
 		factionSlug = strings.ReplaceAll(factionSlug, "۞_", "")
 
@@ -197,11 +263,63 @@ func main() {
 		}
 		fmt.Printf(" -> Saved to %s (%d units)\n", outPath, len(seed.Factions[0].Units))
 	}
+
+	cv.reportUnmapped()
+
+	if len(unresolved) > 0 {
+		return fmt.Errorf("no game system found for %s", strings.Join(unresolved, ", "))
+	}
+	return nil
+}
+
+// findRawFiles walks rawDir recursively and returns every .cat and .gst file,
+// so game systems kept in a sub folder are still indexed.
+func findRawFiles(rawDir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(filepath.Clean(rawDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(path, ".cat") || strings.HasSuffix(path, ".gst") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// resolveGameName looks up a catalogue's gameSystemId, first in the game
+// systems indexed from .gst files and then in GameSystemRegistry.
+func (c *Converter) resolveGameName(gameSystemID string) (string, error) {
+	if gameSystemID == "" {
+		return "", fmt.Errorf("catalogue has no gameSystemId")
+	}
+
+	name, ok := c.GameSystems[gameSystemID]
+	if ok && name != "" {
+		return name, nil
+	}
+
+	name, ok = GameSystemRegistry[gameSystemID]
+	if ok {
+		return name, nil
+	}
+
+	return "", fmt.Errorf("unknown game system %s: add its .gst under data/raw or register it in GameSystemRegistry", gameSystemID)
 }
 
 func (c *Converter) transformUnit(entry SelectionEntry, fileName string) models.UnitSeed {
 	unit := models.UnitSeed{
 		Name:            entry.Name,
+		BattlescribeID:  battlescribeID(entry),
 		MatchedPlay:     true,
 		Source:          "Battlescribe Data",
 		AdditionalStats: make(map[string]string),
@@ -223,23 +341,57 @@ func (c *Converter) transformUnit(entry SelectionEntry, fileName string) models.
 	c.processConstraints(allConstraints, &unit)
 
 	if c.canBeReinforced(entry) {
+		unit.CanBeReinforced = true
 		unit.MaxUnitSize = unit.MinUnitSize * 2
 	}
 
 	c.collectKeywords(entry, &unit)
+	c.collectRegimentOptions(entry, &unit)
 
 	allProfiles := c.collectProfiles(entry)
 	for _, p := range allProfiles {
 		if strings.Contains(p.TypeName, "Unit") || strings.Contains(p.TypeName, "Model") || strings.Contains(p.TypeName, "Stats") {
 			c.mapStats(p.Characteristics, &unit)
-		} else if strings.Contains(p.TypeName, "Weapon") {
-			unit.Weapons = append(unit.Weapons, c.mapWeapon(p))
+		} else if weaponType, ok := c.weaponType(p); ok {
+			unit.Weapons = append(unit.Weapons, c.mapWeapon(p, weaponType))
 		}
 	}
 
+	seenAbilities := make(map[string]bool)
+	for _, p := range c.collectAbilityProfiles(entry) {
+		if !isAbilityProfile(p) || seenAbilities[p.Name] {
+			continue
+		}
+		seenAbilities[p.Name] = true
+		unit.Abilities = append(unit.Abilities, c.mapAbility(p))
+	}
+
 	return unit
 }
 
+// battlescribeID returns the ID of the entry a selection refers to. Links are
+// keyed by their target so the ID stays stable across catalogue releases.
+func battlescribeID(entry SelectionEntry) string {
+	if entry.TargetID != "" {
+		return entry.TargetID
+	}
+	return entry.ID
+}
+
+// allowedUnits lists the units an Army of Renown catalogue links in. Those
+// links point at the parent faction's entries, so the IDs match its units.
+func allowedUnits(units map[string]SelectionEntry) []models.AllowedUnitSeed {
+	allowed := make([]models.AllowedUnitSeed, 0, len(units))
+	for _, entry := range units {
+		allowed = append(allowed, models.AllowedUnitSeed{
+			Name:           entry.Name,
+			BattlescribeID: battlescribeID(entry),
+		})
+	}
+	sort.Slice(allowed, func(i, j int) bool { return allowed[i].Name < allowed[j].Name })
+	return allowed
+}
+
 func (c *Converter) processConstraints(constraints []Constraint, unit *models.UnitSeed) {
 	for _, cons := range constraints {
 		var val int
@@ -326,25 +478,85 @@ func (c *Converter) mapStats(chars []Characteristic, unit *models.UnitSeed) {
 	}
 }
 
-func (c *Converter) mapWeapon(p Profile) models.WeaponSeed {
-	w := models.WeaponSeed{Name: p.Name}
+func (c *Converter) mapAbility(p Profile) models.AbilitySeed {
+	a := models.AbilitySeed{
+		Name: p.Name,
+		Type: abilityType(p.TypeName),
+	}
+
+	var timing, value, declare, effect string
 	for _, char := range p.Characteristics {
 		switch char.Name {
-		case "Range", "Rng":
-			w.Range = char.Value
-		case "Attacks", "A", "Atk":
-			w.Attacks = char.Value
-		case "To Hit", "WS", "Hit":
-			w.ToHit = char.Value
-		case "To Wound", "S", "Wnd":
-			w.ToWound = char.Value
-		case "Rend", "AP", "Rnd":
-			w.Rend = char.Value
-		case "Damage", "D", "Dmg":
-			w.Damage = char.Value
+		case "Timing":
+			timing = cleanText(char.Value)
+		case "Casting Value", "Chanting Value", "Cost":
+			value = char.Name + ": " + cleanText(char.Value)
+		case "Declare":
+			declare = cleanText(char.Value)
+		case "Effect":
+			effect = cleanText(char.Value)
+		}
+	}
+
+	a.Phase = abilityPhase(a.Type, timing)
+
+	var lines []string
+	if timing != "" {
+		lines = append(lines, "Timing: "+timing)
+	}
+	if value != "" && !strings.HasSuffix(value, ": ") {
+		lines = append(lines, value)
+	}
+	if declare != "" {
+		lines = append(lines, "Declare: "+declare)
+	}
+	if effect != "" {
+		if len(lines) > 0 {
+			effect = "Effect: " + effect
 		}
+		lines = append(lines, effect)
 	}
-	return w
+	a.Description = strings.Join(lines, "\n")
+
+	return a
+}
+
+func isAbilityProfile(p Profile) bool {
+	return strings.HasPrefix(p.TypeName, "Ability")
+}
+
+// abilityType turns "Ability (Spell)" into "Spell".
+func abilityType(typeName string) string {
+	t := strings.TrimPrefix(typeName, "Ability")
+	t = strings.TrimSpace(t)
+	t = strings.TrimPrefix(t, "(")
+	t = strings.TrimSuffix(t, ")")
+	if t == "" {
+		return "Passive"
+	}
+	return t
+}
+
+func abilityPhase(abilityType, timing string) string {
+	if timing == "" || timing == "Passive" {
+		if abilityType == "Passive" {
+			return "Passive"
+		}
+		return timing
+	}
+	for _, p := range AbilityPhases {
+		if strings.Contains(timing, p.Match) {
+			return p.Phase
+		}
+	}
+	return timing
+}
+
+// cleanText strips the BattleScribe bold (**) and keyword (^^) markers.
+func cleanText(s string) string {
+	s = strings.ReplaceAll(s, "**", "")
+	s = strings.ReplaceAll(s, "^^", "")
+	return strings.TrimSpace(s)
 }
 
 func (c *Converter) isUnit(entry SelectionEntry) bool {
@@ -390,6 +602,7 @@ func (c *Converter) findUnits(entries []SelectionEntry, found []SelectionEntry)
 func (c *Converter) collectProfiles(entry SelectionEntry) []Profile {
 	var found []Profile
 	found = append(found, entry.Profiles...)
+	found = append(found, c.resolveInfoLinks(entry)...)
 	if entry.TargetID != "" {
 		target, ok := c.MasterEntries[entry.TargetID]
 		if ok {
@@ -405,6 +618,49 @@ func (c *Converter) collectProfiles(entry SelectionEntry) []Profile {
 	return found
 }
 
+// collectAbilityProfiles walks the same tree as collectProfiles but does not
+// follow links to selection entry groups. Those groups are option pools shared
+// by many units (Heroic Traits, Artefacts of Power, Path to Glory paths) and
+// their profiles are not abilities of the unit itself.
+func (c *Converter) collectAbilityProfiles(entry SelectionEntry) []Profile {
+	var found []Profile
+	found = append(found, entry.Profiles...)
+	found = append(found, c.resolveInfoLinks(entry)...)
+	if entry.TargetID != "" && entry.Type != "selectionEntryGroup" {
+		target, ok := c.MasterEntries[entry.TargetID]
+		if ok {
+			found = append(found, c.collectAbilityProfiles(target)...)
+		}
+	}
+	for _, child := range entry.ChildEntries {
+		found = append(found, c.collectAbilityProfiles(child)...)
+	}
+	for _, group := range entry.SelectionEntryGroups {
+		found = append(found, c.collectAbilityProfiles(group)...)
+	}
+	for _, link := range entry.LinkEntries {
+		if link.Type == "selectionEntryGroup" {
+			continue
+		}
+		found = append(found, c.collectAbilityProfiles(link)...)
+	}
+	return found
+}
+
+func (c *Converter) resolveInfoLinks(entry SelectionEntry) []Profile {
+	var found []Profile
+	for _, link := range entry.InfoLinks {
+		if link.Type != "profile" {
+			continue
+		}
+		p, ok := c.MasterProfiles[link.TargetID]
+		if ok {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
 func (c *Converter) collectConstraints(entry SelectionEntry) []Constraint {
 	var found []Constraint
 	found = append(found, entry.Constraints...)
@@ -429,6 +685,7 @@ func (c *Converter) indexEntry(entry SelectionEntry) {
 	if entry.ID != "" {
 		c.MasterEntries[entry.ID] = entry
 	}
+	c.indexProfiles(entry.Profiles)
 	for _, child := range entry.ChildEntries {
 		c.indexEntry(child)
 	}
@@ -440,16 +697,41 @@ func (c *Converter) indexEntry(entry SelectionEntry) {
 	}
 }
 
+func (c *Converter) indexProfiles(profiles []Profile) {
+	for _, p := range profiles {
+		if p.ID != "" {
+			c.MasterProfiles[p.ID] = p
+		}
+	}
+}
+
+func (c *Converter) indexCategories(categories []Category) {
+	for _, cat := range categories {
+		if cat.ID != "" {
+			c.Categories[cat.ID] = cat.Name
+		}
+		c.KeywordNames[strings.ToUpper(cat.Name)] = true
+	}
+}
+
 func (c *Converter) indexGameSystem(gs GameSystem) {
+	c.indexProfiles(gs.SharedProfiles)
+	c.indexCategories(gs.CategoryEntries)
 	for _, entry := range gs.SharedEntries {
 		c.indexEntry(entry)
 	}
 	for _, group := range gs.SharedGroups {
 		c.indexEntry(group)
 	}
+	for _, force := range gs.ForceEntries {
+		c.ForceEntries[forceKey(force.Name)] = force
+	}
 }
 
 func (c *Converter) indexCatalogue(cat Catalogue) {
+	c.CatalogueNames[cat.ID] = cat.Name
+	c.indexProfiles(cat.SharedProfiles)
+	c.indexCategories(cat.CategoryEntries)
 	containers := [][]SelectionEntry{cat.SelectionEntries, cat.EntryLinks, cat.SharedEntries, cat.SharedGroups}
 	for _, container := range containers {
 		for _, entry := range container {
@@ -544,6 +826,7 @@ func (c *Converter) processRoRFile(cat Catalogue, gameName, outDir string) {
 				Source:             "Battlescribe Data",
 				IsRegimentOfRenown: true,
 				Units:              []models.UnitSeed{},
+				BattlescribeID:     cat.ID,
 			}},
 		}
 
@@ -554,12 +837,25 @@ func (c *Converter) processRoRFile(cat Catalogue, gameName, outDir string) {
 			}
 		}
 
-		var rorUnits []SelectionEntry
-		rorUnits = c.findUnits(actualEntry.ChildEntries, rorUnits)
-		rorUnits = c.findUnits(actualEntry.LinkEntries, rorUnits)
-		rorUnits = c.findUnits(actualEntry.SelectionEntryGroups, rorUnits)
+		var units []SelectionEntry
+		units = c.findUnits(actualEntry.ChildEntries, units)
+		units = c.findUnits(actualEntry.LinkEntries, units)
+		units = c.findUnits(actualEntry.SelectionEntryGroups, units)
+
+		// The points, hosts and units of the regiment hang off its force
+		// entry in the .gst rather than off the catalogue entry.
+		force, ok := c.ForceEntries[forceKey(regimentName)]
+		if ok {
+			seed.Factions[0].Points = c.parsePoints(force.Costs)
+			seed.Factions[0].BattlescribeForceID = force.ID
+			seed.Factions[0].HireableBy = c.rorHosts(force)
+			if seed.Factions[0].Allegiance == "" {
+				seed.Factions[0].Allegiance = sharedAllegiance(seed.Factions[0].HireableBy)
+			}
+			units = append(units, rorUnits(cat.EntryLinks, force.ID)...)
+		}
 
-		for _, uEntry := range rorUnits {
+		for _, uEntry := range units {
 			unit := c.transformUnit(uEntry, "Regiments of Renown")
 			if seed.Factions[0].Allegiance != "" {
 				unit.Keywords = append(unit.Keywords, seed.Factions[0].Allegiance)
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMapAbility(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  Profile
+		expected models.AbilitySeed
+	}{
+		{
+			name: "passive",
+			profile: Profile{Name: "Beast", TypeName: "Ability (Passive)", Characteristics: []Characteristic{
+				{Name: "Keywords", Value: ""},
+				{Name: "Effect", Value: "This unit has a maximum **control score** of 1."},
+			}},
+			expected: models.AbilitySeed{
+				Name:        "Beast",
+				Description: "This unit has a maximum control score of 1.",
+				Type:        "Passive",
+				Phase:       "Passive",
+			},
+		},
+		{
+			name: "spell",
+			profile: Profile{Name: "Lightning Blast", TypeName: "Ability (Spell)", Characteristics: []Characteristic{
+				{Name: "Timing", Value: "Your Hero Phase"},
+				{Name: "Casting Value", Value: "7"},
+				{Name: "Declare", Value: "Pick a visible enemy unit within 12\" of this ^^**Wizard**^^ to be the target."},
+				{Name: "Effect", Value: "Inflict D3 mortal damage on the target."},
+			}},
+			expected: models.AbilitySeed{
+				Name:        "Lightning Blast",
+				Description: "Timing: Your Hero Phase\nCasting Value: 7\nDeclare: Pick a visible enemy unit within 12\" of this Wizard to be the target.\nEffect: Inflict D3 mortal damage on the target.",
+				Type:        "Spell",
+				Phase:       "Hero",
+			},
+		},
+		{
+			name: "prayer without chanting value",
+			profile: Profile{Name: "Shield of Faith", TypeName: "Ability (Prayer)", Characteristics: []Characteristic{
+				{Name: "Timing", Value: "Your Hero Phase"},
+				{Name: "Chanting Value", Value: ""},
+				{Name: "Effect", Value: "Add 1 to ward rolls for this unit."},
+			}},
+			expected: models.AbilitySeed{
+				Name:        "Shield of Faith",
+				Description: "Timing: Your Hero Phase\nEffect: Add 1 to ward rolls for this unit.",
+				Type:        "Prayer",
+				Phase:       "Hero",
+			},
+		},
+		{
+			name: "activated",
+			profile: Profile{Name: "Thunderous Pounce", TypeName: "Ability (Activated)", Characteristics: []Characteristic{
+				{Name: "Timing", Value: "Any Charge Phase"},
+				{Name: "Effect", Value: "Roll a dice for each enemy unit within 1\" of this unit."},
+			}},
+			expected: models.AbilitySeed{
+				Name:        "Thunderous Pounce",
+				Description: "Timing: Any Charge Phase\nEffect: Roll a dice for each enemy unit within 1\" of this unit.",
+				Type:        "Activated",
+				Phase:       "Charge",
+			},
+		},
+		{
+			name:     "untyped ability",
+			profile:  Profile{Name: "Unnamed", TypeName: "Ability", Characteristics: []Characteristic{{Name: "Effect", Value: "Text."}}},
+			expected: models.AbilitySeed{Name: "Unnamed", Description: "Text.", Type: "Passive", Phase: "Passive"},
+		},
+	}
+
+	c := &Converter{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.mapAbility(tt.profile)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAbilityPhase(t *testing.T) {
+	tests := []struct {
+		abilityType string
+		timing      string
+		expected    string
+	}{
+		{"Passive", "", "Passive"},
+		{"Passive", "Passive", "Passive"},
+		{"Activated", "", ""},
+		{"Activated", "Deployment Phase", "Deployment"},
+		{"Activated", "Start of Battle Round", "Start of Battle Round"},
+		{"Activated", "Start of Any Turn", "Start of Turn"},
+		{"Spell", "Your Hero Phase", "Hero"},
+		{"Activated", "Enemy Movement Phase", "Movement"},
+		{"Activated", "Your Shooting Phase", "Shooting"},
+		{"Activated", "Any Charge Phase", "Charge"},
+		{"Activated", "Any Combat Phase", "Combat"},
+		{"Activated", "End of Your Turn", "End of Turn"},
+		{"Activated", "Reaction: You declared a Fight ability for this unit", "Reaction"},
+		{"Activated", "Once Per Battle, Any Combat Phase", "Combat"},
+		{"Activated", "Once Per Battle", "Once Per Battle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.abilityType+"/"+tt.timing, func(t *testing.T) {
+			got := abilityPhase(tt.abilityType, tt.timing)
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveGameName(t *testing.T) {
+	c := &Converter{GameSystems: map[string]string{"1234-abcd": "Warhammer 40,000 10th Edition"}}
+
+	tests := []struct {
+		id       string
+		expected string
+		wantErr  bool
+	}{
+		{id: "1234-abcd", expected: "Warhammer 40,000 10th Edition"},
+		{id: "e51d-b1a3-75fc-dc3g", expected: "Age of Sigmar 4.0"},
+		{id: "ffff-ffff-ffff-ffff", wantErr: true},
+		{id: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := c.resolveGameName(tt.id)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestSeedDataUpToDate converts data/raw again and checks the result matches
+// the seed data in data/factions, so a converter change cannot ship without
+// the regenerated YAML.
+func TestSeedDataUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("converts every catalogue")
+	}
+
+	const rawDir, shippedDir = "../../data/raw", "../../data/factions"
+	outDir := t.TempDir()
+	if err := run(rawDir, outDir); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	generated := readTree(t, outDir)
+	shipped := readTree(t, shippedDir)
+
+	for path, data := range generated {
+		old, ok := shipped[path]
+		if !ok {
+			t.Errorf("%s is missing from data/factions", path)
+			continue
+		}
+		if !bytes.Equal(old, data) {
+			t.Errorf("%s is out of date; rerun go run ./cmd/converter", path)
+		}
+	}
+	for path := range shipped {
+		if _, ok := generated[path]; !ok {
+			t.Errorf("%s is no longer generated", path)
+		}
+	}
+
+	var seed models.SeedData
+	if err := yaml.Unmarshal(shipped["age_of_sigmar_4.0/standard/stormcast_eternals.yaml"], &seed); err != nil {
+		t.Fatalf("unmarshal stormcast eternals: %v", err)
+	}
+	faction := seed.Factions[0]
+	if len(faction.BattleFormations) == 0 {
+		t.Error("expected stormcast eternals to have battle formations")
+	}
+	if len(faction.Enhancements) == 0 {
+		t.Error("expected stormcast eternals to have enhancements")
+	}
+
+	var withRegimentOptions, reinforceable int
+	for _, u := range faction.Units {
+		if len(u.RegimentOptions) > 0 {
+			withRegimentOptions++
+		}
+		if u.CanBeReinforced {
+			reinforceable++
+		}
+	}
+	if withRegimentOptions == 0 {
+		t.Error("expected stormcast eternals heroes to have regiment options")
+	}
+	if reinforceable == 0 {
+		t.Error("expected some stormcast eternals units to be reinforceable")
+	}
+
+	var armyOfRenown models.SeedData
+	if err := yaml.Unmarshal(shipped["age_of_sigmar_4.0/armies_of_renown/stormcast_eternals_-_draconith_skywing.yaml"], &armyOfRenown); err != nil {
+		t.Fatalf("unmarshal draconith skywing: %v", err)
+	}
+	if len(armyOfRenown.Factions[0].AllowedUnits) == 0 {
+		t.Error("expected draconith skywing to list its allowed units")
+	}
+
+	var regiment models.SeedData
+	if err := yaml.Unmarshal(shipped["age_of_sigmar_4.0/regiments_of_renown/the_blacktalons.yaml"], &regiment); err != nil {
+		t.Fatalf("unmarshal the blacktalons: %v", err)
+	}
+	if len(regiment.Factions[0].HireableBy) == 0 {
+		t.Error("expected the blacktalons to list the factions that can hire them")
+	}
+}
+
+// readTree reads every file under root, keyed by its slash separated path
+// relative to root.
+func readTree(t *testing.T, root string) map[string][]byte {
+	t.Helper()
+
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read %s: %v", root, err)
+	}
+	return files
+}
+
+func TestRunUnknownGameSystem(t *testing.T) {
+	rawDir := t.TempDir()
+	catalogues := map[string]string{
+		"Known.cat":   `<catalogue id="1111-2222" name="Known" gameSystemId="e51d-b1a3-75fc-dc3g"></catalogue>`,
+		"Unknown.cat": `<catalogue id="3333-4444" name="Unknown" gameSystemId="ffff-ffff-ffff-ffff"></catalogue>`,
+	}
+	for name, xml := range catalogues {
+		if err := os.WriteFile(filepath.Join(rawDir, name), []byte(xml), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	outDir := t.TempDir()
+	err := run(rawDir, outDir)
+	if err == nil {
+		t.Fatal("expected an error for a catalogue with an unknown game system")
+	}
+	if expected := "no game system found for Unknown.cat"; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	if _, err := os.Stat(filepath.Join(outDir, "age_of_sigmar_4.0", "standard", "known.yaml")); err != nil {
+		t.Errorf("expected the known catalogue to still be converted: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// mappingFiles holds one <game_slug>.yaml per game system describing how its
+// weapon profiles map onto models.WeaponSeed.
+//
+//go:embed mappings/*.yaml
+var mappingFiles embed.FS
+
+type GameMapping struct {
+	Weapons WeaponMapping `yaml:"weapons"`
+}
+
+type WeaponMapping struct {
+	Profiles        map[string]string `yaml:"profiles"`
+	Characteristics map[string]string `yaml:"characteristics"`
+}
+
+// DefaultWeaponMapping is used for game systems without a mapping file.
+var DefaultWeaponMapping = WeaponMapping{
+	Profiles: map[string]string{
+		"Melee Weapon": "melee", "Melee Weapons": "melee",
+		"Ranged Weapon": "ranged", "Ranged Weapons": "ranged", "Missile Weapon": "ranged",
+	},
+	Characteristics: map[string]string{
+		"Range": "range", "Rng": "range",
+		"Attacks": "attacks", "A": "attacks", "Atk": "attacks",
+		"To Hit": "to_hit", "WS": "to_hit", "BS": "to_hit", "Hit": "to_hit",
+		"To Wound": "to_wound", "S": "to_wound", "Wnd": "to_wound",
+		"Rend": "rend", "AP": "rend", "Rnd": "rend",
+		"Damage": "damage", "D": "damage", "Dmg": "damage",
+		"Ability": "abilities", "Abilities": "abilities", "Keywords": "abilities",
+	},
+}
+
+func loadGameMappings() (map[string]GameMapping, error) {
+	mappings := make(map[string]GameMapping)
+
+	files, err := fs.Glob(mappingFiles, "mappings/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		data, err := mappingFiles.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		var m GameMapping
+		err = yaml.Unmarshal(data, &m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		gameSlug := strings.TrimSuffix(path.Base(file), ".yaml")
+		mappings[gameSlug] = m
+	}
+
+	return mappings, nil
+}
+
+// weaponMappingFor returns the weapon mapping for a game slug, falling back to
+// DefaultWeaponMapping when the game has no mapping file.
+func (c *Converter) weaponMappingFor(gameSlug string) WeaponMapping {
+	m, ok := c.GameMappings[gameSlug]
+	if !ok || len(m.Weapons.Characteristics) == 0 {
+		return DefaultWeaponMapping
+	}
+	return m.Weapons
+}
+
+// weaponType reports whether a profile is a weapon and, if so, whether it is
+// melee or ranged.
+func (c *Converter) weaponType(p Profile) (string, bool) {
+	weaponType, ok := c.Weapons.Profiles[p.TypeName]
+	if ok {
+		return weaponType, true
+	}
+	if strings.Contains(p.TypeName, "Weapon") {
+		c.recordUnmapped(p.TypeName, "(profile type)")
+		return "", true
+	}
+	return "", false
+}
+
+func (c *Converter) mapWeapon(p Profile, weaponType string) models.WeaponSeed {
+	w := models.WeaponSeed{Name: p.Name, Type: weaponType}
+	for _, char := range p.Characteristics {
+		field, ok := c.Weapons.Characteristics[char.Name]
+		if !ok {
+			c.recordUnmapped(p.TypeName, char.Name)
+			continue
+		}
+
+		value := strings.TrimSpace(char.Value)
+		switch field {
+		case "range":
+			w.Range = value
+		case "attacks":
+			w.Attacks = value
+		case "to_hit":
+			w.ToHit = value
+		case "to_wound":
+			w.ToWound = value
+		case "rend":
+			w.Rend = value
+		case "damage":
+			w.Damage = value
+		case "abilities":
+			if value != "-" {
+				w.Abilities = cleanText(value)
+			}
+		default:
+			c.recordUnmapped(p.TypeName, char.Name)
+		}
+	}
+	return w
+}
+
+func (c *Converter) recordUnmapped(profileType, characteristic string) {
+	c.Unmapped[profileType+": "+characteristic]++
+}
+
+// reportUnmapped prints every characteristic the weapon mapping did not cover,
+// so a new catalogue release does not silently drop a column.
+func (c *Converter) reportUnmapped() {
+	if len(c.Unmapped) == 0 {
+		fmt.Println("All weapon characteristics mapped")
+		return
+	}
+
+	keys := make([]string, 0, len(c.Unmapped))
+	for k := range c.Unmapped {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("Unmapped weapon characteristics (%d):\n", len(keys))
+	for _, k := range keys {
+		fmt.Printf(" - %s (%d profiles)\n", k, c.Unmapped[k])
+	}
+}
@@ -18,12 +18,35 @@ type Catalogue struct {
 }
 
 type GameSystem struct {
-	XMLName        xml.Name         `xml:"gameSystem"`
-	ID             string           `xml:"id,attr"`
-	Name           string           `xml:"name,attr"`
-	SharedEntries  []SelectionEntry `xml:"sharedSelectionEntries>selectionEntry"`
-	SharedGroups   []SelectionEntry `xml:"sharedSelectionEntryGroups>selectionEntryGroup"`
-	SharedProfiles []Profile        `xml:"sharedProfiles>profile"`
+	XMLName          xml.Name         `xml:"gameSystem"`
+	ID               string           `xml:"id,attr"`
+	Name             string           `xml:"name,attr"`
+	SharedEntries    []SelectionEntry `xml:"sharedSelectionEntries>selectionEntry"`
+	SharedGroups     []SelectionEntry `xml:"sharedSelectionEntryGroups>selectionEntryGroup"`
+	SharedProfiles   []Profile        `xml:"sharedProfiles>profile"`
+	SharedRules      []RuleEntry      `xml:"sharedRules>rule"`
+	SelectionEntries []SelectionEntry `xml:"selectionEntries>selectionEntry"`
+	CategoryEntries  []Category       `xml:"categoryEntries>categoryEntry"`
+	ForceEntries     []ForceEntry     `xml:"forceEntries>forceEntry"`
+}
+
+// ForceEntry is a force a roster can add. Regiments of Renown are force
+// entries carrying their points and the catalogues allowed to take them.
+type ForceEntry struct {
+	Name            string           `xml:"name,attr"`
+	ID              string           `xml:"id,attr"`
+	Hidden          bool             `xml:"hidden,attr"`
+	SortIndex       int              `xml:"sortIndex,attr"`
+	Modifiers       []Modifier       `xml:"modifiers>modifier"`
+	Costs           []Cost           `xml:"costs>cost"`
+	ForceEntryLinks []ForceEntryLink `xml:"forceEntryLinks>forceEntryLink"`
+}
+
+// ForceEntryLink adds another force entry, such as a regiment, inside a force.
+type ForceEntryLink struct {
+	Name     string `xml:"name,attr"`
+	ID       string `xml:"id,attr"`
+	TargetID string `xml:"targetId,attr"`
 }
 
 type SelectionEntry struct {
@@ -39,18 +62,42 @@ type SelectionEntry struct {
 	SelectionEntryGroups []SelectionEntry `xml:"selectionEntryGroups>selectionEntryGroup"`
 	Constraints          []Constraint     `xml:"constraints>constraint"`
 	Modifiers            []Modifier       `xml:"modifiers>modifier"` // Added this
+	ModifierGroups       []ModifierGroup  `xml:"modifierGroups>modifierGroup"`
+	InfoLinks            []InfoLink       `xml:"infoLinks>infoLink"`
+	Rules                []RuleEntry      `xml:"rules>rule"`
+}
+
+type InfoLink struct {
+	Name     string `xml:"name,attr"`
+	Type     string `xml:"type,attr"` // profile, rule, infoGroup
+	TargetID string `xml:"targetId,attr"`
 }
 
 type Modifier struct {
-	Type       string      `xml:"type,attr"`
-	Field      string      `xml:"field,attr"`
-	Value      string      `xml:"value,attr"`
-	Conditions []Condition `xml:"conditions>condition"`
-	Repeats    []Repeat    `xml:"repeats>repeat"`
+	Type            string           `xml:"type,attr"`
+	Field           string           `xml:"field,attr"`
+	Value           string           `xml:"value,attr"`
+	Affects         string           `xml:"affects,attr"`
+	Conditions      []Condition      `xml:"conditions>condition"`
+	ConditionGroups []ConditionGroup `xml:"conditionGroups>conditionGroup"`
+	Repeats         []Repeat         `xml:"repeats>repeat"`
+}
+
+type ModifierGroup struct {
+	Modifiers      []Modifier      `xml:"modifiers>modifier"`
+	ModifierGroups []ModifierGroup `xml:"modifierGroups>modifierGroup"`
+	Conditions     []Condition     `xml:"conditions>condition"`
+}
+
+type ConditionGroup struct {
+	Type            string           `xml:"type,attr"`
+	Conditions      []Condition      `xml:"conditions>condition"`
+	ConditionGroups []ConditionGroup `xml:"conditionGroups>conditionGroup"`
 }
 
 type Condition struct {
 	ChildID string `xml:"childId,attr"`
+	Scope   string `xml:"scope,attr"`
 }
 
 type Repeat struct {
@@ -76,6 +123,7 @@ type RuleEntry struct {
 
 type Category struct {
 	Name string `xml:"name,attr"`
+	ID   string `xml:"id,attr"`
 }
 
 type Cost struct {
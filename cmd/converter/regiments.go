@@ -0,0 +1,80 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+// While a hero leads a regiment, BattleScribe adds one of these hidden
+// categories to every unit the hero may take: "Regimental Option" for
+// non-HERO units and "Regimental Hero" for other HEROES.
+const (
+	regimentalOptionID = "db3a-7199-c92e-f3cf"
+	regimentalHeroID   = "8f4b-1fa6-3128-8405"
+)
+
+// regimentOptionAffects prefixes the category or entry ID that a regiment
+// option modifier applies to.
+const regimentOptionAffects = "self.entries.recursive."
+
+// collectRegimentOptions reads the regiment options of a hero from the
+// modifiers on its entry and on the entry it links to.
+func (c *Converter) collectRegimentOptions(entry SelectionEntry, unit *models.UnitSeed) {
+	if entry.TargetID != "" {
+		target, ok := c.MasterEntries[entry.TargetID]
+		if ok {
+			c.collectRegimentOptions(target, unit)
+		}
+	}
+
+	modifiers := append([]Modifier{}, entry.Modifiers...)
+	modifiers = append(modifiers, flattenModifierGroups(entry.ModifierGroups)...)
+
+	for _, mod := range modifiers {
+		option, ok := c.regimentOption(mod)
+		if !ok || slices.Contains(unit.RegimentOptions, option) {
+			continue
+		}
+		unit.RegimentOptions = append(unit.RegimentOptions, option)
+	}
+}
+
+// regimentOption turns a category modifier into a regiment option. The
+// affected ID is either a category, meaning any unit with that keyword, or a
+// single selection entry.
+func (c *Converter) regimentOption(mod Modifier) (models.RegimentOptionSeed, bool) {
+	if mod.Type != "add" || mod.Field != "category" {
+		return models.RegimentOptionSeed{}, false
+	}
+	if mod.Value != regimentalOptionID && mod.Value != regimentalHeroID {
+		return models.RegimentOptionSeed{}, false
+	}
+
+	targetID, ok := strings.CutPrefix(mod.Affects, regimentOptionAffects)
+	if !ok || targetID == "" {
+		return models.RegimentOptionSeed{}, false
+	}
+
+	option := models.RegimentOptionSeed{IsHero: mod.Value == regimentalHeroID}
+	if name, ok := c.Categories[targetID]; ok {
+		option.Keyword = strings.ToUpper(name)
+		return option, true
+	}
+	if target, ok := c.MasterEntries[targetID]; ok {
+		option.UnitName = target.Name
+		return option, true
+	}
+
+	return models.RegimentOptionSeed{}, false
+}
+
+func flattenModifierGroups(groups []ModifierGroup) []Modifier {
+	var found []Modifier
+	for _, g := range groups {
+		found = append(found, g.Modifiers...)
+		found = append(found, flattenModifierGroups(g.ModifierGroups)...)
+	}
+	return found
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// rorHostScope is the scope of the conditions naming the catalogues that may
+// hire a Regiment of Renown. The force entries also require the Regiments of
+// Renown catalogue itself, with the "primary-catalogue" scope.
+const rorHostScope = "parent"
+
+// rorHosts lists the names of the catalogues that unhide a Regiment of Renown
+// force entry, which are the factions allowed to hire it.
+func (c *Converter) rorHosts(force ForceEntry) []string {
+	seen := make(map[string]bool)
+	var hosts []string
+
+	var walk func(conditions []Condition, groups []ConditionGroup)
+	walk = func(conditions []Condition, groups []ConditionGroup) {
+		for _, cond := range conditions {
+			name, ok := c.CatalogueNames[cond.ChildID]
+			if cond.Scope != rorHostScope || !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			hosts = append(hosts, name)
+		}
+		for _, group := range groups {
+			walk(group.Conditions, group.ConditionGroups)
+		}
+	}
+
+	for _, mod := range force.Modifiers {
+		if mod.Field == "hidden" && mod.Value == "false" {
+			walk(mod.Conditions, mod.ConditionGroups)
+		}
+	}
+
+	sort.Strings(hosts)
+	return hosts
+}
+
+// rorUnits returns the entries that are only unlocked once the force is in
+// the roster: the units making up that Regiment of Renown.
+func rorUnits(entries []SelectionEntry, forceID string) []SelectionEntry {
+	var units []SelectionEntry
+	for _, entry := range entries {
+		if unlockedBy(entry.ModifierGroups, forceID) {
+			units = append(units, entry)
+		}
+	}
+	return units
+}
+
+func unlockedBy(groups []ModifierGroup, id string) bool {
+	for _, group := range groups {
+		for _, cond := range group.Conditions {
+			if cond.ChildID == id {
+				return true
+			}
+		}
+		if unlockedBy(group.ModifierGroups, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// forceKey normalises a force name for lookups. The catalogue and the .gst
+// spell some regiments differently ("Pit Beasts" and "Pit-beasts") and only
+// one of them may carry a "[LEGENDS]" tag.
+func forceKey(name string) string {
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// allegianceFor guesses the grand alliance of a faction from its name.
+func allegianceFor(name string) string {
+	upper := strings.ToUpper(name)
+	for key, allegiance := range AllegianceMap {
+		if strings.Contains(upper, key) {
+			return allegiance
+		}
+	}
+	return ""
+}
+
+// sharedAllegiance returns the grand alliance all of the factions belong to,
+// or "" when they span several or one is unknown.
+func sharedAllegiance(factions []string) string {
+	shared := ""
+	for _, name := range factions {
+		allegiance := allegianceFor(name)
+		if allegiance == "" || (shared != "" && allegiance != shared) {
+			return ""
+		}
+		shared = allegiance
+	}
+	return shared
+}
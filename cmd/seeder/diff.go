@@ -0,0 +1,233 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type fieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+func (c fieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field, c.Old, c.New)
+}
+
+// diffField appends a change when old and new differ.
+func diffField(changes []fieldChange, field string, oldValue, newValue any) []fieldChange {
+	o, n := fmt.Sprint(oldValue), fmt.Sprint(newValue)
+	if o == n {
+		return changes
+	}
+	return append(changes, fieldChange{Field: field, Old: o, New: n})
+}
+
+type weaponDiff struct {
+	Name    string        `json:"name"`
+	Changes []fieldChange `json:"changes"`
+}
+
+// unitDiff collects the changes to one existing unit. A nil *unitDiff is
+// valid and records nothing, which is what new units get.
+type unitDiff struct {
+	Name            string        `json:"name"`
+	Points          *fieldChange  `json:"points,omitempty"`
+	Stats           []fieldChange `json:"stats,omitempty"`
+	KeywordsAdded   []string      `json:"keywords_added,omitempty"`
+	KeywordsRemoved []string      `json:"keywords_removed,omitempty"`
+	WeaponsAdded    []string      `json:"weapons_added,omitempty"`
+	WeaponsRemoved  []string      `json:"weapons_removed,omitempty"`
+	WeaponsChanged  []weaponDiff  `json:"weapons_changed,omitempty"`
+}
+
+func (d *unitDiff) setChanges(changes []fieldChange) {
+	if d == nil {
+		return
+	}
+	for _, c := range changes {
+		if c.Field == "points" {
+			d.Points = &c
+			continue
+		}
+		d.Stats = append(d.Stats, c)
+	}
+}
+
+func (d *unitDiff) keywordAdded(name string) {
+	if d != nil {
+		d.KeywordsAdded = append(d.KeywordsAdded, name)
+	}
+}
+
+func (d *unitDiff) keywordRemoved(name string) {
+	if d != nil {
+		d.KeywordsRemoved = append(d.KeywordsRemoved, name)
+	}
+}
+
+func (d *unitDiff) weaponAdded(name string) {
+	if d != nil {
+		d.WeaponsAdded = append(d.WeaponsAdded, name)
+	}
+}
+
+func (d *unitDiff) weaponRemoved(name string) {
+	if d != nil {
+		d.WeaponsRemoved = append(d.WeaponsRemoved, name)
+	}
+}
+
+func (d *unitDiff) weaponChanged(name string, changes []fieldChange) {
+	if d != nil {
+		d.WeaponsChanged = append(d.WeaponsChanged, weaponDiff{Name: name, Changes: changes})
+	}
+}
+
+func (d *unitDiff) empty() bool {
+	return d.Points == nil &&
+		len(d.Stats) == 0 &&
+		len(d.KeywordsAdded) == 0 &&
+		len(d.KeywordsRemoved) == 0 &&
+		len(d.WeaponsAdded) == 0 &&
+		len(d.WeaponsRemoved) == 0 &&
+		len(d.WeaponsChanged) == 0
+}
+
+const (
+	factionAdded     = "added"
+	factionChanged   = "changed"
+	factionUnchanged = "unchanged"
+	factionRemoved   = "removed"
+)
+
+type factionDiff struct {
+	Name         string        `json:"name"`
+	Status       string        `json:"status"`
+	Changes      []fieldChange `json:"changes,omitempty"`
+	UnitsAdded   []string      `json:"units_added,omitempty"`
+	UnitsRemoved []string      `json:"units_removed,omitempty"`
+	UnitsChanged []*unitDiff   `json:"units_changed,omitempty"`
+}
+
+func (f *factionDiff) empty() bool {
+	return f.Status == factionUnchanged &&
+		len(f.UnitsAdded) == 0 &&
+		len(f.UnitsRemoved) == 0 &&
+		len(f.UnitsChanged) == 0
+}
+
+// seedDiff is the per faction change log of a run, kept in seeding order.
+type seedDiff struct {
+	factions []*factionDiff
+	byName   map[string]*factionDiff
+}
+
+func newSeedDiff() *seedDiff {
+	return &seedDiff{byName: make(map[string]*factionDiff)}
+}
+
+func (d *seedDiff) faction(name string) *factionDiff {
+	f, exists := d.byName[name]
+	if !exists {
+		f = &factionDiff{Name: name, Status: factionUnchanged}
+		d.byName[name] = f
+		d.factions = append(d.factions, f)
+	}
+	return f
+}
+
+func (d *seedDiff) merge(other *seedDiff) {
+	for _, o := range other.factions {
+		f := d.faction(o.Name)
+		if o.Status != factionUnchanged {
+			f.Status = o.Status
+		}
+		f.Changes = append(f.Changes, o.Changes...)
+		f.UnitsAdded = append(f.UnitsAdded, o.UnitsAdded...)
+		f.UnitsRemoved = append(f.UnitsRemoved, o.UnitsRemoved...)
+		f.UnitsChanged = append(f.UnitsChanged, o.UnitsChanged...)
+	}
+}
+
+func (d *seedDiff) changed() []*factionDiff {
+	changed := []*factionDiff{}
+	for _, f := range d.factions {
+		if !f.empty() {
+			changed = append(changed, f)
+		}
+	}
+	return changed
+}
+
+type dryRunReport struct {
+	Tables   seedStats      `json:"tables"`
+	Factions []*factionDiff `json:"factions"`
+}
+
+func writeDryRunJSON(w io.Writer, stats seedStats, diff *seedDiff) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(dryRunReport{
+		Tables:   stats,
+		Factions: diff.changed(),
+	})
+}
+
+func writeDryRunText(w io.Writer, stats seedStats, diff *seedDiff) error {
+	changed := diff.changed()
+	if len(changed) == 0 {
+		fmt.Fprintln(w, "No faction changes.")
+	}
+
+	for _, f := range changed {
+		fmt.Fprintf(w, "Faction: %s (%s)\n", f.Name, f.Status)
+		for _, c := range f.Changes {
+			fmt.Fprintf(w, "    %s\n", c)
+		}
+		for _, name := range f.UnitsAdded {
+			fmt.Fprintf(w, "  + %s\n", name)
+		}
+		for _, name := range f.UnitsRemoved {
+			fmt.Fprintf(w, "  - %s\n", name)
+		}
+		for _, u := range f.UnitsChanged {
+			fmt.Fprintf(w, "  ~ %s\n", u.Name)
+			if u.Points != nil {
+				fmt.Fprintf(w, "      %s\n", u.Points)
+			}
+			for _, c := range u.Stats {
+				fmt.Fprintf(w, "      %s\n", c)
+			}
+			if len(u.KeywordsAdded) > 0 || len(u.KeywordsRemoved) > 0 {
+				var parts []string
+				for _, k := range u.KeywordsAdded {
+					parts = append(parts, "+"+k)
+				}
+				for _, k := range u.KeywordsRemoved {
+					parts = append(parts, "-"+k)
+				}
+				fmt.Fprintf(w, "      keywords: %s\n", strings.Join(parts, " "))
+			}
+			for _, name := range u.WeaponsAdded {
+				fmt.Fprintf(w, "      + weapon %s\n", name)
+			}
+			for _, name := range u.WeaponsRemoved {
+				fmt.Fprintf(w, "      - weapon %s\n", name)
+			}
+			for _, wd := range u.WeaponsChanged {
+				parts := make([]string, len(wd.Changes))
+				for i, c := range wd.Changes {
+					parts[i] = c.String()
+				}
+				fmt.Fprintf(w, "      ~ weapon %s: %s\n", wd.Name, strings.Join(parts, ", "))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	return stats.write(w)
+}
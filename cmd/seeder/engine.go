@@ -7,38 +7,76 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 
 	"github.com/JohnG-Dev/army_builder_api/internal/database"
 	"github.com/JohnG-Dev/army_builder_api/internal/models"
 	"github.com/JohnG-Dev/army_builder_api/internal/state"
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
 )
 
+// txStarter is satisfied by both the pool and an open transaction, whose
+// Begin starts a savepoint.
+type txStarter interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+const foreignKeyViolation = "23503"
+
 type Seeder struct {
-	s            *state.State
-	ctx          context.Context
-	keywordMap   map[string]uuid.UUID
-	gameMap      map[string]uuid.UUID
-	factionMap   map[string]uuid.UUID
-	pendingLinks map[uuid.UUID]string
-	txQueries    *database.Queries
+	s              *state.State
+	ctx            context.Context
+	base           *database.Queries
+	txs            txStarter
+	keywordMap     map[string]uuid.UUID
+	gameMap        map[string]uuid.UUID
+	factionMap     map[string]uuid.UUID
+	factionPools   map[uuid.UUID]*rowPool[database.Faction]
+	factionParents map[uuid.UUID]uuid.NullUUID
+	pendingLinks   map[uuid.UUID]string
+	txQueries      *database.Queries
+	fileTx         pgx.Tx
+	stats          seedStats
+	fileStats      seedStats
+	fileKeywords   map[string]uuid.UUID
+	diff           *seedDiff
+	fileDiff       *seedDiff
+	unparsedStats  int
 }
 
 func NewSeeder(ctx context.Context, s *state.State) *Seeder {
 	return &Seeder{
-		s:            s,
-		ctx:          ctx,
-		keywordMap:   make(map[string]uuid.UUID),
-		gameMap:      make(map[string]uuid.UUID),
-		factionMap:   make(map[string]uuid.UUID),
-		pendingLinks: make(map[uuid.UUID]string),
+		s:              s,
+		ctx:            ctx,
+		base:           s.DB,
+		txs:            s.Pool,
+		keywordMap:     make(map[string]uuid.UUID),
+		gameMap:        make(map[string]uuid.UUID),
+		factionMap:     make(map[string]uuid.UUID),
+		factionPools:   make(map[uuid.UUID]*rowPool[database.Faction]),
+		factionParents: make(map[uuid.UUID]uuid.NullUUID),
+		pendingLinks:   make(map[uuid.UUID]string),
+		stats:          make(seedStats),
+		fileStats:      make(seedStats),
+		fileKeywords:   make(map[string]uuid.UUID),
+		diff:           newSeedDiff(),
+		fileDiff:       newSeedDiff(),
 	}
 }
 
+// DryRun routes every write through tx. Each file then runs in a savepoint,
+// and the caller rolls tx back once the report has been printed.
+func (sr *Seeder) DryRun(tx pgx.Tx) {
+	sr.base = sr.s.DB.WithTx(tx)
+	sr.txs = tx
+}
+
 func (sr *Seeder) SeedFile(path string) error {
 	cleanPath := filepath.Clean(path)
 	data, err := os.ReadFile(cleanPath)
@@ -57,7 +95,7 @@ func (sr *Seeder) SeedFile(path string) error {
 		zap.String("game", seed.GameName),
 	)
 
-	tx, err := sr.s.Pool.Begin(sr.ctx)
+	tx, err := sr.txs.Begin(sr.ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -65,18 +103,47 @@ func (sr *Seeder) SeedFile(path string) error {
 		_ = tx.Rollback(sr.ctx)
 	}()
 
-	sr.txQueries = sr.s.DB.WithTx(tx)
+	sr.txQueries = sr.base.WithTx(tx)
+	sr.fileTx = tx
+	sr.fileStats = make(seedStats)
+	sr.fileKeywords = make(map[string]uuid.UUID)
+	sr.fileDiff = newSeedDiff()
+	defer func() {
+		sr.txQueries = nil
+		sr.fileTx = nil
+	}()
 
 	gameID, err := sr.getOrCreateGame(seed.GameName)
 	if err != nil {
 		return err
 	}
 
+	if seed.BattlescribeID != "" {
+		err = sr.syncGameBattlescribe(gameID, seed)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Only the game's core rules file carries game level sections, so faction
+	// files must not prune them.
+	if len(seed.Rules) > 0 || len(seed.GameAbilities) > 0 {
+		err = sr.seedGameRules(gameID, seed.Rules, seed.Version, seed.Source)
+		if err != nil {
+			return err
+		}
+
+		err = sr.seedGameAbilities(gameID, seed.GameAbilities, seed.Version, seed.Source)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, f := range seed.Factions {
 		sr.s.Logger.Info("Seeding Faction", zap.String("name", f.Name))
-		factionID, err := sr.createFaction(gameID, f)
+		factionID, err := sr.upsertFaction(gameID, f)
 		if err != nil {
-			return fmt.Errorf("failed to create faction %s: %w", f.Name, err)
+			return fmt.Errorf("failed to upsert faction %s: %w", f.Name, err)
 		}
 
 		sr.factionMap[f.Name] = factionID
@@ -94,28 +161,24 @@ func (sr *Seeder) SeedFile(path string) error {
 			return err
 		}
 
-		for _, u := range f.Units {
-			sr.s.Logger.Info("Seeding Unit", zap.String("name", u.Name))
-
-			unitID, err := sr.createUnit(factionID, u, f.Version, f.Source)
-			if err != nil {
-				return fmt.Errorf("failed to create unit %s: %w", u.Name, err)
-			}
+		err = sr.seedFactionAbilities(factionID, f.Abilities, f.Version, f.Source)
+		if err != nil {
+			return err
+		}
 
-			err = sr.seedUnitWeapons(unitID, u.Weapons, f.Version, f.Source)
-			if err != nil {
-				return err
-			}
+		err = sr.seedFactionAllowedUnits(factionID, f.AllowedUnits)
+		if err != nil {
+			return err
+		}
 
-			err = sr.seedUnitKeywords(unitID, gameID, u.Keywords, f.Version, f.Source)
-			if err != nil {
-				return err
-			}
+		err = sr.seedRegimentOfRenownHosts(factionID, f.HireableBy)
+		if err != nil {
+			return err
+		}
 
-			err = sr.seedUnitAbilities(unitID, factionID, gameID, u.Abilities, f.Version, f.Source)
-			if err != nil {
-				return err
-			}
+		err = sr.seedFactionUnits(gameID, factionID, f)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -124,7 +187,11 @@ func (sr *Seeder) SeedFile(path string) error {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	sr.txQueries = nil
+	sr.stats.merge(sr.fileStats)
+	sr.diff.merge(sr.fileDiff)
+	for name, id := range sr.fileKeywords {
+		sr.keywordMap[name] = id
+	}
 	return nil
 }
 
@@ -140,7 +207,12 @@ func (sr *Seeder) LinkParents() error {
 			continue
 		}
 
-		err := sr.s.DB.UpdateFactionParent(sr.ctx, database.UpdateFactionParentParams{
+		current, known := sr.factionParents[factionID]
+		if known && current == database.UUIDToNullUUID(parentID) {
+			continue
+		}
+
+		err := sr.base.UpdateFactionParent(sr.ctx, database.UpdateFactionParentParams{
 			ID:              factionID,
 			ParentFactionID: database.UUIDToNullUUID(parentID),
 			IsArmyOfRenown:  true,
@@ -157,11 +229,80 @@ func (sr *Seeder) LinkParents() error {
 	return nil
 }
 
+// Prune deletes factions of the seeded games that no YAML file mentions any
+// more, along with keywords no unit uses. It must only run after every file
+// seeded cleanly, otherwise a failed file would wipe its faction.
+func (sr *Seeder) Prune() error {
+	for gameID, pool := range sr.factionPools {
+		for _, f := range pool.remaining() {
+			sr.s.Logger.Info("Deleting Faction", zap.String("name", f.Name))
+			deleted, err := sr.deleteUnlessInUse(func(q *database.Queries) error {
+				return q.DeleteFaction(sr.ctx, f.ID)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to delete faction %s: %w", f.Name, err)
+			}
+			if !deleted {
+				sr.keptInUse(sr.stats, "factions", f.Name)
+				continue
+			}
+			sr.stats.deleted("factions", 1)
+			sr.diff.faction(f.Name).Status = factionRemoved
+		}
+
+		n, err := sr.base.DeleteUnusedKeywords(sr.ctx, gameID)
+		if err != nil {
+			return fmt.Errorf("failed to delete unused keywords: %w", err)
+		}
+		sr.stats.deleted("keywords", int(n))
+	}
+	return nil
+}
+
+// deleteUnlessInUse runs del in a savepoint and reports whether the row is
+// gone. Saved army lists restrict deletes of the units, enhancements,
+// formations and factions they use, so a row still in a list is kept rather
+// than failing the file: the seeder must never remove user data.
+func (sr *Seeder) deleteUnlessInUse(del func(q *database.Queries) error) (bool, error) {
+	var txs txStarter = sr.txs
+	if sr.fileTx != nil {
+		txs = sr.fileTx
+	}
+
+	sp, err := txs.Begin(sr.ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin savepoint: %w", err)
+	}
+	defer func() {
+		_ = sp.Rollback(sr.ctx)
+	}()
+
+	err = del(sr.s.DB.WithTx(sp))
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, sp.Commit(sr.ctx)
+}
+
+// keptInUse logs a row the YAML no longer has but an army list still uses.
+func (sr *Seeder) keptInUse(st seedStats, table, name string) {
+	sr.s.Logger.Warn("Keeping row still used by saved army lists",
+		zap.String("table", table),
+		zap.String("name", name),
+	)
+	st.unchanged(table)
+}
+
 func (sr *Seeder) getDB() *database.Queries {
 	if sr.txQueries != nil {
 		return sr.txQueries
 	}
-	return sr.s.DB
+	return sr.base
 }
 
 func (sr *Seeder) getOrCreateGame(name string) (uuid.UUID, error) {
@@ -173,7 +314,7 @@ func (sr *Seeder) getOrCreateGame(name string) (uuid.UUID, error) {
 	game, err := sr.getDB().GetGameByName(sr.ctx, name)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			newGame, err := sr.s.DB.CreateGame(sr.ctx, database.CreateGameParams{
+			newGame, err := sr.base.CreateGame(sr.ctx, database.CreateGameParams{
 				Name:    name,
 				Edition: "4th Edition",
 				Version: "1.0",
@@ -182,43 +323,289 @@ func (sr *Seeder) getOrCreateGame(name string) (uuid.UUID, error) {
 			if err != nil {
 				return uuid.Nil, fmt.Errorf("failed to create game: %w", err)
 			}
+			sr.stats.inserted("games")
 			sr.gameMap[name] = newGame.ID
 			return newGame.ID, nil
 		}
 		return uuid.Nil, err
 	}
 
+	keywords, err := sr.getDB().GetKeywordsForGame(sr.ctx, game.ID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to load keywords: %w", err)
+	}
+	for _, k := range keywords {
+		sr.keywordMap[k.Name] = k.ID
+	}
+
+	sr.stats.unchanged("games")
 	sr.gameMap[name] = game.ID
 	return game.ID, nil
 }
 
-func (sr *Seeder) createFaction(gameID uuid.UUID, f models.FactionSeed) (uuid.UUID, error) {
-	faction, err := sr.getDB().CreateFaction(sr.ctx, database.CreateFactionParams{
-		GameID:             gameID,
-		Name:               f.Name,
-		IsArmyOfRenown:     f.IsArmyOfRenown,
-		IsRegimentOfRenown: f.IsRegimentOfRenown,
-		ParentFactionID:    uuid.NullUUID{},
-		Allegiance:         f.Allegiance,
-		Version:            f.Version,
-		Source:             f.Source,
+// syncGameBattlescribe stores the game system and force entry IDs rosters
+// of the game are read and written with.
+func (sr *Seeder) syncGameBattlescribe(gameID uuid.UUID, seed models.SeedData) error {
+	game, err := sr.getDB().GetGame(sr.ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to load game: %w", err)
+	}
+
+	forces := seed.BattlescribeForces
+	if forces == nil {
+		forces = map[string]string{}
+	}
+	forcesJSON, err := json.Marshal(forces)
+	if err != nil {
+		return err
+	}
+
+	if game.BattlescribeID == seed.BattlescribeID && sameJSON(game.BattlescribeForces, forcesJSON) {
+		return nil
+	}
+
+	err = sr.getDB().UpdateGameBattlescribe(sr.ctx, database.UpdateGameBattlescribeParams{
+		ID:                 gameID,
+		BattlescribeID:     seed.BattlescribeID,
+		BattlescribeForces: forcesJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update game: %w", err)
+	}
+	sr.fileStats.updated("games")
+	return nil
+}
+
+func (sr *Seeder) factionPool(gameID uuid.UUID) (*rowPool[database.Faction], error) {
+	pool, exists := sr.factionPools[gameID]
+	if exists {
+		return pool, nil
+	}
+
+	factions, err := sr.getDB().GetFactionsByID(sr.ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load factions: %w", err)
+	}
+
+	pool = newRowPool(factions)
+	sr.factionPools[gameID] = pool
+	return pool, nil
+}
+
+func (sr *Seeder) upsertFaction(gameID uuid.UUID, f models.FactionSeed) (uuid.UUID, error) {
+	pool, err := sr.factionPool(gameID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	diff := sr.fileDiff.faction(f.Name)
+	existing, found := pool.take(
+		func(r database.Faction) bool { return r.Name == f.Name },
+		func(r database.Faction) bool { return len(factionChanges(r, f)) == 0 },
+	)
+	if !found {
+		faction, err := sr.getDB().CreateFaction(sr.ctx, database.CreateFactionParams{
+			GameID:              gameID,
+			Name:                f.Name,
+			IsArmyOfRenown:      f.IsArmyOfRenown,
+			IsRegimentOfRenown:  f.IsRegimentOfRenown,
+			ParentFactionID:     uuid.NullUUID{},
+			Allegiance:          f.Allegiance,
+			Version:             f.Version,
+			Source:              f.Source,
+			Points:              int32(f.Points),
+			BattlescribeID:      f.BattlescribeID,
+			BattlescribeForceID: f.BattlescribeForceID,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		sr.fileStats.inserted("factions")
+		diff.Status = factionAdded
+		return faction.ID, nil
+	}
+
+	sr.factionParents[existing.ID] = existing.ParentFactionID
+	changes := factionChanges(existing, f)
+	if len(changes) == 0 {
+		sr.fileStats.unchanged("factions")
+		return existing.ID, nil
+	}
+
+	parentID := existing.ParentFactionID
+	if f.ParentFactionName == "" {
+		parentID = uuid.NullUUID{}
+	}
+
+	_, err = sr.getDB().UpdateFaction(sr.ctx, database.UpdateFactionParams{
+		ID:                  existing.ID,
+		Name:                f.Name,
+		Allegiance:          f.Allegiance,
+		Version:             f.Version,
+		Source:              f.Source,
+		IsArmyOfRenown:      f.IsArmyOfRenown,
+		IsRegimentOfRenown:  f.IsRegimentOfRenown,
+		ParentFactionID:     parentID,
+		Points:              int32(f.Points),
+		BattlescribeID:      f.BattlescribeID,
+		BattlescribeForceID: f.BattlescribeForceID,
 	})
 	if err != nil {
 		return uuid.Nil, err
 	}
+	sr.fileStats.updated("factions")
+	diff.Status = factionChanged
+	diff.Changes = append(diff.Changes, changes...)
+	return existing.ID, nil
+}
+
+func factionChanges(r database.Faction, f models.FactionSeed) []fieldChange {
+	var changes []fieldChange
+	changes = diffField(changes, "allegiance", r.Allegiance, f.Allegiance)
+	changes = diffField(changes, "version", r.Version, f.Version)
+	changes = diffField(changes, "source", r.Source, f.Source)
+	changes = diffField(changes, "is_army_of_renown", r.IsArmyOfRenown, f.IsArmyOfRenown)
+	changes = diffField(changes, "is_regiment_of_renown", r.IsRegimentOfRenown, f.IsRegimentOfRenown)
+	changes = diffField(changes, "points", r.Points, int32(f.Points))
+	changes = diffField(changes, "battlescribe_id", r.BattlescribeID, f.BattlescribeID)
+	changes = diffField(changes, "battlescribe_force_id", r.BattlescribeForceID, f.BattlescribeForceID)
+	if f.ParentFactionName == "" && r.ParentFactionID.Valid {
+		changes = append(changes, fieldChange{Field: "parent_faction_id", Old: r.ParentFactionID.UUID.String(), New: ""})
+	}
+	return changes
+}
+
+func (sr *Seeder) seedFactionUnits(gameID, factionID uuid.UUID, f models.FactionSeed) error {
+	existing, err := sr.getDB().GetAllUnitsForFaction(sr.ctx, factionID)
+	if err != nil {
+		return fmt.Errorf("failed to load units: %w", err)
+	}
+
+	factionDiff := sr.fileDiff.faction(f.Name)
+	pool := newRowPool(existing)
+	for _, u := range f.Units {
+		sr.s.Logger.Info("Seeding Unit", zap.String("name", u.Name))
+
+		sr.checkUnitStats(f.Name, u)
+
+		unitID, diff, err := sr.upsertUnit(pool, factionID, u, f.Version, f.Source)
+		if err != nil {
+			return fmt.Errorf("failed to upsert unit %s: %w", u.Name, err)
+		}
+
+		err = sr.seedUnitWeapons(unitID, u.Weapons, f.Version, f.Source, diff)
+		if err != nil {
+			return err
+		}
+
+		err = sr.seedUnitKeywords(unitID, gameID, u.Keywords, f.Version, f.Source, diff)
+		if err != nil {
+			return err
+		}
+
+		err = sr.seedUnitRegimentOptions(unitID, u.RegimentOptions)
+		if err != nil {
+			return err
+		}
+
+		err = sr.seedUnitAbilities(unitID, factionID, gameID, u.Abilities, f.Version, f.Source)
+		if err != nil {
+			return err
+		}
+
+		if diff == nil {
+			factionDiff.UnitsAdded = append(factionDiff.UnitsAdded, u.Name)
+		} else if !diff.empty() {
+			factionDiff.UnitsChanged = append(factionDiff.UnitsChanged, diff)
+		}
+	}
+
+	for _, u := range pool.remaining() {
+		sr.s.Logger.Info("Deleting Unit", zap.String("name", u.Name))
+		deleted, err := sr.deleteUnlessInUse(func(q *database.Queries) error {
+			return q.DeleteUnit(sr.ctx, u.ID)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete unit %s: %w", u.Name, err)
+		}
+		if !deleted {
+			sr.keptInUse(sr.fileStats, "units", u.Name)
+			continue
+		}
+		sr.fileStats.deleted("units", 1)
+		factionDiff.UnitsRemoved = append(factionDiff.UnitsRemoved, u.Name)
+	}
+
+	if factionDiff.Status == factionUnchanged && !factionDiff.empty() {
+		factionDiff.Status = factionChanged
+	}
+
+	return nil
+}
+
+// checkUnitStats warns about stat strings of a unit or its weapons that do
+// not parse. They are still seeded; the API reports them as unknown.
+func (sr *Seeder) checkUnitStats(factionName string, u models.UnitSeed) {
+	_, unparsed := stats.ParseUnit(stats.UnitLine{
+		Move:         u.Move,
+		HealthWounds: u.Health,
+		Save:         u.Save,
+		WardFNP:      u.Ward,
+		InvulnSave:   u.Invuln,
+		ControlOC:    u.Control,
+		Toughness:    u.Toughness,
+		Leadership:   u.Leadership,
+	})
+	for _, p := range unparsed {
+		sr.warnUnparsedStat(factionName, u.Name, "", p)
+	}
+
+	for _, w := range u.Weapons {
+		_, unparsed := stats.ParseWeapon(stats.WeaponLine{
+			Range:         w.Range,
+			Attacks:       w.Attacks,
+			HitStats:      w.ToHit,
+			WoundStrength: w.ToWound,
+			RendAP:        w.Rend,
+			Damage:        w.Damage,
+		})
+		for _, p := range unparsed {
+			sr.warnUnparsedStat(factionName, u.Name, w.Name, p)
+		}
+	}
+}
+
+func (sr *Seeder) warnUnparsedStat(factionName, unitName, weaponName string, p stats.Unparsed) {
+	sr.unparsedStats++
+	fields := []zap.Field{
+		zap.String("faction", factionName),
+		zap.String("unit", unitName),
+	}
+	if weaponName != "" {
+		fields = append(fields, zap.String("weapon", weaponName))
+	}
+	fields = append(fields, zap.String("field", p.Field), zap.String("value", p.Raw))
+	sr.s.Logger.Warn("Unparseable stat", fields...)
+}
 
-	return faction.ID, nil
+// UnparsedStats returns how many stat strings failed to parse so far.
+func (sr *Seeder) UnparsedStats() int {
+	return sr.unparsedStats
 }
 
-func (sr *Seeder) createUnit(factionID uuid.UUID, u models.UnitSeed, version, source string) (uuid.UUID, error) {
+// upsertUnit returns the change log for an existing unit, or nil when the
+// unit was inserted.
+func (sr *Seeder) upsertUnit(pool *rowPool[database.Unit], factionID uuid.UUID, u models.UnitSeed, version, source string) (uuid.UUID, *unitDiff, error) {
 	statsJSON, err := json.Marshal(u.AdditionalStats)
 	if err != nil {
 		statsJSON = []byte("{}")
 	}
 
-	unit, err := sr.getDB().CreateUnit(sr.ctx, database.CreateUnitParams{
+	params := database.CreateUnitParams{
 		FactionID:         factionID,
 		Name:              u.Name,
+		Description:       u.Description,
+		IsManifestation:   u.IsManifestation,
 		IsUnique:          u.IsUnique,
 		Move:              cleanStat(u.Move),
 		HealthWounds:      cleanStat(u.Health),
@@ -237,17 +624,111 @@ func (sr *Seeder) createUnit(factionID uuid.UUID, u models.UnitSeed, version, so
 		MatchedPlay:       u.MatchedPlay,
 		Version:           version,
 		Source:            source,
+		BattlescribeID:    u.BattlescribeID,
+		CanBeReinforced:   u.CanBeReinforced,
+	}
+	same := func(r database.Unit) bool { return len(unitChanges(r, params)) == 0 }
+
+	// Prefer the BattleScribe entry ID; fall back to the name for rows or
+	// seeds that predate it.
+	existing, found := pool.take(func(r database.Unit) bool {
+		return u.BattlescribeID != "" && r.BattlescribeID == u.BattlescribeID
+	}, same)
+	if !found {
+		existing, found = pool.take(func(r database.Unit) bool {
+			return r.Name == u.Name && (r.BattlescribeID == "" || u.BattlescribeID == "")
+		}, same)
+	}
+
+	if !found {
+		unit, err := sr.getDB().CreateUnit(sr.ctx, params)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+		sr.fileStats.inserted("units")
+		return unit.ID, nil, nil
+	}
+
+	diff := &unitDiff{Name: u.Name}
+	changes := unitChanges(existing, params)
+	if len(changes) == 0 {
+		sr.fileStats.unchanged("units")
+		return existing.ID, diff, nil
+	}
+
+	_, err = sr.getDB().UpdateUnit(sr.ctx, database.UpdateUnitParams{
+		ID:                existing.ID,
+		Name:              params.Name,
+		Description:       params.Description,
+		Move:              params.Move,
+		HealthWounds:      params.HealthWounds,
+		SaveStats:         params.SaveStats,
+		WardFnp:           params.WardFnp,
+		InvulnSave:        params.InvulnSave,
+		ControlOc:         params.ControlOc,
+		Toughness:         params.Toughness,
+		LeadershipBravery: params.LeadershipBravery,
+		Points:            params.Points,
+		AdditionalStats:   params.AdditionalStats,
+		SummonCost:        params.SummonCost,
+		Banishment:        params.Banishment,
+		MinUnitSize:       params.MinUnitSize,
+		MaxUnitSize:       params.MaxUnitSize,
+		MatchedPlay:       params.MatchedPlay,
+		Version:           params.Version,
+		Source:            params.Source,
+		IsManifestation:   params.IsManifestation,
+		IsUnique:          params.IsUnique,
+		BattlescribeID:    params.BattlescribeID,
+		CanBeReinforced:   params.CanBeReinforced,
 	})
 	if err != nil {
-		return uuid.Nil, err
+		return uuid.Nil, nil, err
 	}
+	sr.fileStats.updated("units")
+	diff.setChanges(changes)
+	return existing.ID, diff, nil
+}
 
-	return unit.ID, nil
+func unitChanges(r database.Unit, p database.CreateUnitParams) []fieldChange {
+	var changes []fieldChange
+	changes = diffField(changes, "name", r.Name, p.Name)
+	changes = diffField(changes, "description", r.Description, p.Description)
+	changes = diffField(changes, "is_manifestation", r.IsManifestation, p.IsManifestation)
+	changes = diffField(changes, "is_unique", r.IsUnique, p.IsUnique)
+	changes = diffField(changes, "move", r.Move, p.Move)
+	changes = diffField(changes, "health_wounds", r.HealthWounds, p.HealthWounds)
+	changes = diffField(changes, "save_stats", r.SaveStats, p.SaveStats)
+	changes = diffField(changes, "ward_fnp", r.WardFnp, p.WardFnp)
+	changes = diffField(changes, "invuln_save", r.InvulnSave, p.InvulnSave)
+	changes = diffField(changes, "control_oc", r.ControlOc, p.ControlOc)
+	changes = diffField(changes, "toughness", r.Toughness, p.Toughness)
+	changes = diffField(changes, "leadership_bravery", r.LeadershipBravery, p.LeadershipBravery)
+	changes = diffField(changes, "points", r.Points, p.Points)
+	if !sameJSON(r.AdditionalStats, p.AdditionalStats) {
+		changes = append(changes, fieldChange{Field: "additional_stats", Old: string(r.AdditionalStats), New: string(p.AdditionalStats)})
+	}
+	changes = diffField(changes, "summon_cost", r.SummonCost, p.SummonCost)
+	changes = diffField(changes, "banishment", r.Banishment, p.Banishment)
+	changes = diffField(changes, "min_unit_size", r.MinUnitSize, p.MinUnitSize)
+	changes = diffField(changes, "max_unit_size", r.MaxUnitSize, p.MaxUnitSize)
+	changes = diffField(changes, "can_be_reinforced", r.CanBeReinforced, p.CanBeReinforced)
+	changes = diffField(changes, "matched_play", r.MatchedPlay, p.MatchedPlay)
+	changes = diffField(changes, "version", r.Version, p.Version)
+	changes = diffField(changes, "source", r.Source, p.Source)
+	changes = diffField(changes, "battlescribe_id", r.BattlescribeID, p.BattlescribeID)
+	return changes
 }
 
-func (sr *Seeder) seedUnitWeapons(unitID uuid.UUID, weapons []models.WeaponSeed, version, source string) error {
+func (sr *Seeder) seedUnitWeapons(unitID uuid.UUID, weapons []models.WeaponSeed, version, source string, diff *unitDiff) error {
+	existing, err := sr.getDB().GetWeaponsForUnit(sr.ctx, unitID)
+	if err != nil {
+		return fmt.Errorf("failed to load weapons: %w", err)
+	}
+
+	pool := newRowPool(existing)
 	for _, w := range weapons {
-		_, err := sr.getDB().CreateWeapon(sr.ctx, database.CreateWeaponParams{
+		params := database.CreateWeaponParams{
 			UnitID:        unitID,
 			Name:          w.Name,
 			Range:         w.Range,
@@ -258,36 +739,102 @@ func (sr *Seeder) seedUnitWeapons(unitID uuid.UUID, weapons []models.WeaponSeed,
 			Damage:        w.Damage,
 			Version:       version,
 			Source:        source,
-		})
+			WeaponType:    w.Type,
+			Abilities:     w.Abilities,
+		}
+		same := func(r database.Weapon) bool { return len(weaponChanges(r, params)) == 0 }
+
+		row, found := pool.take(func(r database.Weapon) bool { return r.Name == w.Name }, same)
+		switch {
+		case !found:
+			_, err = sr.getDB().CreateWeapon(sr.ctx, params)
+			if err != nil {
+				return fmt.Errorf("failed to create weapon %s: %w", w.Name, err)
+			}
+			sr.fileStats.inserted("weapons")
+			diff.weaponAdded(w.Name)
+		case same(row):
+			sr.fileStats.unchanged("weapons")
+		default:
+			_, err = sr.getDB().UpdateWeapon(sr.ctx, database.UpdateWeaponParams{
+				ID:            row.ID,
+				Name:          params.Name,
+				Range:         params.Range,
+				Attacks:       params.Attacks,
+				HitStats:      params.HitStats,
+				WoundStrength: params.WoundStrength,
+				RendAp:        params.RendAp,
+				Damage:        params.Damage,
+				Version:       params.Version,
+				Source:        params.Source,
+				WeaponType:    params.WeaponType,
+				Abilities:     params.Abilities,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update weapon %s: %w", w.Name, err)
+			}
+			sr.fileStats.updated("weapons")
+			diff.weaponChanged(w.Name, weaponChanges(row, params))
+		}
+	}
+
+	for _, w := range pool.remaining() {
+		err := sr.getDB().DeleteWeapon(sr.ctx, w.ID)
 		if err != nil {
-			return fmt.Errorf("failed to create weapon %s: %w", w.Name, err)
+			return fmt.Errorf("failed to delete weapon %s: %w", w.Name, err)
 		}
+		sr.fileStats.deleted("weapons", 1)
+		diff.weaponRemoved(w.Name)
 	}
 
 	return nil
 }
 
-func (sr *Seeder) seedUnitKeywords(unitID uuid.UUID, gameID uuid.UUID, keywordNames []string, version, source string) error {
+func weaponChanges(r database.Weapon, p database.CreateWeaponParams) []fieldChange {
+	var changes []fieldChange
+	changes = diffField(changes, "range", r.Range, p.Range)
+	changes = diffField(changes, "attacks", r.Attacks, p.Attacks)
+	changes = diffField(changes, "hit_stats", r.HitStats, p.HitStats)
+	changes = diffField(changes, "wound_strength", r.WoundStrength, p.WoundStrength)
+	changes = diffField(changes, "rend_ap", r.RendAp, p.RendAp)
+	changes = diffField(changes, "damage", r.Damage, p.Damage)
+	changes = diffField(changes, "weapon_type", r.WeaponType, p.WeaponType)
+	changes = diffField(changes, "abilities", r.Abilities, p.Abilities)
+	changes = diffField(changes, "version", r.Version, p.Version)
+	changes = diffField(changes, "source", r.Source, p.Source)
+	return changes
+}
+
+func (sr *Seeder) seedUnitKeywords(unitID uuid.UUID, gameID uuid.UUID, keywordNames []string, version, source string, diff *unitDiff) error {
+	rows, err := sr.getDB().GetKeywordsForUnit(sr.ctx, unitID)
+	if err != nil {
+		return fmt.Errorf("failed to load unit keywords: %w", err)
+	}
+
+	existing := make(map[string]uuid.UUID, len(rows))
+	for _, row := range rows {
+		existing[row.KeywordName] = row.KeywordID
+	}
+
+	seen := make(map[string]bool, len(keywordNames))
 	for _, name := range keywordNames {
-		keywordID, exists := sr.keywordMap[name]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
 
-		if !exists {
-			sr.s.Logger.Info("Creating New Keyword", zap.String("name", name))
-			k, err := sr.getDB().CreateKeyword(sr.ctx, database.CreateKeywordParams{
-				GameID:      gameID,
-				Name:        name,
-				Description: "",
-				Version:     version,
-				Source:      source,
-			})
-			if err != nil {
-				return fmt.Errorf("failed to create keyword %s: %w", name, err)
-			}
-			keywordID = k.ID
-			sr.keywordMap[name] = keywordID
+		if _, linked := existing[name]; linked {
+			delete(existing, name)
+			sr.fileStats.unchanged("unit_keywords")
+			continue
 		}
 
-		err := sr.getDB().AddKeywordToUnit(sr.ctx, database.AddKeywordToUnitParams{
+		keywordID, err := sr.getOrCreateKeyword(gameID, name, version, source)
+		if err != nil {
+			return err
+		}
+
+		err = sr.getDB().AddKeywordToUnit(sr.ctx, database.AddKeywordToUnitParams{
 			UnitID:    unitID,
 			KeywordID: keywordID,
 			Value:     "",
@@ -295,83 +842,614 @@ func (sr *Seeder) seedUnitKeywords(unitID uuid.UUID, gameID uuid.UUID, keywordNa
 		if err != nil {
 			return fmt.Errorf("failed to add keyword %s to unit: %w", name, err)
 		}
+		sr.fileStats.inserted("unit_keywords")
+		diff.keywordAdded(name)
+	}
+
+	for _, row := range rows {
+		if _, stale := existing[row.KeywordName]; !stale {
+			continue
+		}
+
+		err := sr.getDB().RemoveKeywordFromUnit(sr.ctx, database.RemoveKeywordFromUnitParams{
+			UnitID:    unitID,
+			KeywordID: row.KeywordID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove keyword %s from unit: %w", row.KeywordName, err)
+		}
+		sr.fileStats.deleted("unit_keywords", 1)
+		diff.keywordRemoved(row.KeywordName)
 	}
 	return nil
 }
 
+func (sr *Seeder) getOrCreateKeyword(gameID uuid.UUID, name, version, source string) (uuid.UUID, error) {
+	if id, exists := sr.keywordMap[name]; exists {
+		return id, nil
+	}
+	if id, exists := sr.fileKeywords[name]; exists {
+		return id, nil
+	}
+
+	sr.s.Logger.Info("Creating New Keyword", zap.String("name", name))
+	k, err := sr.getDB().CreateKeyword(sr.ctx, database.CreateKeywordParams{
+		GameID:      gameID,
+		Name:        name,
+		Description: "",
+		Version:     version,
+		Source:      source,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create keyword %s: %w", name, err)
+	}
+	sr.fileStats.inserted("keywords")
+	sr.fileKeywords[name] = k.ID
+	return k.ID, nil
+}
+
+// seedUnitRegimentOptions keeps options that are still in the YAML and
+// replaces the rest. An option is nothing but its fields, so there is nothing
+// to update in place.
+func (sr *Seeder) seedUnitRegimentOptions(unitID uuid.UUID, options []models.RegimentOptionSeed) error {
+	existing, err := sr.getDB().GetRegimentOptionsForUnit(sr.ctx, unitID)
+	if err != nil {
+		return fmt.Errorf("failed to load regiment options: %w", err)
+	}
+
+	pool := newRowPool(existing)
+	for _, o := range options {
+		same := func(r database.RegimentOption) bool {
+			return r.Keyword == o.Keyword && r.UnitName == o.UnitName && r.IsHero == o.IsHero
+		}
+
+		_, found := pool.take(same, same)
+		if found {
+			sr.fileStats.unchanged("regiment_options")
+			continue
+		}
+
+		_, err := sr.getDB().CreateRegimentOption(sr.ctx, database.CreateRegimentOptionParams{
+			UnitID:   unitID,
+			Keyword:  o.Keyword,
+			UnitName: o.UnitName,
+			IsHero:   o.IsHero,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create regiment option: %w", err)
+		}
+		sr.fileStats.inserted("regiment_options")
+	}
+
+	for _, o := range pool.remaining() {
+		err := sr.getDB().DeleteRegimentOption(sr.ctx, o.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete regiment option: %w", err)
+		}
+		sr.fileStats.deleted("regiment_options", 1)
+	}
+
+	return nil
+}
+
 func (sr *Seeder) seedUnitAbilities(unitID, factionID, gameID uuid.UUID, abilities []models.AbilitySeed, version, source string) error {
-	for _, a := range abilities {
-		ability, err := sr.getDB().CreateAbility(sr.ctx, database.CreateAbilityParams{
-			UnitID:      database.UUIDToNullUUID(unitID),
-			FactionID:   uuid.NullUUID{},
-			GameID:      uuid.NullUUID{},
-			Name:        a.Name,
-			Description: a.Description,
-			Type:        a.Type,
-			Phase:       a.Phase,
-			Version:     version,
-			Source:      source,
+	existing, err := sr.getDB().GetAbilitiesForUnit(sr.ctx, database.UUIDToNullUUID(unitID))
+	if err != nil {
+		return fmt.Errorf("failed to load unit abilities: %w", err)
+	}
+
+	return sr.syncAbilities(existing, database.CreateAbilityParams{
+		UnitID:    database.UUIDToNullUUID(unitID),
+		FactionID: uuid.NullUUID{},
+		GameID:    uuid.NullUUID{},
+		Version:   version,
+		Source:    source,
+	}, abilities)
+}
+
+func (sr *Seeder) seedFactionAbilities(factionID uuid.UUID, abilities []models.AbilitySeed, version, source string) error {
+	existing, err := sr.getDB().GetAbilitiesForFaction(sr.ctx, database.UUIDToNullUUID(factionID))
+	if err != nil {
+		return fmt.Errorf("failed to load faction abilities: %w", err)
+	}
+
+	return sr.syncAbilities(existing, database.CreateAbilityParams{
+		UnitID:    uuid.NullUUID{},
+		FactionID: database.UUIDToNullUUID(factionID),
+		GameID:    uuid.NullUUID{},
+		Version:   version,
+		Source:    source,
+	}, abilities)
+}
+
+func (sr *Seeder) seedFactionAllowedUnits(factionID uuid.UUID, allowed []models.AllowedUnitSeed) error {
+	existing, err := sr.getDB().GetAllowedUnitsForFaction(sr.ctx, factionID)
+	if err != nil {
+		return fmt.Errorf("failed to load allowed units: %w", err)
+	}
+
+	pool := newRowPool(existing)
+	for _, a := range allowed {
+		same := func(r database.FactionAllowedUnit) bool {
+			return r.UnitName == a.Name && r.BattlescribeID == a.BattlescribeID
+		}
+
+		_, found := pool.take(same, same)
+		if found {
+			sr.fileStats.unchanged("faction_allowed_units")
+			continue
+		}
+
+		_, err := sr.getDB().CreateAllowedUnit(sr.ctx, database.CreateAllowedUnitParams{
+			FactionID:      factionID,
+			UnitName:       a.Name,
+			BattlescribeID: a.BattlescribeID,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to create ability %s: %w", a.Name, err)
+			return fmt.Errorf("failed to create allowed unit: %w", err)
 		}
+		sr.fileStats.inserted("faction_allowed_units")
+	}
 
-		for _, e := range a.Effects {
-			_, err := sr.getDB().CreateAbilityEffect(sr.ctx, database.CreateAbilityEffectParams{
-				AbilityID:   ability.ID,
-				Stat:        e.Stat,
-				Modifier:    int32(e.Modifier),
-				Condition:   e.Condition,
-				Description: e.Description,
-				Version:     version,
-				Source:      source,
+	for _, a := range pool.remaining() {
+		err := sr.getDB().DeleteAllowedUnit(sr.ctx, a.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete allowed unit: %w", err)
+		}
+		sr.fileStats.deleted("faction_allowed_units", 1)
+	}
+
+	return nil
+}
+
+func (sr *Seeder) seedRegimentOfRenownHosts(factionID uuid.UUID, hosts []string) error {
+	existing, err := sr.getDB().GetHostsForRegimentOfRenown(sr.ctx, factionID)
+	if err != nil {
+		return fmt.Errorf("failed to load regiment of renown hosts: %w", err)
+	}
+
+	pool := newRowPool(existing)
+	for _, host := range hosts {
+		same := func(r database.RegimentOfRenownHost) bool { return r.HostFactionName == host }
+
+		_, found := pool.take(same, same)
+		if found {
+			sr.fileStats.unchanged("regiment_of_renown_hosts")
+			continue
+		}
+
+		_, err := sr.getDB().CreateRegimentOfRenownHost(sr.ctx, database.CreateRegimentOfRenownHostParams{
+			FactionID:       factionID,
+			HostFactionName: host,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create regiment of renown host: %w", err)
+		}
+		sr.fileStats.inserted("regiment_of_renown_hosts")
+	}
+
+	for _, h := range pool.remaining() {
+		err := sr.getDB().DeleteRegimentOfRenownHost(sr.ctx, h.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete regiment of renown host: %w", err)
+		}
+		sr.fileStats.deleted("regiment_of_renown_hosts", 1)
+	}
+
+	return nil
+}
+
+func (sr *Seeder) seedGameAbilities(gameID uuid.UUID, abilities []models.AbilitySeed, version, source string) error {
+	existing, err := sr.getDB().GetAbilitiesForGame(sr.ctx, database.UUIDToNullUUID(gameID))
+	if err != nil {
+		return fmt.Errorf("failed to load game abilities: %w", err)
+	}
+
+	return sr.syncAbilities(existing, database.CreateAbilityParams{
+		UnitID:    uuid.NullUUID{},
+		FactionID: uuid.NullUUID{},
+		GameID:    database.UUIDToNullUUID(gameID),
+		Version:   version,
+		Source:    source,
+	}, abilities)
+}
+
+// syncAbilities reconciles the abilities of one owner (unit, faction or
+// game). scope carries the owner columns plus version and source.
+func (sr *Seeder) syncAbilities(existing []database.Ability, scope database.CreateAbilityParams, abilities []models.AbilitySeed) error {
+	pool := newRowPool(existing)
+	for _, a := range abilities {
+		params := scope
+		params.Name = a.Name
+		params.Description = a.Description
+		params.Type = a.Type
+		params.Phase = a.Phase
+		same := func(r database.Ability) bool { return abilityMatches(r, params) }
+
+		row, found := pool.take(func(r database.Ability) bool { return r.Name == a.Name }, same)
+		if !found {
+			err := sr.createAbility(params, a.Effects)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if same(row) {
+			sr.fileStats.unchanged("abilities")
+		} else {
+			_, err := sr.getDB().UpdateAbility(sr.ctx, database.UpdateAbilityParams{
+				ID:          row.ID,
+				Name:        params.Name,
+				Description: params.Description,
+				Type:        params.Type,
+				Phase:       params.Phase,
+				Version:     params.Version,
+				Source:      params.Source,
 			})
 			if err != nil {
-				return fmt.Errorf("failed to create ability effect %s: %w", a.Name, err)
+				return fmt.Errorf("failed to update ability %s: %w", a.Name, err)
 			}
+			sr.fileStats.updated("abilities")
+		}
+
+		effects, err := sr.getDB().GetAbilityEffectsForAbility(sr.ctx, database.UUIDToNullUUID(row.ID))
+		if err != nil {
+			return fmt.Errorf("failed to load ability effects %s: %w", a.Name, err)
+		}
+
+		owner := database.CreateAbilityEffectParams{AbilityID: database.UUIDToNullUUID(row.ID)}
+		err = sr.syncAbilityEffects(owner, effects, a.Effects, params.Version, params.Source)
+		if err != nil {
+			return fmt.Errorf("failed to sync ability effects %s: %w", a.Name, err)
 		}
 	}
 
+	for _, a := range pool.remaining() {
+		err := sr.getDB().DeleteAbility(sr.ctx, a.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete ability %s: %w", a.Name, err)
+		}
+		sr.fileStats.deleted("abilities", 1)
+	}
+
 	return nil
 }
 
-func (sr *Seeder) seedFactionBattleFormations(gameID, factionID uuid.UUID, battleFormations []models.BattleFormationSeed, version, source string) error {
-	for _, b := range battleFormations {
-		_, err := sr.getDB().CreateBattleFormation(sr.ctx, database.CreateBattleFormationParams{
+func abilityMatches(r database.Ability, p database.CreateAbilityParams) bool {
+	return r.Name == p.Name &&
+		r.Description == p.Description &&
+		r.Type == p.Type &&
+		r.Phase == p.Phase &&
+		r.Version == p.Version &&
+		r.Source == p.Source
+}
+
+func (sr *Seeder) createAbility(params database.CreateAbilityParams, effects []models.AbilityEffectSeed) error {
+	ability, err := sr.getDB().CreateAbility(sr.ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to create ability %s: %w", params.Name, err)
+	}
+	sr.fileStats.inserted("abilities")
+
+	owner := database.CreateAbilityEffectParams{AbilityID: database.UUIDToNullUUID(ability.ID)}
+	err = sr.syncAbilityEffects(owner, nil, effects, params.Version, params.Source)
+	if err != nil {
+		return fmt.Errorf("failed to create ability effect %s: %w", params.Name, err)
+	}
+
+	return nil
+}
+
+// syncAbilityEffects has no natural key to go on, so effects are either
+// identical to an existing row or replaced. owner holds the ability,
+// enhancement or battle formation the effects belong to.
+func (sr *Seeder) syncAbilityEffects(owner database.CreateAbilityEffectParams, existing []database.AbilityEffect, effects []models.AbilityEffectSeed, version, source string) error {
+	pool := newRowPool(existing)
+	for _, e := range effects {
+		params := owner
+		params.Stat = e.Stat
+		params.Modifier = int32(e.Modifier)
+		params.Condition = e.Condition
+		params.Description = e.Description
+		params.Version = version
+		params.Source = source
+		same := func(r database.AbilityEffect) bool {
+			return r.Stat == params.Stat &&
+				r.Modifier == params.Modifier &&
+				r.Condition == params.Condition &&
+				r.Description == params.Description &&
+				r.Version == params.Version &&
+				r.Source == params.Source
+		}
+
+		_, found := pool.take(same, same)
+		if found {
+			sr.fileStats.unchanged("ability_effects")
+			continue
+		}
+
+		_, err := sr.getDB().CreateAbilityEffect(sr.ctx, params)
+		if err != nil {
+			return err
+		}
+		sr.fileStats.inserted("ability_effects")
+	}
+
+	for _, e := range pool.remaining() {
+		err := sr.getDB().DeleteAbilityEffect(sr.ctx, e.ID)
+		if err != nil {
+			return err
+		}
+		sr.fileStats.deleted("ability_effects", 1)
+	}
+
+	return nil
+}
+
+func (sr *Seeder) seedGameRules(gameID uuid.UUID, rules []models.RuleSeed, version, source string) error {
+	existing, err := sr.getDB().GetRulesForGame(sr.ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to load rules: %w", err)
+	}
+
+	pool := newRowPool(existing)
+	for _, r := range rules {
+		params := database.CreateRuleParams{
 			GameID:      gameID,
-			FactionID:   factionID,
-			Name:        b.Name,
-			Description: b.Description,
+			Name:        r.Name,
+			Description: r.Description,
+			RuleType:    r.RuleType,
+			Text:        r.Text,
 			Version:     version,
 			Source:      source,
+		}
+		same := func(row database.Rule) bool {
+			return row.Description == params.Description &&
+				row.Text == params.Text &&
+				row.Version == params.Version &&
+				row.Source == params.Source
+		}
+
+		row, found := pool.take(func(row database.Rule) bool {
+			return row.Name == r.Name && row.RuleType == r.RuleType
+		}, same)
+		switch {
+		case !found:
+			_, err = sr.getDB().CreateRule(sr.ctx, params)
+			if err != nil {
+				return fmt.Errorf("failed to create rule %s: %w", r.Name, err)
+			}
+			sr.fileStats.inserted("rules")
+		case same(row):
+			sr.fileStats.unchanged("rules")
+		default:
+			_, err = sr.getDB().UpdateRule(sr.ctx, database.UpdateRuleParams{
+				ID:          row.ID,
+				Name:        params.Name,
+				Description: params.Description,
+				RuleType:    params.RuleType,
+				Version:     params.Version,
+				Source:      params.Source,
+				Text:        params.Text,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update rule %s: %w", r.Name, err)
+			}
+			sr.fileStats.updated("rules")
+		}
+	}
+
+	for _, r := range pool.remaining() {
+		err := sr.getDB().DeleteRule(sr.ctx, r.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete rule %s: %w", r.Name, err)
+		}
+		sr.fileStats.deleted("rules", 1)
+	}
+
+	return nil
+}
+
+func (sr *Seeder) seedFactionBattleFormations(gameID, factionID uuid.UUID, battleFormations []models.BattleFormationSeed, version, source string) error {
+	existing, err := sr.getDB().GetBattleFormationsForFaction(sr.ctx, factionID)
+	if err != nil {
+		return fmt.Errorf("failed to load battle formations: %w", err)
+	}
+
+	pool := newRowPool(existing)
+	for _, b := range battleFormations {
+		same := func(r database.BattleFormation) bool {
+			return r.Description == b.Description && r.Version == version && r.Source == source
+		}
+
+		row, found := pool.take(func(r database.BattleFormation) bool { return r.Name == b.Name }, same)
+		switch {
+		case !found:
+			row, err = sr.getDB().CreateBattleFormation(sr.ctx, database.CreateBattleFormationParams{
+				GameID:      gameID,
+				FactionID:   factionID,
+				Name:        b.Name,
+				Description: b.Description,
+				Version:     version,
+				Source:      source,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create battleformation %s: %w", b.Name, err)
+			}
+			sr.fileStats.inserted("battle_formations")
+		case same(row):
+			sr.fileStats.unchanged("battle_formations")
+		default:
+			_, err = sr.getDB().UpdateBattleFormation(sr.ctx, database.UpdateBattleFormationParams{
+				ID:          row.ID,
+				Name:        b.Name,
+				Description: b.Description,
+				Version:     version,
+				Source:      source,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update battleformation %s: %w", b.Name, err)
+			}
+			sr.fileStats.updated("battle_formations")
+		}
+
+		var effects []database.AbilityEffect
+		if found {
+			effects, err = sr.getDB().GetAbilityEffectsForBattleFormation(sr.ctx, database.UUIDToNullUUID(row.ID))
+			if err != nil {
+				return fmt.Errorf("failed to load battleformation effects %s: %w", b.Name, err)
+			}
+		}
+
+		owner := database.CreateAbilityEffectParams{BattleFormationID: database.UUIDToNullUUID(row.ID)}
+		err = sr.syncAbilityEffects(owner, effects, b.Effects, version, source)
+		if err != nil {
+			return fmt.Errorf("failed to sync battleformation effects %s: %w", b.Name, err)
+		}
+	}
+
+	for _, b := range pool.remaining() {
+		deleted, err := sr.deleteUnlessInUse(func(q *database.Queries) error {
+			return q.DeleteBattleFormation(sr.ctx, b.ID)
 		})
 		if err != nil {
-			return fmt.Errorf("failed to create battleformation %s: %w", b.Name, err)
+			return fmt.Errorf("failed to delete battleformation %s: %w", b.Name, err)
+		}
+		if !deleted {
+			sr.keptInUse(sr.fileStats, "battle_formations", b.Name)
+			continue
 		}
+		sr.fileStats.deleted("battle_formations", 1)
 	}
 	return nil
 }
 
 func (sr *Seeder) seedFactionEnhancements(factionID uuid.UUID, enhancements []models.EnhancementSeed, version, source string) error {
+	existing, err := sr.getDB().GetEnhancementsForFaction(sr.ctx, factionID)
+	if err != nil {
+		return fmt.Errorf("failed to load enhancements: %w", err)
+	}
+
+	pool := newRowPool(existing)
 	for _, e := range enhancements {
-		_, err := sr.getDB().CreateEnhancement(sr.ctx, database.CreateEnhancementParams{
-			FactionID:       factionID,
-			Name:            e.Name,
-			EnhancementType: e.EnhancementType,
-			Description:     e.Description,
-			Restrictions:    e.Restrictions,
-			Points:          int32(e.Points),
-			Version:         version,
-			Source:          source,
+		requiredKeywords := e.RequiredKeywords
+		if requiredKeywords == nil {
+			requiredKeywords = [][]string{}
+		}
+		requiredJSON, err := json.Marshal(requiredKeywords)
+		if err != nil {
+			return fmt.Errorf("failed to marshal required keywords for %s: %w", e.Name, err)
+		}
+
+		params := database.CreateEnhancementParams{
+			FactionID:        factionID,
+			Name:             e.Name,
+			EnhancementType:  e.EnhancementType,
+			Description:      e.Description,
+			Restrictions:     e.Restrictions,
+			RequiredKeywords: requiredJSON,
+			Points:           int32(e.Points),
+			IsUnique:         e.IsUnique,
+			Version:          version,
+			Source:           source,
+			BattlescribeID:   e.BattlescribeID,
+		}
+		same := func(r database.Enhancement) bool { return enhancementMatches(r, params) }
+
+		row, found := pool.take(func(r database.Enhancement) bool {
+			return e.BattlescribeID != "" && r.BattlescribeID == e.BattlescribeID
+		}, same)
+		if !found {
+			row, found = pool.take(func(r database.Enhancement) bool {
+				return r.Name == e.Name && (r.BattlescribeID == "" || e.BattlescribeID == "")
+			}, same)
+		}
+
+		switch {
+		case !found:
+			row, err = sr.getDB().CreateEnhancement(sr.ctx, params)
+			if err != nil {
+				return fmt.Errorf("failed to create enhancement %s: %w", e.Name, err)
+			}
+			sr.fileStats.inserted("enhancements")
+		case same(row):
+			sr.fileStats.unchanged("enhancements")
+		default:
+			_, err = sr.getDB().UpdateEnhancement(sr.ctx, database.UpdateEnhancementParams{
+				ID:               row.ID,
+				Name:             params.Name,
+				EnhancementType:  params.EnhancementType,
+				Description:      params.Description,
+				Points:           params.Points,
+				Version:          params.Version,
+				Source:           params.Source,
+				IsUnique:         params.IsUnique,
+				Restrictions:     params.Restrictions,
+				BattlescribeID:   params.BattlescribeID,
+				RequiredKeywords: params.RequiredKeywords,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update enhancement %s: %w", e.Name, err)
+			}
+			sr.fileStats.updated("enhancements")
+		}
+
+		var effects []database.AbilityEffect
+		if found {
+			effects, err = sr.getDB().GetAbilityEffectsForEnhancement(sr.ctx, database.UUIDToNullUUID(row.ID))
+			if err != nil {
+				return fmt.Errorf("failed to load enhancement effects %s: %w", e.Name, err)
+			}
+		}
+
+		owner := database.CreateAbilityEffectParams{EnhancementID: database.UUIDToNullUUID(row.ID)}
+		err = sr.syncAbilityEffects(owner, effects, e.Effects, version, source)
+		if err != nil {
+			return fmt.Errorf("failed to sync enhancement effects %s: %w", e.Name, err)
+		}
+	}
+
+	for _, e := range pool.remaining() {
+		deleted, err := sr.deleteUnlessInUse(func(q *database.Queries) error {
+			return q.DeleteEnhancement(sr.ctx, e.ID)
 		})
 		if err != nil {
-			return fmt.Errorf("failed to create enhancement %s: %w", e.Name, err)
+			return fmt.Errorf("failed to delete enhancement %s: %w", e.Name, err)
 		}
+		if !deleted {
+			sr.keptInUse(sr.fileStats, "enhancements", e.Name)
+			continue
+		}
+		sr.fileStats.deleted("enhancements", 1)
 	}
 
 	return nil
 }
 
+func enhancementMatches(r database.Enhancement, p database.CreateEnhancementParams) bool {
+	return r.Name == p.Name &&
+		r.EnhancementType == p.EnhancementType &&
+		r.Description == p.Description &&
+		r.Restrictions == p.Restrictions &&
+		sameJSON(r.RequiredKeywords, p.RequiredKeywords) &&
+		r.Points == p.Points &&
+		r.IsUnique == p.IsUnique &&
+		r.Version == p.Version &&
+		r.Source == p.Source &&
+		r.BattlescribeID == p.BattlescribeID
+}
+
+// sameJSON compares JSONB values semantically, since postgres normalises
+// whitespace and key order on the way back out.
+func sameJSON(a, b []byte) bool {
+	var av, bv any
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
 func cleanStat(s string) string {
 	if s == "" {
 		return "-"
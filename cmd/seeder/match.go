@@ -0,0 +1,52 @@
+package main
+
+// rowPool holds the rows that already exist under one parent. Seed entries
+// claim rows as they are matched; whatever is left unclaimed afterwards has
+// vanished from the YAML and gets deleted.
+type rowPool[T any] struct {
+	rows    []T
+	claimed []bool
+}
+
+func newRowPool[T any](rows []T) *rowPool[T] {
+	return &rowPool[T]{
+		rows:    rows,
+		claimed: make([]bool, len(rows)),
+	}
+}
+
+// take claims a row accepted by match. When several rows share a natural key
+// (duplicate names are legal in the source data) a row for which same
+// reports true is preferred, so unchanged duplicates keep their IDs.
+func (p *rowPool[T]) take(match, same func(T) bool) (T, bool) {
+	found := -1
+	for i, row := range p.rows {
+		if p.claimed[i] || !match(row) {
+			continue
+		}
+		if same(row) {
+			found = i
+			break
+		}
+		if found == -1 {
+			found = i
+		}
+	}
+
+	if found == -1 {
+		var zero T
+		return zero, false
+	}
+	p.claimed[found] = true
+	return p.rows[found], true
+}
+
+func (p *rowPool[T]) remaining() []T {
+	var rest []T
+	for i, row := range p.rows {
+		if !p.claimed[i] {
+			rest = append(rest, row)
+		}
+	}
+	return rest
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+type tableStats struct {
+	Inserted  int `json:"inserted"`
+	Updated   int `json:"updated"`
+	Deleted   int `json:"deleted"`
+	Unchanged int `json:"unchanged"`
+}
+
+// seedStats counts row changes per table. Each file gets its own counters,
+// merged into the run total only once its transaction commits.
+type seedStats map[string]*tableStats
+
+func (st seedStats) table(name string) *tableStats {
+	t, ok := st[name]
+	if !ok {
+		t = &tableStats{}
+		st[name] = t
+	}
+	return t
+}
+
+func (st seedStats) inserted(table string) {
+	st.table(table).Inserted++
+}
+
+func (st seedStats) updated(table string) {
+	st.table(table).Updated++
+}
+
+func (st seedStats) deleted(table string, n int) {
+	st.table(table).Deleted += n
+}
+
+func (st seedStats) unchanged(table string) {
+	st.table(table).Unchanged++
+}
+
+func (st seedStats) merge(other seedStats) {
+	for name, t := range other {
+		total := st.table(name)
+		total.Inserted += t.Inserted
+		total.Updated += t.Updated
+		total.Deleted += t.Deleted
+		total.Unchanged += t.Unchanged
+	}
+}
+
+func (st seedStats) write(w io.Writer) error {
+	names := make([]string, 0, len(st))
+	for name := range st {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "table\tinserted\tupdated\tdeleted\tunchanged\t")
+	for _, name := range names {
+		t := st[name]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t\n", name, t.Inserted, t.Updated, t.Deleted, t.Unchanged)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,220 @@
+// Package battlescribe reads and writes BattleScribe rosters: .ros files and
+// the zipped .rosz files the app saves by default.
+package battlescribe
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Namespace is the XML namespace of roster files.
+	Namespace = "http://www.battlescribe.net/schema/rosterSchema"
+	// Version is the BattleScribe data format rosters are written in.
+	Version = "2.03"
+	// PointsTypeID is the cost type points are recorded under.
+	PointsTypeID = "points"
+	// PointsName is the name BattleScribe shows points costs under.
+	PointsName = "pts"
+)
+
+// Roles of the force entries a game stores in its battlescribe_forces: the
+// army itself and the regiment and auxiliary forces an Age of Sigmar army
+// adds inside it.
+const (
+	ForceArmy      = "army"
+	ForceRegiment  = "regiment"
+	ForceAuxiliary = "auxiliary"
+)
+
+// maxRosterSize caps how much of a .rosz archive is inflated.
+const maxRosterSize = 16 << 20
+
+var (
+	// ErrNoRoster is returned when a .rosz archive holds no .ros file.
+	ErrNoRoster = errors.New("battlescribe: archive holds no roster")
+	// ErrRosterTooLarge is returned when a roster inflates past maxRosterSize.
+	ErrRosterTooLarge = errors.New("battlescribe: roster too large")
+)
+
+var zipMagic = []byte("PK\x03\x04")
+
+// Roster is the root element of a .ros file.
+type Roster struct {
+	XMLName             xml.Name `xml:"roster"`
+	Xmlns               string   `xml:"xmlns,attr,omitempty"`
+	ID                  string   `xml:"id,attr"`
+	Name                string   `xml:"name,attr"`
+	BattleScribeVersion string   `xml:"battleScribeVersion,attr"`
+	GameSystemID        string   `xml:"gameSystemId,attr"`
+	GameSystemName      string   `xml:"gameSystemName,attr"`
+	GameSystemRevision  string   `xml:"gameSystemRevision,attr,omitempty"`
+	Costs               []Cost   `xml:"costs>cost"`
+	CostLimits          []Cost   `xml:"costLimits>costLimit"`
+	Forces              []Force  `xml:"forces>force"`
+}
+
+// Force is a force added to a roster, or to another force. Its EntryID is
+// the force entry of the game system it was built from.
+type Force struct {
+	ID                string      `xml:"id,attr"`
+	Name              string      `xml:"name,attr"`
+	EntryID           string      `xml:"entryId,attr"`
+	CatalogueID       string      `xml:"catalogueId,attr"`
+	CatalogueRevision string      `xml:"catalogueRevision,attr,omitempty"`
+	CatalogueName     string      `xml:"catalogueName,attr"`
+	Selections        []Selection `xml:"selections>selection"`
+	Categories        []Category  `xml:"categories>category"`
+	Forces            []Force     `xml:"forces>force"`
+}
+
+// Selection is a unit, model or upgrade picked in a force. Selections
+// reached through entry links carry the link and target IDs in EntryID,
+// joined by "::".
+type Selection struct {
+	ID           string      `xml:"id,attr"`
+	Name         string      `xml:"name,attr"`
+	EntryID      string      `xml:"entryId,attr"`
+	EntryGroupID string      `xml:"entryGroupId,attr,omitempty"`
+	Number       int         `xml:"number,attr"`
+	Type         string      `xml:"type,attr"`
+	Selections   []Selection `xml:"selections>selection"`
+	Categories   []Category  `xml:"categories>category"`
+	Costs        []Cost      `xml:"costs>cost"`
+}
+
+type Category struct {
+	ID      string `xml:"id,attr"`
+	Name    string `xml:"name,attr"`
+	EntryID string `xml:"entryId,attr"`
+	Primary bool   `xml:"primary,attr"`
+}
+
+// Cost is used for both costs and cost limits.
+type Cost struct {
+	Name   string  `xml:"name,attr"`
+	TypeID string  `xml:"typeId,attr"`
+	Value  float64 `xml:"value,attr"`
+}
+
+// Read parses a roster from the contents of a .ros file, or of a .rosz
+// archive holding one.
+func Read(data []byte) (Roster, error) {
+	if bytes.HasPrefix(data, zipMagic) {
+		unzipped, err := unzipRoster(data)
+		if err != nil {
+			return Roster{}, err
+		}
+		data = unzipped
+	}
+
+	var roster Roster
+	err := xml.Unmarshal(data, &roster)
+	if err != nil {
+		return Roster{}, fmt.Errorf("battlescribe: %w", err)
+	}
+	return roster, nil
+}
+
+func unzipRoster(data []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("battlescribe: %w", err)
+	}
+
+	for _, f := range archive.File {
+		if !strings.EqualFold(path.Ext(f.Name), ".ros") {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("battlescribe: %w", err)
+		}
+		defer rc.Close()
+
+		unzipped, err := io.ReadAll(io.LimitReader(rc, maxRosterSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("battlescribe: %w", err)
+		}
+		if len(unzipped) > maxRosterSize {
+			return nil, ErrRosterTooLarge
+		}
+		return unzipped, nil
+	}
+
+	return nil, ErrNoRoster
+}
+
+// Write renders roster as a .ros file, filling in the namespace and format
+// version when they are not set.
+func Write(roster Roster) ([]byte, error) {
+	if roster.Xmlns == "" {
+		roster.Xmlns = Namespace
+	}
+	if roster.BattleScribeVersion == "" {
+		roster.BattleScribeVersion = Version
+	}
+
+	data, err := xml.MarshalIndent(roster, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("battlescribe: %w", err)
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.Write(data)
+	b.WriteString("\n")
+	return b.Bytes(), nil
+}
+
+// NewID returns a fresh ID for a roster, force or selection.
+func NewID() string {
+	return uuid.NewString()
+}
+
+// EntryIDs splits an entry ID into the IDs of the links and entry it was
+// reached through.
+func EntryIDs(entryID string) []string {
+	if entryID == "" {
+		return nil
+	}
+	return strings.Split(entryID, "::")
+}
+
+// HasEntryID reports whether entryID was reached through id.
+func HasEntryID(entryID, id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, part := range EntryIDs(entryID) {
+		if part == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Points returns the points among costs.
+func Points(costs []Cost) int {
+	total := 0.0
+	for _, c := range costs {
+		if c.TypeID == PointsTypeID || c.Name == PointsName {
+			total += c.Value
+		}
+	}
+	return int(total)
+}
+
+// PointsCost returns costs holding the given points.
+func PointsCost(points int) []Cost {
+	return []Cost{{Name: PointsName, TypeID: PointsTypeID, Value: float64(points)}}
+}
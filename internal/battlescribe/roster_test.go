@@ -0,0 +1,164 @@
+package battlescribe
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testRoster = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<roster id="r1" name="Test List" battleScribeVersion="2.03" gameSystemId="gs1" gameSystemName="Age of Sigmar 4.0" gameSystemRevision="76" xmlns="http://www.battlescribe.net/schema/rosterSchema">
+  <costs>
+    <cost name="pts" typeId="points" value="240.0"/>
+  </costs>
+  <costLimits>
+    <costLimit name="pts" typeId="points" value="2000.0"/>
+  </costLimits>
+  <forces>
+    <force id="f1" name="General's Handbook" entryId="army1" catalogueId="cat1" catalogueRevision="36" catalogueName="Stormcast Eternals">
+      <selections>
+        <selection id="s1" name="Battle Formation" entryId="bf1" number="1" type="upgrade"/>
+      </selections>
+      <forces>
+        <force id="f2" name="Regiment" entryId="reg1" catalogueId="cat1" catalogueName="Stormcast Eternals">
+          <selections>
+            <selection id="s2" name="Lord-Celestant" entryId="link1::unit1" number="1" type="model">
+              <selections>
+                <selection id="s3" name="General" entryId="gen1" number="1" type="upgrade"/>
+              </selections>
+              <categories>
+                <category id="c1" name="HERO" entryId="hero1" primary="true"/>
+              </categories>
+              <costs>
+                <cost name="pts" typeId="points" value="120.0"/>
+              </costs>
+            </selection>
+          </selections>
+        </force>
+      </forces>
+    </force>
+  </forces>
+</roster>
+`
+
+func TestRead(t *testing.T) {
+	roster, err := Read([]byte(testRoster))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	if roster.Name != "Test List" || roster.GameSystemID != "gs1" {
+		t.Errorf("unexpected roster header: %+v", roster)
+	}
+	if Points(roster.CostLimits) != 2000 {
+		t.Errorf("expected a 2000 points limit, got %d", Points(roster.CostLimits))
+	}
+	if len(roster.Forces) != 1 || len(roster.Forces[0].Forces) != 1 {
+		t.Fatalf("expected one army force with one child force, got %+v", roster.Forces)
+	}
+
+	regiment := roster.Forces[0].Forces[0]
+	if regiment.EntryID != "reg1" || len(regiment.Selections) != 1 {
+		t.Fatalf("unexpected regiment force: %+v", regiment)
+	}
+
+	unit := regiment.Selections[0]
+	if !HasEntryID(unit.EntryID, "unit1") || HasEntryID(unit.EntryID, "unit") {
+		t.Errorf("unexpected entry id match for %q", unit.EntryID)
+	}
+	if Points(unit.Costs) != 120 {
+		t.Errorf("expected 120 points, got %d", Points(unit.Costs))
+	}
+	if len(unit.Selections) != 1 || unit.Selections[0].Name != "General" {
+		t.Errorf("expected a General upgrade, got %+v", unit.Selections)
+	}
+	if len(unit.Categories) != 1 || !unit.Categories[0].Primary {
+		t.Errorf("expected a primary category, got %+v", unit.Categories)
+	}
+}
+
+func TestRead_Zipped(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("Test List.ros")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = w.Write([]byte(testRoster))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = zw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roster, err := Read(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if roster.Name != "Test List" {
+		t.Errorf("expected Test List, got %q", roster.Name)
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	_, err := zw.Create("notes.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = zw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Read(buf.Bytes())
+	if !errors.Is(err, ErrNoRoster) {
+		t.Errorf("expected ErrNoRoster, got %v", err)
+	}
+
+	_, err = Read([]byte(`<catalogue id="c1" name="Not a roster"/>`))
+	if err == nil {
+		t.Error("expected an error for a catalogue")
+	}
+
+	_, err = Read([]byte("Stormcast Eternals 2000 pts"))
+	if err == nil {
+		t.Error("expected an error for plain text")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	roster, err := Read([]byte(testRoster))
+	if err != nil {
+		t.Fatal(err)
+	}
+	roster.Xmlns = ""
+	roster.BattleScribeVersion = ""
+
+	data, err := Write(roster)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	out := string(data)
+	if !strings.HasPrefix(out, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`) {
+		t.Errorf("missing XML declaration:\n%s", out)
+	}
+	if !strings.Contains(out, `xmlns="`+Namespace+`"`) || !strings.Contains(out, `battleScribeVersion="`+Version+`"`) {
+		t.Errorf("missing namespace or version:\n%s", out)
+	}
+
+	again, err := Read(data)
+	if err != nil {
+		t.Fatalf("Read of written roster failed: %v", err)
+	}
+	unit := again.Forces[0].Forces[0].Selections[0]
+	if unit.EntryID != "link1::unit1" || Points(unit.Costs) != 120 || unit.Selections[0].Name != "General" {
+		t.Errorf("roster did not round trip: %+v", unit)
+	}
+}
@@ -0,0 +1,168 @@
+// Package combat works out what a weapon profile does to a target: the
+// expected damage of its attack sequence and the distribution around it.
+package combat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
+)
+
+// ErrUnsupported is returned for profiles the attack sequence cannot be
+// worked out for, such as a damage of "See 'Bloodwrack Stare' ability".
+var ErrUnsupported = errors.New("unsupported profile")
+
+// Crit effects of a weapon, triggered by an unmodified hit roll of 6.
+const (
+	CritNone      = "none"
+	CritMortal    = "mortal"
+	CritTwoHits   = "2_hits"
+	CritAutoWound = "auto_wound"
+)
+
+// critAbilities maps the weapon ability names of the core rules to crit
+// effects.
+var critAbilities = map[string]string{
+	"crit (mortal)":     CritMortal,
+	"crit (2 hits)":     CritTwoHits,
+	"crit (auto-wound)": CritAutoWound,
+}
+
+// IsCrit reports whether crit is one of the crit effects.
+func IsCrit(crit string) bool {
+	switch crit {
+	case CritNone, CritMortal, CritTwoHits, CritAutoWound:
+		return true
+	}
+	return false
+}
+
+// CritFromAbilities finds the crit effect named in a weapon's abilities, such
+// as "Crit (Mortal), Shoot in Combat".
+func CritFromAbilities(abilities string) string {
+	text := strings.ToLower(abilities)
+	for name, crit := range critAbilities {
+		if strings.Contains(text, name) {
+			return crit
+		}
+	}
+	return CritNone
+}
+
+// Profile is a weapon and the number of models attacking with it.
+type Profile struct {
+	Models int
+	Stats  stats.WeaponStats
+	Crit   string
+}
+
+// Target holds the stats of the unit attacked. Values of kind none are
+// simply missing: no save, no ward.
+type Target struct {
+	Save      stats.Value
+	Ward      stats.Value
+	Invuln    stats.Value
+	Toughness stats.Value
+}
+
+// Check returns why p cannot be rolled against t, or nil if it can.
+func Check(p Profile, t Target) error {
+	_, err := newSequence(p, t)
+	return err
+}
+
+// sequence holds the chances of each step of the attack sequence for one
+// profile against one target.
+type sequence struct {
+	hit    float64
+	crit   float64
+	wound  float64
+	save   float64
+	ward   float64
+	crits  string
+	damage stats.Value
+}
+
+func newSequence(p Profile, t Target) (sequence, error) {
+	if p.Stats.HitStats.Kind != stats.KindThreshold {
+		return sequence{}, fmt.Errorf("%w: hit %q", ErrUnsupported, p.Stats.HitStats.Raw)
+	}
+	switch p.Stats.Attacks.Kind {
+	case stats.KindFixed, stats.KindDice:
+	default:
+		return sequence{}, fmt.Errorf("%w: attacks %q", ErrUnsupported, p.Stats.Attacks.Raw)
+	}
+	switch p.Stats.Damage.Kind {
+	case stats.KindFixed, stats.KindDice:
+	default:
+		return sequence{}, fmt.Errorf("%w: damage %q", ErrUnsupported, p.Stats.Damage.Raw)
+	}
+	if p.Stats.RendAP.Kind != stats.KindRend {
+		return sequence{}, fmt.Errorf("%w: rend %q", ErrUnsupported, p.Stats.RendAP.Raw)
+	}
+
+	woundTarget, err := woundTarget(p.Stats.WoundStrength, t.Toughness)
+	if err != nil {
+		return sequence{}, err
+	}
+
+	crit := p.Crit
+	if crit == "" {
+		crit = CritNone
+	}
+
+	seq := sequence{
+		hit:    stats.ThresholdChance(p.Stats.HitStats.Min),
+		crit:   1.0 / 6,
+		wound:  stats.ThresholdChance(woundTarget),
+		crits:  crit,
+		damage: p.Stats.Damage,
+	}
+
+	if t.Save.Kind == stats.KindThreshold {
+		seq.save = saveChance(t.Save.Min + p.Stats.RendAP.Min)
+	}
+	if t.Invuln.Kind == stats.KindThreshold {
+		seq.save = max(seq.save, saveChance(t.Invuln.Min))
+	}
+	if t.Ward.Kind == stats.KindThreshold {
+		seq.ward = saveChance(t.Ward.Min)
+	}
+	return seq, nil
+}
+
+// woundTarget is the wound roll needed: the weapon's own in Age of Sigmar, or
+// strength against toughness in Warhammer 40,000.
+func woundTarget(wound, toughness stats.Value) (int, error) {
+	if wound.Kind == stats.KindThreshold {
+		return wound.Min, nil
+	}
+	if wound.Kind != stats.KindFixed || toughness.Kind != stats.KindFixed {
+		return 0, fmt.Errorf("%w: wound %q against toughness %q", ErrUnsupported, wound.Raw, toughness.Raw)
+	}
+
+	s, t := wound.Min, toughness.Min
+	switch {
+	case s >= 2*t:
+		return 2, nil
+	case s > t:
+		return 3, nil
+	case s == t:
+		return 4, nil
+	case 2*s <= t:
+		return 6, nil
+	default:
+		return 5, nil
+	}
+}
+
+// saveChance is the chance of a save or ward roll of target or more. Unlike
+// hit and wound rolls, a save worsened past 6 cannot be made.
+func saveChance(target int) float64 {
+	if target > 6 {
+		return 0
+	}
+	return stats.ThresholdChance(target)
+}
@@ -0,0 +1,116 @@
+package combat
+
+import (
+	"math"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/dice"
+)
+
+// Expected is the average outcome of a profile's attack sequence, step by
+// step, and the distribution of the damage it deals after wards.
+type Expected struct {
+	Attacks      float64
+	Hits         float64
+	Crits        float64
+	Wounds       float64
+	Unsaved      float64
+	MortalDamage float64
+	Damage       float64
+	Distribution dice.Distribution
+}
+
+// ExpectedDamage works out the attack sequence of p against t. Damage is
+// summed over the whole unit; it does not spill between models.
+func ExpectedDamage(p Profile, t Target) (Expected, error) {
+	seq, err := newSequence(p, t)
+	if err != nil {
+		return Expected{}, err
+	}
+
+	models := max(p.Models, 1)
+	attacks := float64(models) * p.Stats.Attacks.Mean
+
+	normalHits := seq.hit - seq.crit
+	hitsToWound := normalHits
+	autoWounds, mortals := 0.0, 0.0
+	switch seq.crits {
+	case CritNone:
+		hitsToWound += seq.crit
+	case CritTwoHits:
+		hitsToWound += 2 * seq.crit
+	case CritAutoWound:
+		autoWounds = seq.crit
+	case CritMortal:
+		mortals = seq.crit
+	}
+
+	wounds := hitsToWound*seq.wound + autoWounds
+	unsaved := wounds * (1 - seq.save)
+	perDamage := p.Stats.Damage.Mean * (1 - seq.ward)
+
+	e := Expected{
+		Attacks:      attacks,
+		Hits:         attacks * seq.hit,
+		Crits:        attacks * seq.crit,
+		Wounds:       attacks * wounds,
+		Unsaved:      attacks * unsaved,
+		MortalDamage: attacks * mortals * perDamage,
+		Damage:       attacks * (unsaved + mortals) * perDamage,
+	}
+
+	perModel := p.Stats.Attacks.Expr().Distribution().Repeat(models)
+	e.Distribution = seq.attackDistribution().Compound(perModel)
+	return e, nil
+}
+
+// attackDistribution is the damage of a single attack, misses included.
+func (seq sequence) attackDistribution() dice.Distribution {
+	nothing := dice.Fixed(0).Distribution()
+	damage := thin(seq.damage.Expr().Distribution(), 1-seq.ward)
+
+	wounded := dice.Mixture([]float64{1 - seq.save, seq.save}, []dice.Distribution{damage, nothing})
+	hit := dice.Mixture([]float64{seq.wound, 1 - seq.wound}, []dice.Distribution{wounded, nothing})
+
+	crit := hit
+	switch seq.crits {
+	case CritTwoHits:
+		crit = hit.Add(hit)
+	case CritAutoWound:
+		crit = wounded
+	case CritMortal:
+		crit = damage
+	}
+
+	return dice.Mixture(
+		[]float64{seq.hit - seq.crit, seq.crit, 1 - seq.hit},
+		[]dice.Distribution{hit, crit, nothing},
+	)
+}
+
+// thin returns the distribution of the damage points of dist that each get
+// through with chance keep, as each point is rolled for separately by wards.
+func thin(dist dice.Distribution, keep float64) dice.Distribution {
+	if keep >= 1 {
+		return dist
+	}
+
+	weights := make([]float64, 0, len(dist))
+	dists := make([]dice.Distribution, 0, len(dist))
+	for _, o := range dist {
+		weights = append(weights, o.Probability)
+		dists = append(dists, binomial(o.Value, keep))
+	}
+	return dice.Mixture(weights, dists)
+}
+
+func binomial(n int, p float64) dice.Distribution {
+	dist := make(dice.Distribution, 0, n+1)
+	for k := 0; k <= n; k++ {
+		ways := 1.0
+		for i := 0; i < k; i++ {
+			ways = ways * float64(n-i) / float64(i+1)
+		}
+		dist = append(dist, dice.Outcome{Value: k, Probability: ways * math.Pow(p, float64(k)) * math.Pow(1-p, float64(n-k))})
+	}
+	return dist
+}
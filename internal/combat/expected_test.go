@@ -0,0 +1,145 @@
+package combat
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
+)
+
+func weapon(t *testing.T, attacks, hit, wound, rend, damage string) stats.WeaponStats {
+	t.Helper()
+	parsed, unparsed := stats.ParseWeapon(stats.WeaponLine{Attacks: attacks, HitStats: hit, WoundStrength: wound, RendAP: rend, Damage: damage})
+	if len(unparsed) > 0 {
+		t.Fatalf("unparseable test weapon: %+v", unparsed)
+	}
+	return parsed
+}
+
+func target(t *testing.T, save, ward string) Target {
+	t.Helper()
+	parsed, unparsed := stats.ParseUnit(stats.UnitLine{Save: save, WardFNP: ward})
+	if len(unparsed) > 0 {
+		t.Fatalf("unparseable test target: %+v", unparsed)
+	}
+	return Target{Save: parsed.Save, Ward: parsed.WardFNP}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestExpectedDamage(t *testing.T) {
+	p := Profile{Models: 5, Stats: weapon(t, "2", "3+", "4+", "1", "1")}
+
+	e, err := ExpectedDamage(p, target(t, "4+", ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 10 attacks, 2/3 hit, 1/2 wound, 5+ save after rend.
+	if !near(e.Attacks, 10) || !near(e.Hits, 10*4.0/6) || !near(e.Wounds, 10*4.0/6/2) {
+		t.Errorf("unexpected attack sequence %+v", e)
+	}
+	if !near(e.Damage, 10*4.0/6/2*4/6) || e.MortalDamage != 0 {
+		t.Errorf("expected %v damage, got %+v", 10*4.0/6/2*4/6, e)
+	}
+	if !near(e.Distribution.Mean(), e.Damage) || e.Distribution[len(e.Distribution)-1].Value != 10 {
+		t.Errorf("expected a distribution of 0 to 10 matching the mean, got %+v", e.Distribution)
+	}
+}
+
+func TestExpectedDamage_DistributionMatchesMean(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      Profile
+		target Target
+	}{
+		{name: "dice attacks and damage", p: Profile{Models: 3, Stats: weapon(t, "D3", "4+", "3+", "2", "D3+1")}, target: target(t, "3+", "")},
+		{name: "ward", p: Profile{Models: 2, Stats: weapon(t, "3", "3+", "3+", "-", "2")}, target: target(t, "5+", "5+")},
+		{name: "crit mortal", p: Profile{Models: 4, Stats: weapon(t, "2", "3+", "4+", "-", "D3"), Crit: CritMortal}, target: target(t, "3+", "6+")},
+		{name: "crit two hits", p: Profile{Models: 4, Stats: weapon(t, "2", "3+", "4+", "1", "1"), Crit: CritTwoHits}, target: target(t, "4+", "")},
+		{name: "crit auto-wound", p: Profile{Models: 4, Stats: weapon(t, "2", "3+", "5+", "1", "2"), Crit: CritAutoWound}, target: target(t, "4+", "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := ExpectedDamage(tt.p, tt.target)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !near(e.Distribution.Mean(), e.Damage) {
+				t.Errorf("expected the distribution to average %v, got %v", e.Damage, e.Distribution.Mean())
+			}
+
+			total := 0.0
+			for _, o := range e.Distribution {
+				total += o.Probability
+			}
+			if !near(total, 1) {
+				t.Errorf("expected probabilities to add up to 1, got %v", total)
+			}
+		})
+	}
+}
+
+func TestExpectedDamage_CritMortal(t *testing.T) {
+	p := Profile{Models: 6, Stats: weapon(t, "1", "4+", "4+", "-", "1"), Crit: CritMortal}
+
+	e, err := ExpectedDamage(p, target(t, "2+", ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// One crit in six attacks on average ignores the 2+ save.
+	if !near(e.Crits, 1) || !near(e.MortalDamage, 1) {
+		t.Errorf("expected one mortal damage, got %+v", e)
+	}
+	if !near(e.Damage, 1+6*(2.0/6)*0.5*(1.0/6)) {
+		t.Errorf("unexpected damage %v", e.Damage)
+	}
+}
+
+func TestExpectedDamage_StrengthAgainstToughness(t *testing.T) {
+	p := Profile{Models: 1, Stats: weapon(t, "6", "3+", "8", "-2", "1")}
+	tough, _ := stats.ParseAmount("4")
+
+	e, err := ExpectedDamage(p, Target{Toughness: tough})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Strength 8 against toughness 4 wounds on a 2+.
+	if !near(e.Wounds, 4*5.0/6) {
+		t.Errorf("expected wounds on 2+, got %v", e.Wounds)
+	}
+
+	_, err = ExpectedDamage(p, Target{})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected strength without toughness to be unsupported, got %v", err)
+	}
+}
+
+func TestExpectedDamage_Unsupported(t *testing.T) {
+	p := Profile{Models: 1, Stats: weapon(t, "1", "3+", "3+", "1", "1")}
+	p.Stats.Damage, _ = stats.ParseAmount("See 'Bloodwrack Stare' ability")
+
+	_, err := ExpectedDamage(p, Target{})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestCritFromAbilities(t *testing.T) {
+	for in, expected := range map[string]string{
+		"Crit (Mortal)":                  CritMortal,
+		"Shoot in Combat, Crit (2 Hits)": CritTwoHits,
+		"crit (auto-wound)":              CritAutoWound,
+		"Anti-charge (+1 Rend)":          CritNone,
+		"":                               CritNone,
+	} {
+		if got := CritFromAbilities(in); got != expected {
+			t.Errorf("expected %q for %q, got %q", expected, in, got)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package combat
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/dice"
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
+)
+
+// Defender is the unit attacks are allocated to, model by model.
+type Defender struct {
+	Target
+	Models int
+	Health stats.Value
+}
+
+// Simulation is what happened over every iteration of a simulated combat.
+type Simulation struct {
+	Iterations  int
+	Destroyed   float64
+	ModelsSlain dice.Distribution
+	Damage      dice.Distribution
+}
+
+// Simulate rolls the attack sequence of every profile against d, iterations
+// times, with dice seeded by seed. Damage is allocated to one model at a
+// time and carries over to the next once it is slain; damage beyond the
+// whole unit is lost.
+func Simulate(profiles []Profile, d Defender, iterations int, seed int64) (Simulation, error) {
+	if d.Health.Kind != stats.KindFixed || d.Health.Min < 1 {
+		return Simulation{}, fmt.Errorf("%w: health %q", ErrUnsupported, d.Health.Raw)
+	}
+
+	seqs := make([]sequence, len(profiles))
+	for i, p := range profiles {
+		seq, err := newSequence(p, d.Target)
+		if err != nil {
+			return Simulation{}, err
+		}
+		seqs[i] = seq
+	}
+
+	r := rand.New(rand.NewPCG(uint64(seed), uint64(seed)))
+	models := max(d.Models, 1)
+	health := d.Health.Min
+
+	slain := make(map[int]int)
+	damage := make(map[int]int)
+	destroyed := 0
+	for range iterations {
+		dealt := 0
+		for i, p := range profiles {
+			dealt += seqs[i].roll(r, max(p.Models, 1), p.Stats.Attacks.Expr())
+			if dealt >= models*health {
+				break
+			}
+		}
+
+		dealt = min(dealt, models*health)
+		slain[dealt/health]++
+		damage[dealt]++
+		if dealt == models*health {
+			destroyed++
+		}
+	}
+
+	return Simulation{
+		Iterations:  iterations,
+		Destroyed:   float64(destroyed) / float64(iterations),
+		ModelsSlain: dice.FromCounts(slain),
+		Damage:      dice.FromCounts(damage),
+	}, nil
+}
+
+// roll makes the attacks of models models once and returns the damage dealt
+// after wards.
+func (seq sequence) roll(r *rand.Rand, models int, attacks dice.Expr) int {
+	total := 0
+	for range models {
+		for range attacks.Roll(r) {
+			total += seq.rollAttack(r)
+		}
+	}
+	return total
+}
+
+func (seq sequence) rollAttack(r *rand.Rand) int {
+	hit := r.Float64()
+	switch {
+	case hit >= seq.hit:
+		return 0
+	case hit >= seq.crit:
+		return seq.rollHit(r)
+	}
+
+	switch seq.crits {
+	case CritTwoHits:
+		return seq.rollHit(r) + seq.rollHit(r)
+	case CritAutoWound:
+		return seq.rollWounded(r)
+	case CritMortal:
+		return seq.rollDamage(r)
+	}
+	return seq.rollHit(r)
+}
+
+func (seq sequence) rollHit(r *rand.Rand) int {
+	if r.Float64() >= seq.wound {
+		return 0
+	}
+	return seq.rollWounded(r)
+}
+
+func (seq sequence) rollWounded(r *rand.Rand) int {
+	if r.Float64() < seq.save {
+		return 0
+	}
+	return seq.rollDamage(r)
+}
+
+func (seq sequence) rollDamage(r *rand.Rand) int {
+	points := seq.damage.Expr().Roll(r)
+	dealt := 0
+	for range points {
+		if r.Float64() >= seq.ward {
+			dealt++
+		}
+	}
+	return dealt
+}
@@ -0,0 +1,100 @@
+package combat
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
+)
+
+func defender(t *testing.T, save, ward, health string, models int) Defender {
+	t.Helper()
+	hp, err := stats.ParseAmount(health)
+	if err != nil {
+		t.Fatalf("unparseable test health: %v", err)
+	}
+	return Defender{Target: target(t, save, ward), Models: models, Health: hp}
+}
+
+func TestSimulate_MatchesExpectedDamage(t *testing.T) {
+	profiles := []Profile{
+		{Models: 5, Stats: weapon(t, "2", "3+", "4+", "1", "D3"), Crit: CritTwoHits},
+		{Models: 5, Stats: weapon(t, "1", "4+", "3+", "-", "1"), Crit: CritMortal},
+	}
+	d := defender(t, "4+", "6+", "100", 1)
+
+	sim, err := Simulate(profiles, d, 20000, 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := 0.0
+	for _, p := range profiles {
+		e, err := ExpectedDamage(p, d.Target)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected += e.Damage
+	}
+
+	// Nothing is slain at 100 health, so the damage is never capped.
+	if math.Abs(sim.Damage.Mean()-expected) > 0.1 {
+		t.Errorf("expected simulated damage near %v, got %v", expected, sim.Damage.Mean())
+	}
+	if sim.Destroyed != 0 || len(sim.ModelsSlain) != 1 || sim.ModelsSlain[0].Value != 0 {
+		t.Errorf("expected nothing slain, got %+v", sim)
+	}
+}
+
+func TestSimulate_ModelsSlain(t *testing.T) {
+	profiles := []Profile{{Models: 10, Stats: weapon(t, "3", "3+", "3+", "1", "1")}}
+	d := defender(t, "4+", "", "2", 5)
+
+	sim, err := Simulate(profiles, d, 2000, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	last := sim.ModelsSlain[len(sim.ModelsSlain)-1]
+	if last.Value > 5 || sim.Damage[len(sim.Damage)-1].Value > 10 {
+		t.Errorf("expected at most 5 models and 10 damage, got %+v and %+v", sim.ModelsSlain, sim.Damage)
+	}
+	if math.Abs(sim.Destroyed-sim.ModelsSlain.AtLeast(5)) > 1e-9 {
+		t.Errorf("expected the unit destroyed whenever 5 models were slain, got %v and %v", sim.Destroyed, sim.ModelsSlain.AtLeast(5))
+	}
+
+	// 30 attacks averaging 8.9 damage against 10 health.
+	if sim.Destroyed <= 0 || sim.Destroyed >= 0.5 || sim.ModelsSlain.Percentile(0.5) != 4 {
+		t.Errorf("expected the unit destroyed now and then and 4 models slain at the median, got %v and %d", sim.Destroyed, sim.ModelsSlain.Percentile(0.5))
+	}
+}
+
+func TestSimulate_Seeded(t *testing.T) {
+	profiles := []Profile{{Models: 10, Stats: weapon(t, "2", "4+", "4+", "1", "D3")}}
+	d := defender(t, "4+", "", "2", 10)
+
+	first, err := Simulate(profiles, d, 500, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, _ := Simulate(profiles, d, 500, 7)
+	other, _ := Simulate(profiles, d, 500, 8)
+
+	if !reflect.DeepEqual(first, again) {
+		t.Error("expected the same seed to give the same results")
+	}
+	if reflect.DeepEqual(first, other) {
+		t.Error("expected another seed to give other results")
+	}
+}
+
+func TestSimulate_Unsupported(t *testing.T) {
+	profiles := []Profile{{Models: 1, Stats: weapon(t, "1", "3+", "3+", "1", "1")}}
+
+	_, err := Simulate(profiles, defender(t, "4+", "", "D6", 1), 10, 1)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected rolled health to be unsupported, got %v", err)
+	}
+}
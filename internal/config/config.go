@@ -3,4 +3,7 @@ package config
 type Config struct {
 	Env  string
 	Port string
+
+	// RulesetsDir replaces the built-in validation rulesets when set.
+	RulesetsDir string
 }
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createAbility = `-- name: CreateAbility :one
@@ -189,6 +190,46 @@ func (q *Queries) GetAbilitiesForFaction(ctx context.Context, factionID uuid.Nul
 	return items, nil
 }
 
+const getAbilitiesForGame = `-- name: GetAbilitiesForGame :many
+SELECT id, unit_id, faction_id, game_id, name, description, type, phase, version, source, created_at, updated_at
+FROM abilities
+WHERE game_id = $1
+ORDER BY phase ASC, name ASC
+`
+
+func (q *Queries) GetAbilitiesForGame(ctx context.Context, gameID uuid.NullUUID) ([]Ability, error) {
+	rows, err := q.db.Query(ctx, getAbilitiesForGame, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ability
+	for rows.Next() {
+		var i Ability
+		if err := rows.Scan(
+			&i.ID,
+			&i.UnitID,
+			&i.FactionID,
+			&i.GameID,
+			&i.Name,
+			&i.Description,
+			&i.Type,
+			&i.Phase,
+			&i.Version,
+			&i.Source,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAbilitiesForUnit = `-- name: GetAbilitiesForUnit :many
 SELECT id, unit_id, faction_id, game_id, name, description, type, phase, version, source, created_at, updated_at
 FROM abilities
@@ -294,6 +335,92 @@ func (q *Queries) GetAllAbilities(ctx context.Context) ([]Ability, error) {
 	return items, nil
 }
 
+const listAbilities = `-- name: ListAbilities :many
+SELECT a.id, a.unit_id, a.faction_id, a.game_id, a.name, a.description, a.type, a.phase, a.version, a.source, a.created_at, a.updated_at
+FROM abilities a
+LEFT JOIN units u ON u.id = a.unit_id
+LEFT JOIN factions f ON f.id = COALESCE(a.faction_id, u.faction_id)
+WHERE ($1::uuid IS NULL OR a.unit_id = $1)
+  AND ($2::uuid IS NULL OR a.faction_id = $2)
+  AND ($3::uuid IS NULL OR a.game_id = $3)
+  AND ($4::text IS NULL OR a.type ILIKE $4)
+  AND ($5::text IS NULL OR a.phase ILIKE $5)
+  AND ($6::text IS NULL OR f.allegiance ILIKE $6)
+  AND ($7::uuid IS NULL OR CASE $8::text
+    WHEN 'name' THEN (a.name, a.id) > ($9::text, $7)
+    WHEN '-name' THEN (a.name, a.id) < ($9::text, $7)
+    WHEN 'created_at' THEN (a.created_at, a.id) > ($10::timestamptz, $7)
+    WHEN '-created_at' THEN (a.created_at, a.id) < ($10::timestamptz, $7)
+  END)
+ORDER BY
+  CASE WHEN $8 = 'name' THEN a.name END ASC,
+  CASE WHEN $8 = '-name' THEN a.name END DESC,
+  CASE WHEN $8 = 'created_at' THEN a.created_at END ASC,
+  CASE WHEN $8 = '-created_at' THEN a.created_at END DESC,
+  CASE WHEN $8 LIKE '-%' THEN a.id END DESC,
+  a.id ASC
+LIMIT $11
+`
+
+type ListAbilitiesParams struct {
+	UnitID         uuid.NullUUID
+	FactionID      uuid.NullUUID
+	GameID         uuid.NullUUID
+	Type           pgtype.Text
+	Phase          pgtype.Text
+	Allegiance     pgtype.Text
+	AfterID        uuid.NullUUID
+	Sort           string
+	AfterName      pgtype.Text
+	AfterCreatedAt pgtype.Timestamptz
+	PageSize       int32
+}
+
+func (q *Queries) ListAbilities(ctx context.Context, arg ListAbilitiesParams) ([]Ability, error) {
+	rows, err := q.db.Query(ctx, listAbilities,
+		arg.UnitID,
+		arg.FactionID,
+		arg.GameID,
+		arg.Type,
+		arg.Phase,
+		arg.Allegiance,
+		arg.AfterID,
+		arg.Sort,
+		arg.AfterName,
+		arg.AfterCreatedAt,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ability
+	for rows.Next() {
+		var i Ability
+		if err := rows.Scan(
+			&i.ID,
+			&i.UnitID,
+			&i.FactionID,
+			&i.GameID,
+			&i.Name,
+			&i.Description,
+			&i.Type,
+			&i.Phase,
+			&i.Version,
+			&i.Source,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateAbility = `-- name: UpdateAbility :one
 UPDATE abilities
 SET name = $2, description = $3, type = $4, phase = $5, version = $6, source = $7, updated_at = now()
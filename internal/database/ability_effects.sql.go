@@ -12,19 +12,21 @@ import (
 )
 
 const createAbilityEffect = `-- name: CreateAbilityEffect :one
-INSERT INTO ability_effects (ability_id, stat, modifier, condition, description, version, source)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at
+INSERT INTO ability_effects (ability_id, stat, modifier, condition, description, version, source, enhancement_id, battle_formation_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at, enhancement_id, battle_formation_id
 `
 
 type CreateAbilityEffectParams struct {
-	AbilityID   uuid.UUID
-	Stat        string
-	Modifier    int32
-	Condition   string
-	Description string
-	Version     string
-	Source      string
+	AbilityID         uuid.NullUUID
+	Stat              string
+	Modifier          int32
+	Condition         string
+	Description       string
+	Version           string
+	Source            string
+	EnhancementID     uuid.NullUUID
+	BattleFormationID uuid.NullUUID
 }
 
 func (q *Queries) CreateAbilityEffect(ctx context.Context, arg CreateAbilityEffectParams) (AbilityEffect, error) {
@@ -36,6 +38,8 @@ func (q *Queries) CreateAbilityEffect(ctx context.Context, arg CreateAbilityEffe
 		arg.Description,
 		arg.Version,
 		arg.Source,
+		arg.EnhancementID,
+		arg.BattleFormationID,
 	)
 	var i AbilityEffect
 	err := row.Scan(
@@ -49,6 +53,8 @@ func (q *Queries) CreateAbilityEffect(ctx context.Context, arg CreateAbilityEffe
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EnhancementID,
+		&i.BattleFormationID,
 	)
 	return i, err
 }
@@ -64,7 +70,7 @@ func (q *Queries) DeleteAbilityEffect(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAbilityEffectByID = `-- name: GetAbilityEffectByID :one
-SELECT id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at
+SELECT id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at, enhancement_id, battle_formation_id
 FROM ability_effects
 WHERE id = $1
 `
@@ -83,18 +89,20 @@ func (q *Queries) GetAbilityEffectByID(ctx context.Context, id uuid.UUID) (Abili
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EnhancementID,
+		&i.BattleFormationID,
 	)
 	return i, err
 }
 
 const getAbilityEffectsForAbility = `-- name: GetAbilityEffectsForAbility :many
-SELECT id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at
+SELECT id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at, enhancement_id, battle_formation_id
 FROM ability_effects
 WHERE ability_id = $1
 ORDER BY stat ASC
 `
 
-func (q *Queries) GetAbilityEffectsForAbility(ctx context.Context, abilityID uuid.UUID) ([]AbilityEffect, error) {
+func (q *Queries) GetAbilityEffectsForAbility(ctx context.Context, abilityID uuid.NullUUID) ([]AbilityEffect, error) {
 	rows, err := q.db.Query(ctx, getAbilityEffectsForAbility, abilityID)
 	if err != nil {
 		return nil, err
@@ -114,6 +122,88 @@ func (q *Queries) GetAbilityEffectsForAbility(ctx context.Context, abilityID uui
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.EnhancementID,
+			&i.BattleFormationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAbilityEffectsForBattleFormation = `-- name: GetAbilityEffectsForBattleFormation :many
+SELECT id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at, enhancement_id, battle_formation_id
+FROM ability_effects
+WHERE battle_formation_id = $1
+ORDER BY stat ASC
+`
+
+func (q *Queries) GetAbilityEffectsForBattleFormation(ctx context.Context, battleFormationID uuid.NullUUID) ([]AbilityEffect, error) {
+	rows, err := q.db.Query(ctx, getAbilityEffectsForBattleFormation, battleFormationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AbilityEffect
+	for rows.Next() {
+		var i AbilityEffect
+		if err := rows.Scan(
+			&i.ID,
+			&i.AbilityID,
+			&i.Stat,
+			&i.Modifier,
+			&i.Condition,
+			&i.Description,
+			&i.Version,
+			&i.Source,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EnhancementID,
+			&i.BattleFormationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAbilityEffectsForEnhancement = `-- name: GetAbilityEffectsForEnhancement :many
+SELECT id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at, enhancement_id, battle_formation_id
+FROM ability_effects
+WHERE enhancement_id = $1
+ORDER BY stat ASC
+`
+
+func (q *Queries) GetAbilityEffectsForEnhancement(ctx context.Context, enhancementID uuid.NullUUID) ([]AbilityEffect, error) {
+	rows, err := q.db.Query(ctx, getAbilityEffectsForEnhancement, enhancementID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AbilityEffect
+	for rows.Next() {
+		var i AbilityEffect
+		if err := rows.Scan(
+			&i.ID,
+			&i.AbilityID,
+			&i.Stat,
+			&i.Modifier,
+			&i.Condition,
+			&i.Description,
+			&i.Version,
+			&i.Source,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EnhancementID,
+			&i.BattleFormationID,
 		); err != nil {
 			return nil, err
 		}
@@ -126,9 +216,9 @@ func (q *Queries) GetAbilityEffectsForAbility(ctx context.Context, abilityID uui
 }
 
 const getAllAbilityEffects = `-- name: GetAllAbilityEffects :many
-SELECT id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at
+SELECT id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at, enhancement_id, battle_formation_id
 FROM ability_effects
-ORDER BY ability_id, stat ASC
+ORDER BY ability_id, enhancement_id, battle_formation_id, stat ASC
 `
 
 func (q *Queries) GetAllAbilityEffects(ctx context.Context) ([]AbilityEffect, error) {
@@ -151,6 +241,8 @@ func (q *Queries) GetAllAbilityEffects(ctx context.Context) ([]AbilityEffect, er
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.EnhancementID,
+			&i.BattleFormationID,
 		); err != nil {
 			return nil, err
 		}
@@ -166,7 +258,7 @@ const updateAbilityEffect = `-- name: UpdateAbilityEffect :one
 UPDATE ability_effects
 SET stat = $2, modifier = $3, condition = $4, description = $5, version = $6, source = $7, updated_at = now()
 WHERE id = $1
-RETURNING id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at
+RETURNING id, ability_id, stat, modifier, condition, description, version, source, created_at, updated_at, enhancement_id, battle_formation_id
 `
 
 type UpdateAbilityEffectParams struct {
@@ -201,6 +293,8 @@ func (q *Queries) UpdateAbilityEffect(ctx context.Context, arg UpdateAbilityEffe
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EnhancementID,
+		&i.BattleFormationID,
 	)
 	return i, err
 }
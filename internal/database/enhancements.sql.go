@@ -7,26 +7,29 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/google/uuid"
 )
 
 const createEnhancement = `-- name: CreateEnhancement :one
-INSERT INTO enhancements (faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at
+INSERT INTO enhancements (faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, battlescribe_id, required_keywords)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at, battlescribe_id, required_keywords
 `
 
 type CreateEnhancementParams struct {
-	FactionID       uuid.UUID
-	Name            string
-	EnhancementType string
-	Description     string
-	Points          int32
-	IsUnique        bool
-	Restrictions    string
-	Version         string
-	Source          string
+	FactionID        uuid.UUID
+	Name             string
+	EnhancementType  string
+	Description      string
+	Points           int32
+	IsUnique         bool
+	Restrictions     string
+	Version          string
+	Source           string
+	BattlescribeID   string
+	RequiredKeywords json.RawMessage
 }
 
 func (q *Queries) CreateEnhancement(ctx context.Context, arg CreateEnhancementParams) (Enhancement, error) {
@@ -40,6 +43,8 @@ func (q *Queries) CreateEnhancement(ctx context.Context, arg CreateEnhancementPa
 		arg.Restrictions,
 		arg.Version,
 		arg.Source,
+		arg.BattlescribeID,
+		arg.RequiredKeywords,
 	)
 	var i Enhancement
 	err := row.Scan(
@@ -55,6 +60,8 @@ func (q *Queries) CreateEnhancement(ctx context.Context, arg CreateEnhancementPa
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.RequiredKeywords,
 	)
 	return i, err
 }
@@ -70,7 +77,7 @@ func (q *Queries) DeleteEnhancement(ctx context.Context, id uuid.UUID) error {
 }
 
 const getEnhancementByID = `-- name: GetEnhancementByID :one
-SELECT id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at
+SELECT id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at, battlescribe_id, required_keywords
 FROM enhancements
 WHERE id = $1
 `
@@ -91,12 +98,14 @@ func (q *Queries) GetEnhancementByID(ctx context.Context, id uuid.UUID) (Enhance
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.RequiredKeywords,
 	)
 	return i, err
 }
 
 const getEnhancements = `-- name: GetEnhancements :many
-SELECT id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at
+SELECT id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at, battlescribe_id, required_keywords
 FROM enhancements
 ORDER BY faction_id, name ASC
 `
@@ -123,6 +132,8 @@ func (q *Queries) GetEnhancements(ctx context.Context) ([]Enhancement, error) {
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.RequiredKeywords,
 		); err != nil {
 			return nil, err
 		}
@@ -135,7 +146,7 @@ func (q *Queries) GetEnhancements(ctx context.Context) ([]Enhancement, error) {
 }
 
 const getEnhancementsByType = `-- name: GetEnhancementsByType :many
-SELECT id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at
+SELECT id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at, battlescribe_id, required_keywords
 FROM enhancements
 WHERE enhancement_type = $1
 ORDER BY faction_id, name ASC
@@ -163,6 +174,8 @@ func (q *Queries) GetEnhancementsByType(ctx context.Context, enhancementType str
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.RequiredKeywords,
 		); err != nil {
 			return nil, err
 		}
@@ -175,7 +188,7 @@ func (q *Queries) GetEnhancementsByType(ctx context.Context, enhancementType str
 }
 
 const getEnhancementsForFaction = `-- name: GetEnhancementsForFaction :many
-SELECT id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at
+SELECT id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at, battlescribe_id, required_keywords
 FROM enhancements
 WHERE faction_id = $1
 ORDER BY name ASC
@@ -203,6 +216,8 @@ func (q *Queries) GetEnhancementsForFaction(ctx context.Context, factionID uuid.
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.RequiredKeywords,
 		); err != nil {
 			return nil, err
 		}
@@ -216,19 +231,24 @@ func (q *Queries) GetEnhancementsForFaction(ctx context.Context, factionID uuid.
 
 const updateEnhancement = `-- name: UpdateEnhancement :one
 UPDATE enhancements
-SET name = $2, enhancement_type = $3, description = $4, points = $5, version = $6, source = $7, updated_at = now()
+SET name = $2, enhancement_type = $3, description = $4, points = $5, version = $6, source = $7,
+    is_unique = $8, restrictions = $9, battlescribe_id = $10, required_keywords = $11, updated_at = now()
 WHERE id = $1
-RETURNING id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at
+RETURNING id, faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, created_at, updated_at, battlescribe_id, required_keywords
 `
 
 type UpdateEnhancementParams struct {
-	ID              uuid.UUID
-	Name            string
-	EnhancementType string
-	Description     string
-	Points          int32
-	Version         string
-	Source          string
+	ID               uuid.UUID
+	Name             string
+	EnhancementType  string
+	Description      string
+	Points           int32
+	Version          string
+	Source           string
+	IsUnique         bool
+	Restrictions     string
+	BattlescribeID   string
+	RequiredKeywords json.RawMessage
 }
 
 func (q *Queries) UpdateEnhancement(ctx context.Context, arg UpdateEnhancementParams) (Enhancement, error) {
@@ -240,6 +260,10 @@ func (q *Queries) UpdateEnhancement(ctx context.Context, arg UpdateEnhancementPa
 		arg.Points,
 		arg.Version,
 		arg.Source,
+		arg.IsUnique,
+		arg.Restrictions,
+		arg.BattlescribeID,
+		arg.RequiredKeywords,
 	)
 	var i Enhancement
 	err := row.Scan(
@@ -255,6 +279,8 @@ func (q *Queries) UpdateEnhancement(ctx context.Context, arg UpdateEnhancementPa
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.RequiredKeywords,
 	)
 	return i, err
 }
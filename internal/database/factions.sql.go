@@ -12,20 +12,23 @@ import (
 )
 
 const createFaction = `-- name: CreateFaction :one
-INSERT INTO factions (game_id, name, allegiance, version, source, is_army_of_renown, is_regiment_of_renown, parent_faction_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at
+INSERT INTO factions (game_id, name, allegiance, version, source, is_army_of_renown, is_regiment_of_renown, parent_faction_id, points, battlescribe_id, battlescribe_force_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at, points, battlescribe_id, battlescribe_force_id
 `
 
 type CreateFactionParams struct {
-	GameID             uuid.UUID
-	Name               string
-	Allegiance         string
-	Version            string
-	Source             string
-	IsArmyOfRenown     bool
-	IsRegimentOfRenown bool
-	ParentFactionID    uuid.NullUUID
+	GameID              uuid.UUID
+	Name                string
+	Allegiance          string
+	Version             string
+	Source              string
+	IsArmyOfRenown      bool
+	IsRegimentOfRenown  bool
+	ParentFactionID     uuid.NullUUID
+	Points              int32
+	BattlescribeID      string
+	BattlescribeForceID string
 }
 
 func (q *Queries) CreateFaction(ctx context.Context, arg CreateFactionParams) (Faction, error) {
@@ -38,6 +41,9 @@ func (q *Queries) CreateFaction(ctx context.Context, arg CreateFactionParams) (F
 		arg.IsArmyOfRenown,
 		arg.IsRegimentOfRenown,
 		arg.ParentFactionID,
+		arg.Points,
+		arg.BattlescribeID,
+		arg.BattlescribeForceID,
 	)
 	var i Faction
 	err := row.Scan(
@@ -53,6 +59,9 @@ func (q *Queries) CreateFaction(ctx context.Context, arg CreateFactionParams) (F
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Points,
+		&i.BattlescribeID,
+		&i.BattlescribeForceID,
 	)
 	return i, err
 }
@@ -68,7 +77,7 @@ func (q *Queries) DeleteFaction(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAllFactions = `-- name: GetAllFactions :many
-SELECT id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at
+SELECT id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at, points, battlescribe_id, battlescribe_force_id
 FROM factions
 ORDER BY game_id, name ASC
 `
@@ -95,6 +104,9 @@ func (q *Queries) GetAllFactions(ctx context.Context) ([]Faction, error) {
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Points,
+			&i.BattlescribeID,
+			&i.BattlescribeForceID,
 		); err != nil {
 			return nil, err
 		}
@@ -107,7 +119,7 @@ func (q *Queries) GetAllFactions(ctx context.Context) ([]Faction, error) {
 }
 
 const getFaction = `-- name: GetFaction :one
-SELECT id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at
+SELECT id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at, points, battlescribe_id, battlescribe_force_id
 FROM factions
 WHERE id = $1
 `
@@ -128,12 +140,15 @@ func (q *Queries) GetFaction(ctx context.Context, id uuid.UUID) (Faction, error)
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Points,
+		&i.BattlescribeID,
+		&i.BattlescribeForceID,
 	)
 	return i, err
 }
 
 const getFactionsByID = `-- name: GetFactionsByID :many
-SELECT id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at
+SELECT id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at, points, battlescribe_id, battlescribe_force_id
 FROM factions
 WHERE game_id = $1
 ORDER BY name ASC
@@ -161,6 +176,9 @@ func (q *Queries) GetFactionsByID(ctx context.Context, gameID uuid.UUID) ([]Fact
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Points,
+			&i.BattlescribeID,
+			&i.BattlescribeForceID,
 		); err != nil {
 			return nil, err
 		}
@@ -173,7 +191,7 @@ func (q *Queries) GetFactionsByID(ctx context.Context, gameID uuid.UUID) ([]Fact
 }
 
 const getFactionsByName = `-- name: GetFactionsByName :many
-SELECT id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at
+SELECT id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at, points, battlescribe_id, battlescribe_force_id
 FROM factions
 WHERE name ILIKE $1
 ORDER BY game_id, name ASC
@@ -201,6 +219,9 @@ func (q *Queries) GetFactionsByName(ctx context.Context, name string) ([]Faction
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Points,
+			&i.BattlescribeID,
+			&i.BattlescribeForceID,
 		); err != nil {
 			return nil, err
 		}
@@ -214,20 +235,23 @@ func (q *Queries) GetFactionsByName(ctx context.Context, name string) ([]Faction
 
 const updateFaction = `-- name: UpdateFaction :one
 UPDATE factions
-SET name = $2, allegiance = $3, version = $4, source = $5, is_army_of_renown = $6, is_regiment_of_renown = $7, parent_faction_id = $8, updated_at = now()
+SET name = $2, allegiance = $3, version = $4, source = $5, is_army_of_renown = $6, is_regiment_of_renown = $7, parent_faction_id = $8, points = $9, battlescribe_id = $10, battlescribe_force_id = $11, updated_at = now()
 WHERE id = $1
-RETURNING id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at
+RETURNING id, game_id, name, is_army_of_renown, is_regiment_of_renown, parent_faction_id, description, allegiance, version, source, created_at, updated_at, points, battlescribe_id, battlescribe_force_id
 `
 
 type UpdateFactionParams struct {
-	ID                 uuid.UUID
-	Name               string
-	Allegiance         string
-	Version            string
-	Source             string
-	IsArmyOfRenown     bool
-	IsRegimentOfRenown bool
-	ParentFactionID    uuid.NullUUID
+	ID                  uuid.UUID
+	Name                string
+	Allegiance          string
+	Version             string
+	Source              string
+	IsArmyOfRenown      bool
+	IsRegimentOfRenown  bool
+	ParentFactionID     uuid.NullUUID
+	Points              int32
+	BattlescribeID      string
+	BattlescribeForceID string
 }
 
 func (q *Queries) UpdateFaction(ctx context.Context, arg UpdateFactionParams) (Faction, error) {
@@ -240,6 +264,9 @@ func (q *Queries) UpdateFaction(ctx context.Context, arg UpdateFactionParams) (F
 		arg.IsArmyOfRenown,
 		arg.IsRegimentOfRenown,
 		arg.ParentFactionID,
+		arg.Points,
+		arg.BattlescribeID,
+		arg.BattlescribeForceID,
 	)
 	var i Faction
 	err := row.Scan(
@@ -255,6 +282,9 @@ func (q *Queries) UpdateFaction(ctx context.Context, arg UpdateFactionParams) (F
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Points,
+		&i.BattlescribeID,
+		&i.BattlescribeForceID,
 	)
 	return i, err
 }
@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/google/uuid"
 )
@@ -14,7 +15,7 @@ import (
 const createGame = `-- name: CreateGame :one
 INSERT INTO games (name, edition, version, source)
 VALUES ($1, $2, $3, $4)
-RETURNING id, name, edition, version, source, created_at, updated_at
+RETURNING id, name, edition, version, source, created_at, updated_at, battlescribe_id, battlescribe_forces
 `
 
 type CreateGameParams struct {
@@ -40,6 +41,8 @@ func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (Game, e
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.BattlescribeForces,
 	)
 	return i, err
 }
@@ -55,7 +58,7 @@ func (q *Queries) DeleteGame(ctx context.Context, id uuid.UUID) error {
 }
 
 const getGame = `-- name: GetGame :one
-SELECT id, name, edition, version, source, created_at, updated_at
+SELECT id, name, edition, version, source, created_at, updated_at, battlescribe_id, battlescribe_forces
 FROM games
 WHERE id = $1
 `
@@ -71,12 +74,14 @@ func (q *Queries) GetGame(ctx context.Context, id uuid.UUID) (Game, error) {
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.BattlescribeForces,
 	)
 	return i, err
 }
 
 const getGameByName = `-- name: GetGameByName :one
-SELECT id, name, edition, version, source, created_at, updated_at
+SELECT id, name, edition, version, source, created_at, updated_at, battlescribe_id, battlescribe_forces
 FROM games
 WHERE name = $1
 `
@@ -92,12 +97,14 @@ func (q *Queries) GetGameByName(ctx context.Context, name string) (Game, error)
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.BattlescribeForces,
 	)
 	return i, err
 }
 
 const getGames = `-- name: GetGames :many
-SELECT id, name, edition, version, source, created_at, updated_at
+SELECT id, name, edition, version, source, created_at, updated_at, battlescribe_id, battlescribe_forces
 FROM games
 ORDER BY name ASC
 `
@@ -119,6 +126,8 @@ func (q *Queries) GetGames(ctx context.Context) ([]Game, error) {
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.BattlescribeForces,
 		); err != nil {
 			return nil, err
 		}
@@ -134,7 +143,7 @@ const updateGame = `-- name: UpdateGame :one
 UPDATE games
 SET edition = $2, version = $3, source = $4, updated_at = now()
 WHERE id = $1
-RETURNING id, name, edition, version, source, created_at, updated_at
+RETURNING id, name, edition, version, source, created_at, updated_at, battlescribe_id, battlescribe_forces
 `
 
 type UpdateGameParams struct {
@@ -160,6 +169,25 @@ func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (Game, e
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.BattlescribeForces,
 	)
 	return i, err
 }
+
+const updateGameBattlescribe = `-- name: UpdateGameBattlescribe :exec
+UPDATE games
+SET battlescribe_id = $2, battlescribe_forces = $3, updated_at = now()
+WHERE id = $1
+`
+
+type UpdateGameBattlescribeParams struct {
+	ID                 uuid.UUID
+	BattlescribeID     string
+	BattlescribeForces json.RawMessage
+}
+
+func (q *Queries) UpdateGameBattlescribe(ctx context.Context, arg UpdateGameBattlescribeParams) error {
+	_, err := q.db.Exec(ctx, updateGameBattlescribe, arg.ID, arg.BattlescribeID, arg.BattlescribeForces)
+	return err
+}
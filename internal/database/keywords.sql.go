@@ -7,8 +7,10 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const addKeywordToUnit = `-- name: AddKeywordToUnit :exec
@@ -74,6 +76,22 @@ func (q *Queries) DeleteKeyword(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteUnusedKeywords = `-- name: DeleteUnusedKeywords :execrows
+DELETE FROM keywords k
+WHERE k.game_id = $1
+  AND NOT EXISTS (
+    SELECT 1 FROM unit_keywords uk WHERE uk.keyword_id = k.id
+  )
+`
+
+func (q *Queries) DeleteUnusedKeywords(ctx context.Context, gameID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUnusedKeywords, gameID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getAllKeywords = `-- name: GetAllKeywords :many
 SELECT id, game_id, name, description, version, source, created_at, updated_at
 FROM keywords
@@ -208,7 +226,7 @@ func (q *Queries) GetKeywordsForUnit(ctx context.Context, unitID uuid.UUID) ([]G
 }
 
 const getUnitsWithKeyword = `-- name: GetUnitsWithKeyword :many
-SELECT DISTINCT u.id, u.faction_id, u.name, u.description, u.is_manifestation, u.is_unique, u.move, u.health_wounds, u.save_stats, u.ward_fnp, u.invuln_save, u.control_oc, u.toughness, u.leadership_bravery, u.points, u.additional_stats, u.summon_cost, u.banishment, u.min_unit_size, u.max_unit_size, u.matched_play, u.version, u.source, u.created_at, u.updated_at
+SELECT DISTINCT u.id, u.faction_id, u.name, u.description, u.is_manifestation, u.is_unique, u.move, u.health_wounds, u.save_stats, u.ward_fnp, u.invuln_save, u.control_oc, u.toughness, u.leadership_bravery, u.points, u.additional_stats, u.summon_cost, u.banishment, u.min_unit_size, u.max_unit_size, u.matched_play, u.version, u.source, u.created_at, u.updated_at, u.battlescribe_id, u.can_be_reinforced
 FROM units u
 JOIN unit_keywords uk ON u.id = uk.unit_id
 JOIN keywords k ON uk.keyword_id = k.id
@@ -251,6 +269,8 @@ func (q *Queries) GetUnitsWithKeyword(ctx context.Context, name string) ([]Unit,
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.CanBeReinforced,
 		); err != nil {
 			return nil, err
 		}
@@ -263,7 +283,7 @@ func (q *Queries) GetUnitsWithKeyword(ctx context.Context, name string) ([]Unit,
 }
 
 const getUnitsWithKeywordAndValue = `-- name: GetUnitsWithKeywordAndValue :many
-SELECT DISTINCT u.id, u.faction_id, u.name, u.description, u.is_manifestation, u.is_unique, u.move, u.health_wounds, u.save_stats, u.ward_fnp, u.invuln_save, u.control_oc, u.toughness, u.leadership_bravery, u.points, u.additional_stats, u.summon_cost, u.banishment, u.min_unit_size, u.max_unit_size, u.matched_play, u.version, u.source, u.created_at, u.updated_at
+SELECT DISTINCT u.id, u.faction_id, u.name, u.description, u.is_manifestation, u.is_unique, u.move, u.health_wounds, u.save_stats, u.ward_fnp, u.invuln_save, u.control_oc, u.toughness, u.leadership_bravery, u.points, u.additional_stats, u.summon_cost, u.banishment, u.min_unit_size, u.max_unit_size, u.matched_play, u.version, u.source, u.created_at, u.updated_at, u.battlescribe_id, u.can_be_reinforced
 FROM units u
 JOIN unit_keywords uk ON u.id = uk.unit_id
 JOIN keywords k ON uk.keyword_id = k.id
@@ -311,6 +331,90 @@ func (q *Queries) GetUnitsWithKeywordAndValue(ctx context.Context, arg GetUnitsW
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.CanBeReinforced,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listKeywords = `-- name: ListKeywords :many
+SELECT k.id, k.game_id, k.name, k.description, k.version, k.source, k.created_at, k.updated_at, COALESCE(uk.value, '')::text AS value
+FROM keywords k
+LEFT JOIN unit_keywords uk ON uk.keyword_id = k.id AND uk.unit_id = $1
+WHERE ($1::uuid IS NULL OR uk.unit_id IS NOT NULL)
+  AND ($2::uuid IS NULL OR k.game_id = $2)
+  AND ($3::uuid IS NULL OR CASE $4::text
+    WHEN 'name' THEN (k.name, k.id) > ($5::text, $3)
+    WHEN '-name' THEN (k.name, k.id) < ($5::text, $3)
+    WHEN 'created_at' THEN (k.created_at, k.id) > ($6::timestamptz, $3)
+    WHEN '-created_at' THEN (k.created_at, k.id) < ($6::timestamptz, $3)
+  END)
+ORDER BY
+  CASE WHEN $4 = 'name' THEN k.name END ASC,
+  CASE WHEN $4 = '-name' THEN k.name END DESC,
+  CASE WHEN $4 = 'created_at' THEN k.created_at END ASC,
+  CASE WHEN $4 = '-created_at' THEN k.created_at END DESC,
+  CASE WHEN $4 LIKE '-%' THEN k.id END DESC,
+  k.id ASC
+LIMIT $7
+`
+
+type ListKeywordsRow struct {
+	ID          uuid.UUID
+	GameID      uuid.UUID
+	Name        string
+	Description string
+	Version     string
+	Source      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Value       string
+}
+
+type ListKeywordsParams struct {
+	UnitID         uuid.NullUUID
+	GameID         uuid.NullUUID
+	AfterID        uuid.NullUUID
+	Sort           string
+	AfterName      pgtype.Text
+	AfterCreatedAt pgtype.Timestamptz
+	PageSize       int32
+}
+
+func (q *Queries) ListKeywords(ctx context.Context, arg ListKeywordsParams) ([]ListKeywordsRow, error) {
+	rows, err := q.db.Query(ctx, listKeywords,
+		arg.UnitID,
+		arg.GameID,
+		arg.AfterID,
+		arg.Sort,
+		arg.AfterName,
+		arg.AfterCreatedAt,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListKeywordsRow
+	for rows.Next() {
+		var i ListKeywordsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.Name,
+			&i.Description,
+			&i.Version,
+			&i.Source,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Value,
 		); err != nil {
 			return nil, err
 		}
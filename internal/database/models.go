@@ -27,16 +27,57 @@ type Ability struct {
 }
 
 type AbilityEffect struct {
-	ID          uuid.UUID
-	AbilityID   uuid.UUID
-	Stat        string
-	Modifier    int32
-	Condition   string
-	Description string
-	Version     string
-	Source      string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                uuid.UUID
+	AbilityID         uuid.NullUUID
+	Stat              string
+	Modifier          int32
+	Condition         string
+	Description       string
+	Version           string
+	Source            string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	EnhancementID     uuid.NullUUID
+	BattleFormationID uuid.NullUUID
+}
+
+type ArmyList struct {
+	ID                 uuid.UUID
+	GameID             uuid.UUID
+	FactionID          uuid.UUID
+	Name               string
+	PointsLimit        int32
+	BattleFormationID  uuid.NullUUID
+	TotalPoints        int32
+	IsValid            bool
+	Validation         json.RawMessage
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	RegimentOfRenownID uuid.NullUUID
+}
+
+type ArmyListRegiment struct {
+	ID                 uuid.UUID
+	ArmyListID         uuid.UUID
+	Position           int32
+	Name               string
+	IsGeneral          bool
+	IsAuxiliary        bool
+	IsRegimentOfRenown bool
+}
+
+type ArmyListUnit struct {
+	ID           uuid.UUID
+	RegimentID   uuid.UUID
+	UnitID       uuid.UUID
+	Position     int32
+	Quantity     int32
+	IsReinforced bool
+}
+
+type ArmyListUnitEnhancement struct {
+	ArmyListUnitID uuid.UUID
+	EnhancementID  uuid.UUID
 }
 
 type BattleFormation struct {
@@ -52,43 +93,57 @@ type BattleFormation struct {
 }
 
 type Enhancement struct {
-	ID              uuid.UUID
-	FactionID       uuid.UUID
-	Name            string
-	EnhancementType string
-	Description     string
-	Points          int32
-	IsUnique        bool
-	Restrictions    string
-	Version         string
-	Source          string
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	ID               uuid.UUID
+	FactionID        uuid.UUID
+	Name             string
+	EnhancementType  string
+	Description      string
+	Points           int32
+	IsUnique         bool
+	Restrictions     string
+	Version          string
+	Source           string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	BattlescribeID   string
+	RequiredKeywords json.RawMessage
 }
 
 type Faction struct {
+	ID                  uuid.UUID
+	GameID              uuid.UUID
+	Name                string
+	IsArmyOfRenown      bool
+	IsRegimentOfRenown  bool
+	ParentFactionID     uuid.NullUUID
+	Description         string
+	Allegiance          string
+	Version             string
+	Source              string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Points              int32
+	BattlescribeID      string
+	BattlescribeForceID string
+}
+
+type FactionAllowedUnit struct {
+	ID             uuid.UUID
+	FactionID      uuid.UUID
+	UnitName       string
+	BattlescribeID string
+}
+
+type Game struct {
 	ID                 uuid.UUID
-	GameID             uuid.UUID
 	Name               string
-	IsArmyOfRenown     bool
-	IsRegimentOfRenown bool
-	ParentFactionID    uuid.NullUUID
-	Description        string
-	Allegiance         string
+	Edition            string
 	Version            string
 	Source             string
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
-}
-
-type Game struct {
-	ID        uuid.UUID
-	Name      string
-	Edition   string
-	Version   string
-	Source    string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	BattlescribeID     string
+	BattlescribeForces json.RawMessage
 }
 
 type Keyword struct {
@@ -102,6 +157,20 @@ type Keyword struct {
 	UpdatedAt   time.Time
 }
 
+type RegimentOfRenownHost struct {
+	ID              uuid.UUID
+	FactionID       uuid.UUID
+	HostFactionName string
+}
+
+type RegimentOption struct {
+	ID       uuid.UUID
+	UnitID   uuid.UUID
+	Keyword  string
+	UnitName string
+	IsHero   bool
+}
+
 type Rule struct {
 	ID          uuid.UUID
 	GameID      uuid.UUID
@@ -141,6 +210,8 @@ type Unit struct {
 	Source            string
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
+	BattlescribeID    string
+	CanBeReinforced   bool
 }
 
 type UnitKeyword struct {
@@ -163,4 +234,6 @@ type Weapon struct {
 	Source        string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	WeaponType    string
+	Abilities     string
 }
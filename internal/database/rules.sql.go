@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createRule = `-- name: CreateRule :one
@@ -205,9 +206,79 @@ func (q *Queries) GetRulesForGame(ctx context.Context, gameID uuid.UUID) ([]Rule
 	return items, nil
 }
 
+const listRules = `-- name: ListRules :many
+SELECT r.id, r.game_id, r.name, r.description, r.text, r.rule_type, r.version, r.source, r.created_at, r.updated_at
+FROM rules r
+WHERE ($1::uuid IS NULL OR r.game_id = $1)
+  AND ($2::text IS NULL OR r.rule_type ILIKE $2)
+  AND ($3::uuid IS NULL OR CASE $4::text
+    WHEN 'name' THEN (r.name, r.id) > ($5::text, $3)
+    WHEN '-name' THEN (r.name, r.id) < ($5::text, $3)
+    WHEN 'created_at' THEN (r.created_at, r.id) > ($6::timestamptz, $3)
+    WHEN '-created_at' THEN (r.created_at, r.id) < ($6::timestamptz, $3)
+  END)
+ORDER BY
+  CASE WHEN $4 = 'name' THEN r.name END ASC,
+  CASE WHEN $4 = '-name' THEN r.name END DESC,
+  CASE WHEN $4 = 'created_at' THEN r.created_at END ASC,
+  CASE WHEN $4 = '-created_at' THEN r.created_at END DESC,
+  CASE WHEN $4 LIKE '-%' THEN r.id END DESC,
+  r.id ASC
+LIMIT $7
+`
+
+type ListRulesParams struct {
+	GameID         uuid.NullUUID
+	RuleType       pgtype.Text
+	AfterID        uuid.NullUUID
+	Sort           string
+	AfterName      pgtype.Text
+	AfterCreatedAt pgtype.Timestamptz
+	PageSize       int32
+}
+
+func (q *Queries) ListRules(ctx context.Context, arg ListRulesParams) ([]Rule, error) {
+	rows, err := q.db.Query(ctx, listRules,
+		arg.GameID,
+		arg.RuleType,
+		arg.AfterID,
+		arg.Sort,
+		arg.AfterName,
+		arg.AfterCreatedAt,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Rule
+	for rows.Next() {
+		var i Rule
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.Name,
+			&i.Description,
+			&i.Text,
+			&i.RuleType,
+			&i.Version,
+			&i.Source,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateRule = `-- name: UpdateRule :one
 UPDATE rules
-SET name = $2, description = $3, rule_type = $4, version = $5, source = $6, updated_at = now()
+SET name = $2, description = $3, rule_type = $4, version = $5, source = $6, text = $7, updated_at = now()
 WHERE id = $1
 RETURNING id, game_id, name, description, text, rule_type, version, source, created_at, updated_at
 `
@@ -219,6 +290,7 @@ type UpdateRuleParams struct {
 	RuleType    string
 	Version     string
 	Source      string
+	Text        string
 }
 
 func (q *Queries) UpdateRule(ctx context.Context, arg UpdateRuleParams) (Rule, error) {
@@ -229,6 +301,7 @@ func (q *Queries) UpdateRule(ctx context.Context, arg UpdateRuleParams) (Rule, e
 		arg.RuleType,
 		arg.Version,
 		arg.Source,
+		arg.Text,
 	)
 	var i Rule
 	err := row.Scan(
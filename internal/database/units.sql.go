@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createUnit = `-- name: CreateUnit :one
@@ -19,15 +20,17 @@ INSERT INTO units (
   control_oc, toughness, leadership_bravery, points,
   additional_stats,
   summon_cost, banishment,
-  min_unit_size, max_unit_size, matched_play, version, source
+  min_unit_size, max_unit_size, matched_play, version, source,
+  battlescribe_id, can_be_reinforced
 )
 VALUES (
   $1, $2, $3, $4, $5,
   $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
   $16, $17,
-  $18, $19, $20, $21, $22
+  $18, $19, $20, $21, $22,
+  $23, $24
 )
-RETURNING id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at
+RETURNING id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at, battlescribe_id, can_be_reinforced
 `
 
 type CreateUnitParams struct {
@@ -53,6 +56,8 @@ type CreateUnitParams struct {
 	MatchedPlay       bool
 	Version           string
 	Source            string
+	BattlescribeID    string
+	CanBeReinforced   bool
 }
 
 func (q *Queries) CreateUnit(ctx context.Context, arg CreateUnitParams) (Unit, error) {
@@ -79,6 +84,8 @@ func (q *Queries) CreateUnit(ctx context.Context, arg CreateUnitParams) (Unit, e
 		arg.MatchedPlay,
 		arg.Version,
 		arg.Source,
+		arg.BattlescribeID,
+		arg.CanBeReinforced,
 	)
 	var i Unit
 	err := row.Scan(
@@ -107,6 +114,8 @@ func (q *Queries) CreateUnit(ctx context.Context, arg CreateUnitParams) (Unit, e
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.CanBeReinforced,
 	)
 	return i, err
 }
@@ -121,15 +130,15 @@ func (q *Queries) DeleteUnit(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-const getAllUnits = `-- name: GetAllUnits :many
-SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at
+const getAllUnitsForFaction = `-- name: GetAllUnitsForFaction :many
+SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at, battlescribe_id, can_be_reinforced
 FROM units
-WHERE is_manifestation = false
-ORDER BY faction_id, name ASC
+WHERE faction_id = $1
+ORDER BY name ASC
 `
 
-func (q *Queries) GetAllUnits(ctx context.Context) ([]Unit, error) {
-	rows, err := q.db.Query(ctx, getAllUnits)
+func (q *Queries) GetAllUnitsForFaction(ctx context.Context, factionID uuid.UUID) ([]Unit, error) {
+	rows, err := q.db.Query(ctx, getAllUnitsForFaction, factionID)
 	if err != nil {
 		return nil, err
 	}
@@ -163,6 +172,8 @@ func (q *Queries) GetAllUnits(ctx context.Context) ([]Unit, error) {
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.CanBeReinforced,
 		); err != nil {
 			return nil, err
 		}
@@ -175,7 +186,7 @@ func (q *Queries) GetAllUnits(ctx context.Context) ([]Unit, error) {
 }
 
 const getManifestationByID = `-- name: GetManifestationByID :one
-SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at
+SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at, battlescribe_id, can_be_reinforced
 FROM units
 WHERE id = $1 AND is_manifestation = true
 `
@@ -209,12 +220,14 @@ func (q *Queries) GetManifestationByID(ctx context.Context, id uuid.UUID) (Unit,
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.CanBeReinforced,
 	)
 	return i, err
 }
 
 const getManifestations = `-- name: GetManifestations :many
-SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at
+SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at, battlescribe_id, can_be_reinforced
 FROM units
 WHERE is_manifestation = true
 ORDER BY faction_id, name ASC
@@ -255,6 +268,8 @@ func (q *Queries) GetManifestations(ctx context.Context) ([]Unit, error) {
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.CanBeReinforced,
 		); err != nil {
 			return nil, err
 		}
@@ -267,7 +282,7 @@ func (q *Queries) GetManifestations(ctx context.Context) ([]Unit, error) {
 }
 
 const getNonManifestationUnits = `-- name: GetNonManifestationUnits :many
-SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at
+SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at, battlescribe_id, can_be_reinforced
 FROM units
 WHERE is_manifestation = false
 ORDER BY faction_id, name ASC
@@ -308,6 +323,8 @@ func (q *Queries) GetNonManifestationUnits(ctx context.Context) ([]Unit, error)
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.CanBeReinforced,
 		); err != nil {
 			return nil, err
 		}
@@ -320,7 +337,7 @@ func (q *Queries) GetNonManifestationUnits(ctx context.Context) ([]Unit, error)
 }
 
 const getUnitByID = `-- name: GetUnitByID :one
-SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at
+SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at, battlescribe_id, can_be_reinforced
 FROM units
 WHERE id = $1
 `
@@ -354,12 +371,14 @@ func (q *Queries) GetUnitByID(ctx context.Context, id uuid.UUID) (Unit, error) {
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.CanBeReinforced,
 	)
 	return i, err
 }
 
 const getUnitsByFaction = `-- name: GetUnitsByFaction :many
-SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at
+SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at, battlescribe_id, can_be_reinforced
 FROM units
 WHERE faction_id = $1 AND is_manifestation = false
 ORDER BY name ASC
@@ -400,6 +419,8 @@ func (q *Queries) GetUnitsByFaction(ctx context.Context, factionID uuid.UUID) ([
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.CanBeReinforced,
 		); err != nil {
 			return nil, err
 		}
@@ -411,15 +432,80 @@ func (q *Queries) GetUnitsByFaction(ctx context.Context, factionID uuid.UUID) ([
 	return items, nil
 }
 
-const getUnitsByMatchedPlay = `-- name: GetUnitsByMatchedPlay :many
-SELECT id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at
-FROM units
-WHERE faction_id = $1 AND matched_play = true AND is_manifestation = false
-ORDER BY name ASC
+const listUnits = `-- name: ListUnits :many
+SELECT u.id, u.faction_id, u.name, u.description, u.is_manifestation, u.is_unique, u.move, u.health_wounds, u.save_stats, u.ward_fnp, u.invuln_save, u.control_oc, u.toughness, u.leadership_bravery, u.points, u.additional_stats, u.summon_cost, u.banishment, u.min_unit_size, u.max_unit_size, u.matched_play, u.version, u.source, u.created_at, u.updated_at, u.battlescribe_id, u.can_be_reinforced
+FROM units u
+JOIN factions f ON f.id = u.faction_id
+WHERE u.is_manifestation = $1
+  AND ($2::uuid IS NULL OR u.faction_id = $2)
+  AND ($3::uuid IS NULL OR f.game_id = $3)
+  AND ($4::int IS NULL OR u.points >= $4)
+  AND ($5::int IS NULL OR u.points <= $5)
+  AND ($6::boolean IS NULL OR u.is_unique = $6)
+  AND ($7::boolean IS NULL OR u.matched_play = $7)
+  AND ($8::text IS NULL OR f.allegiance ILIKE $8)
+  AND ($9::text IS NULL OR array_to_tsvector(ARRAY(
+    SELECT upper(k.name)
+    FROM unit_keywords uk
+    JOIN keywords k ON k.id = uk.keyword_id
+    WHERE uk.unit_id = u.id
+  )) @@ $9::text::tsquery)
+  AND ($10::uuid IS NULL OR CASE $11::text
+    WHEN 'name' THEN (u.name, u.id) > ($12::text, $10)
+    WHEN '-name' THEN (u.name, u.id) < ($12::text, $10)
+    WHEN 'points' THEN (u.points, u.id) > ($13::int, $10)
+    WHEN '-points' THEN (u.points, u.id) < ($13::int, $10)
+    WHEN 'created_at' THEN (u.created_at, u.id) > ($14::timestamptz, $10)
+    WHEN '-created_at' THEN (u.created_at, u.id) < ($14::timestamptz, $10)
+  END)
+ORDER BY
+  CASE WHEN $11 = 'name' THEN u.name END ASC,
+  CASE WHEN $11 = '-name' THEN u.name END DESC,
+  CASE WHEN $11 = 'points' THEN u.points END ASC,
+  CASE WHEN $11 = '-points' THEN u.points END DESC,
+  CASE WHEN $11 = 'created_at' THEN u.created_at END ASC,
+  CASE WHEN $11 = '-created_at' THEN u.created_at END DESC,
+  CASE WHEN $11 LIKE '-%' THEN u.id END DESC,
+  u.id ASC
+LIMIT $15
 `
 
-func (q *Queries) GetUnitsByMatchedPlay(ctx context.Context, factionID uuid.UUID) ([]Unit, error) {
-	rows, err := q.db.Query(ctx, getUnitsByMatchedPlay, factionID)
+type ListUnitsParams struct {
+	IsManifestation bool
+	FactionID       uuid.NullUUID
+	GameID          uuid.NullUUID
+	PointsMin       pgtype.Int4
+	PointsMax       pgtype.Int4
+	IsUnique        pgtype.Bool
+	MatchedPlay     pgtype.Bool
+	Allegiance      pgtype.Text
+	Keywords        pgtype.Text
+	AfterID         uuid.NullUUID
+	Sort            string
+	AfterName       pgtype.Text
+	AfterPoints     pgtype.Int4
+	AfterCreatedAt  pgtype.Timestamptz
+	PageSize        int32
+}
+
+func (q *Queries) ListUnits(ctx context.Context, arg ListUnitsParams) ([]Unit, error) {
+	rows, err := q.db.Query(ctx, listUnits,
+		arg.IsManifestation,
+		arg.FactionID,
+		arg.GameID,
+		arg.PointsMin,
+		arg.PointsMax,
+		arg.IsUnique,
+		arg.MatchedPlay,
+		arg.Allegiance,
+		arg.Keywords,
+		arg.AfterID,
+		arg.Sort,
+		arg.AfterName,
+		arg.AfterPoints,
+		arg.AfterCreatedAt,
+		arg.PageSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -453,6 +539,8 @@ func (q *Queries) GetUnitsByMatchedPlay(ctx context.Context, factionID uuid.UUID
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BattlescribeID,
+			&i.CanBeReinforced,
 		); err != nil {
 			return nil, err
 		}
@@ -470,9 +558,11 @@ SET name = $2, description = $3, move = $4, health_wounds = $5, save_stats = $6,
     ward_fnp = $7, invuln_save = $8, control_oc = $9, toughness = $10, 
     leadership_bravery = $11, points = $12, additional_stats = $13,
     summon_cost = $14, banishment = $15, min_unit_size = $16, max_unit_size = $17, 
-    matched_play = $18, version = $19, source = $20, updated_at = now()
+    matched_play = $18, version = $19, source = $20, is_manifestation = $21,
+    is_unique = $22, battlescribe_id = $23, can_be_reinforced = $24,
+    updated_at = now()
 WHERE id = $1
-RETURNING id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at
+RETURNING id, faction_id, name, description, is_manifestation, is_unique, move, health_wounds, save_stats, ward_fnp, invuln_save, control_oc, toughness, leadership_bravery, points, additional_stats, summon_cost, banishment, min_unit_size, max_unit_size, matched_play, version, source, created_at, updated_at, battlescribe_id, can_be_reinforced
 `
 
 type UpdateUnitParams struct {
@@ -496,6 +586,10 @@ type UpdateUnitParams struct {
 	MatchedPlay       bool
 	Version           string
 	Source            string
+	IsManifestation   bool
+	IsUnique          bool
+	BattlescribeID    string
+	CanBeReinforced   bool
 }
 
 func (q *Queries) UpdateUnit(ctx context.Context, arg UpdateUnitParams) (Unit, error) {
@@ -520,6 +614,10 @@ func (q *Queries) UpdateUnit(ctx context.Context, arg UpdateUnitParams) (Unit, e
 		arg.MatchedPlay,
 		arg.Version,
 		arg.Source,
+		arg.IsManifestation,
+		arg.IsUnique,
+		arg.BattlescribeID,
+		arg.CanBeReinforced,
 	)
 	var i Unit
 	err := row.Scan(
@@ -548,6 +646,8 @@ func (q *Queries) UpdateUnit(ctx context.Context, arg UpdateUnitParams) (Unit, e
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BattlescribeID,
+		&i.CanBeReinforced,
 	)
 	return i, err
 }
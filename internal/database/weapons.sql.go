@@ -9,15 +9,17 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createWeapon = `-- name: CreateWeapon :one
 INSERT INTO weapons (
   unit_id, name, range, attacks, hit_stats, 
-  wound_strength, rend_ap, damage, version, source
+  wound_strength, rend_ap, damage, version, source,
+  weapon_type, abilities
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, unit_id, name, range, attacks, hit_stats, wound_strength, rend_ap, damage, version, source, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, unit_id, name, range, attacks, hit_stats, wound_strength, rend_ap, damage, version, source, created_at, updated_at, weapon_type, abilities
 `
 
 type CreateWeaponParams struct {
@@ -31,6 +33,8 @@ type CreateWeaponParams struct {
 	Damage        string
 	Version       string
 	Source        string
+	WeaponType    string
+	Abilities     string
 }
 
 func (q *Queries) CreateWeapon(ctx context.Context, arg CreateWeaponParams) (Weapon, error) {
@@ -45,6 +49,8 @@ func (q *Queries) CreateWeapon(ctx context.Context, arg CreateWeaponParams) (Wea
 		arg.Damage,
 		arg.Version,
 		arg.Source,
+		arg.WeaponType,
+		arg.Abilities,
 	)
 	var i Weapon
 	err := row.Scan(
@@ -61,6 +67,8 @@ func (q *Queries) CreateWeapon(ctx context.Context, arg CreateWeaponParams) (Wea
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WeaponType,
+		&i.Abilities,
 	)
 	return i, err
 }
@@ -75,14 +83,44 @@ func (q *Queries) DeleteWeapon(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-const getAllWeapons = `-- name: GetAllWeapons :many
-SELECT id, unit_id, name, range, attacks, hit_stats, wound_strength, rend_ap, damage, version, source, created_at, updated_at
+const getWeaponByID = `-- name: GetWeaponByID :one
+SELECT id, unit_id, name, range, attacks, hit_stats, wound_strength, rend_ap, damage, version, source, created_at, updated_at, weapon_type, abilities
+FROM weapons
+WHERE id = $1
+`
+
+func (q *Queries) GetWeaponByID(ctx context.Context, id uuid.UUID) (Weapon, error) {
+	row := q.db.QueryRow(ctx, getWeaponByID, id)
+	var i Weapon
+	err := row.Scan(
+		&i.ID,
+		&i.UnitID,
+		&i.Name,
+		&i.Range,
+		&i.Attacks,
+		&i.HitStats,
+		&i.WoundStrength,
+		&i.RendAp,
+		&i.Damage,
+		&i.Version,
+		&i.Source,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.WeaponType,
+		&i.Abilities,
+	)
+	return i, err
+}
+
+const getWeaponsForUnit = `-- name: GetWeaponsForUnit :many
+SELECT id, unit_id, name, range, attacks, hit_stats, wound_strength, rend_ap, damage, version, source, created_at, updated_at, weapon_type, abilities
 FROM weapons
-ORDER BY unit_id, name ASC
+WHERE unit_id = $1
+ORDER BY name ASC
 `
 
-func (q *Queries) GetAllWeapons(ctx context.Context) ([]Weapon, error) {
-	rows, err := q.db.Query(ctx, getAllWeapons)
+func (q *Queries) GetWeaponsForUnit(ctx context.Context, unitID uuid.UUID) ([]Weapon, error) {
+	rows, err := q.db.Query(ctx, getWeaponsForUnit, unitID)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +142,8 @@ func (q *Queries) GetAllWeapons(ctx context.Context) ([]Weapon, error) {
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.WeaponType,
+			&i.Abilities,
 		); err != nil {
 			return nil, err
 		}
@@ -115,42 +155,58 @@ func (q *Queries) GetAllWeapons(ctx context.Context) ([]Weapon, error) {
 	return items, nil
 }
 
-const getWeaponByID = `-- name: GetWeaponByID :one
-SELECT id, unit_id, name, range, attacks, hit_stats, wound_strength, rend_ap, damage, version, source, created_at, updated_at
-FROM weapons
-WHERE id = $1
+const listWeapons = `-- name: ListWeapons :many
+SELECT w.id, w.unit_id, w.name, w.range, w.attacks, w.hit_stats, w.wound_strength, w.rend_ap, w.damage, w.version, w.source, w.created_at, w.updated_at, w.weapon_type, w.abilities
+FROM weapons w
+JOIN units u ON u.id = w.unit_id
+JOIN factions f ON f.id = u.faction_id
+WHERE ($1::uuid IS NULL OR w.unit_id = $1)
+  AND ($2::uuid IS NULL OR u.faction_id = $2)
+  AND ($3::uuid IS NULL OR f.game_id = $3)
+  AND ($4::text IS NULL OR w.weapon_type ILIKE $4)
+  AND ($5::text IS NULL OR f.allegiance ILIKE $5)
+  AND ($6::uuid IS NULL OR CASE $7::text
+    WHEN 'name' THEN (w.name, w.id) > ($8::text, $6)
+    WHEN '-name' THEN (w.name, w.id) < ($8::text, $6)
+    WHEN 'created_at' THEN (w.created_at, w.id) > ($9::timestamptz, $6)
+    WHEN '-created_at' THEN (w.created_at, w.id) < ($9::timestamptz, $6)
+  END)
+ORDER BY
+  CASE WHEN $7 = 'name' THEN w.name END ASC,
+  CASE WHEN $7 = '-name' THEN w.name END DESC,
+  CASE WHEN $7 = 'created_at' THEN w.created_at END ASC,
+  CASE WHEN $7 = '-created_at' THEN w.created_at END DESC,
+  CASE WHEN $7 LIKE '-%' THEN w.id END DESC,
+  w.id ASC
+LIMIT $10
 `
 
-func (q *Queries) GetWeaponByID(ctx context.Context, id uuid.UUID) (Weapon, error) {
-	row := q.db.QueryRow(ctx, getWeaponByID, id)
-	var i Weapon
-	err := row.Scan(
-		&i.ID,
-		&i.UnitID,
-		&i.Name,
-		&i.Range,
-		&i.Attacks,
-		&i.HitStats,
-		&i.WoundStrength,
-		&i.RendAp,
-		&i.Damage,
-		&i.Version,
-		&i.Source,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type ListWeaponsParams struct {
+	UnitID         uuid.NullUUID
+	FactionID      uuid.NullUUID
+	GameID         uuid.NullUUID
+	WeaponType     pgtype.Text
+	Allegiance     pgtype.Text
+	AfterID        uuid.NullUUID
+	Sort           string
+	AfterName      pgtype.Text
+	AfterCreatedAt pgtype.Timestamptz
+	PageSize       int32
 }
 
-const getWeaponsForUnit = `-- name: GetWeaponsForUnit :many
-SELECT id, unit_id, name, range, attacks, hit_stats, wound_strength, rend_ap, damage, version, source, created_at, updated_at
-FROM weapons
-WHERE unit_id = $1
-ORDER BY name ASC
-`
-
-func (q *Queries) GetWeaponsForUnit(ctx context.Context, unitID uuid.UUID) ([]Weapon, error) {
-	rows, err := q.db.Query(ctx, getWeaponsForUnit, unitID)
+func (q *Queries) ListWeapons(ctx context.Context, arg ListWeaponsParams) ([]Weapon, error) {
+	rows, err := q.db.Query(ctx, listWeapons,
+		arg.UnitID,
+		arg.FactionID,
+		arg.GameID,
+		arg.WeaponType,
+		arg.Allegiance,
+		arg.AfterID,
+		arg.Sort,
+		arg.AfterName,
+		arg.AfterCreatedAt,
+		arg.PageSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +228,8 @@ func (q *Queries) GetWeaponsForUnit(ctx context.Context, unitID uuid.UUID) ([]We
 			&i.Source,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.WeaponType,
+			&i.Abilities,
 		); err != nil {
 			return nil, err
 		}
@@ -187,9 +245,10 @@ const updateWeapon = `-- name: UpdateWeapon :one
 UPDATE weapons
 SET name = $2, range = $3, attacks = $4, hit_stats = $5, 
     wound_strength = $6, rend_ap = $7, damage = $8, 
-    version = $9, source = $10, updated_at = now()
+    version = $9, source = $10, weapon_type = $11,
+    abilities = $12, updated_at = now()
 WHERE id = $1
-RETURNING id, unit_id, name, range, attacks, hit_stats, wound_strength, rend_ap, damage, version, source, created_at, updated_at
+RETURNING id, unit_id, name, range, attacks, hit_stats, wound_strength, rend_ap, damage, version, source, created_at, updated_at, weapon_type, abilities
 `
 
 type UpdateWeaponParams struct {
@@ -203,6 +262,8 @@ type UpdateWeaponParams struct {
 	Damage        string
 	Version       string
 	Source        string
+	WeaponType    string
+	Abilities     string
 }
 
 func (q *Queries) UpdateWeapon(ctx context.Context, arg UpdateWeaponParams) (Weapon, error) {
@@ -217,6 +278,8 @@ func (q *Queries) UpdateWeapon(ctx context.Context, arg UpdateWeaponParams) (Wea
 		arg.Damage,
 		arg.Version,
 		arg.Source,
+		arg.WeaponType,
+		arg.Abilities,
 	)
 	var i Weapon
 	err := row.Scan(
@@ -233,6 +296,8 @@ func (q *Queries) UpdateWeapon(ctx context.Context, arg UpdateWeaponParams) (Wea
 		&i.Source,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WeaponType,
+		&i.Abilities,
 	)
 	return i, err
 }
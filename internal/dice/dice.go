@@ -0,0 +1,146 @@
+// Package dice parses dice expressions such as "D3", "2D6" and "D6+1" and
+// works out their range, mean and distribution.
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalid is returned for strings that are not a dice expression.
+var ErrInvalid = errors.New("invalid dice expression")
+
+// maxDice caps the dice of an expression so distributions stay small.
+const maxDice = 20
+
+// Expr is Count dice with Sides sides each, plus Modifier. A fixed value has
+// no dice.
+type Expr struct {
+	Count    int
+	Sides    int
+	Modifier int
+}
+
+// Fixed returns the expression always worth n.
+func Fixed(n int) Expr {
+	return Expr{Modifier: n}
+}
+
+// Parse reads expressions of the form "3", "D3", "2D6", "D6+1" or "D3-1".
+// The D is case-insensitive and spaces are ignored.
+func Parse(s string) (Expr, error) {
+	raw := s
+	s = strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if s == "" {
+		return Expr{}, fmt.Errorf("%w: empty", ErrInvalid)
+	}
+
+	d := strings.IndexByte(s, 'D')
+	if d < 0 {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Expr{}, fmt.Errorf("%w: %q", ErrInvalid, raw)
+		}
+		return Fixed(n), nil
+	}
+
+	e := Expr{Count: 1}
+	if d > 0 {
+		count, err := strconv.Atoi(s[:d])
+		if err != nil || count < 1 {
+			return Expr{}, fmt.Errorf("%w: %q", ErrInvalid, raw)
+		}
+		e.Count = count
+	}
+
+	rest := s[d+1:]
+	sidesEnd := strings.IndexAny(rest, "+-")
+	if sidesEnd < 0 {
+		sidesEnd = len(rest)
+	}
+	sides, err := strconv.Atoi(rest[:sidesEnd])
+	if err != nil || sides < 2 {
+		return Expr{}, fmt.Errorf("%w: %q", ErrInvalid, raw)
+	}
+	e.Sides = sides
+
+	if sidesEnd < len(rest) {
+		modifier, err := strconv.Atoi(rest[sidesEnd:])
+		if err != nil {
+			return Expr{}, fmt.Errorf("%w: %q", ErrInvalid, raw)
+		}
+		e.Modifier = modifier
+	}
+
+	if e.Count > maxDice {
+		return Expr{}, fmt.Errorf("%w: more than %d dice in %q", ErrInvalid, maxDice, raw)
+	}
+	return e, nil
+}
+
+// IsFixed reports whether the expression rolls no dice.
+func (e Expr) IsFixed() bool {
+	return e.Count == 0
+}
+
+func (e Expr) Min() int {
+	return e.Count + e.Modifier
+}
+
+func (e Expr) Max() int {
+	return e.Count*e.Sides + e.Modifier
+}
+
+func (e Expr) Mean() float64 {
+	return float64(e.Count)*float64(e.Sides+1)/2 + float64(e.Modifier)
+}
+
+// String writes the expression back in its canonical form, such as "D3+1".
+func (e Expr) String() string {
+	if e.IsFixed() {
+		return strconv.Itoa(e.Modifier)
+	}
+
+	var b strings.Builder
+	if e.Count > 1 {
+		b.WriteString(strconv.Itoa(e.Count))
+	}
+	b.WriteString("D")
+	b.WriteString(strconv.Itoa(e.Sides))
+	if e.Modifier > 0 {
+		b.WriteString("+")
+	}
+	if e.Modifier != 0 {
+		b.WriteString(strconv.Itoa(e.Modifier))
+	}
+	return b.String()
+}
+
+// Roll rolls the expression with r.
+func (e Expr) Roll(r *rand.Rand) int {
+	total := e.Modifier
+	for range e.Count {
+		total += 1 + r.IntN(e.Sides)
+	}
+	return total
+}
+
+// Distribution returns the chance of every total the expression can roll.
+func (e Expr) Distribution() Distribution {
+	dist := Distribution{{Value: e.Modifier, Probability: 1}}
+	if e.IsFixed() {
+		return dist
+	}
+
+	die := make(Distribution, e.Sides)
+	for i := range die {
+		die[i] = Outcome{Value: i + 1, Probability: 1 / float64(e.Sides)}
+	}
+	for range e.Count {
+		dist = dist.Add(die)
+	}
+	return dist
+}
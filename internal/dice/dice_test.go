@@ -0,0 +1,147 @@
+package dice
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected Expr
+		min, max int
+		mean     float64
+	}{
+		{in: "3", expected: Expr{Modifier: 3}, min: 3, max: 3, mean: 3},
+		{in: "D3", expected: Expr{Count: 1, Sides: 3}, min: 1, max: 3, mean: 2},
+		{in: "d6", expected: Expr{Count: 1, Sides: 6}, min: 1, max: 6, mean: 3.5},
+		{in: "2D6", expected: Expr{Count: 2, Sides: 6}, min: 2, max: 12, mean: 7},
+		{in: "D6+1", expected: Expr{Count: 1, Sides: 6, Modifier: 1}, min: 2, max: 7, mean: 4.5},
+		{in: "D3+0", expected: Expr{Count: 1, Sides: 3}, min: 1, max: 3, mean: 2},
+		{in: "3D6 + 3", expected: Expr{Count: 3, Sides: 6, Modifier: 3}, min: 6, max: 21, mean: 13.5},
+		{in: "D6-1", expected: Expr{Count: 1, Sides: 6, Modifier: -1}, min: 0, max: 5, mean: 2.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, e)
+			}
+			if e.Min() != tt.min || e.Max() != tt.max || e.Mean() != tt.mean {
+				t.Errorf("expected %d-%d mean %v, got %d-%d mean %v", tt.min, tt.max, tt.mean, e.Min(), e.Max(), e.Mean())
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, in := range []string{"", "-", "D", "2D", "D1", "0D6", "D6+", "3+", "See ability", "100D6"} {
+		_, err := Parse(in)
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("expected ErrInvalid for %q, got %v", in, err)
+		}
+	}
+}
+
+func TestExprString(t *testing.T) {
+	for in, expected := range map[string]string{"3": "3", "D3+0": "D3", "2d6": "2D6", "D6+1": "D6+1", "D6-1": "D6-1"} {
+		e, err := Parse(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.String() != expected {
+			t.Errorf("expected %q for %q, got %q", expected, in, e.String())
+		}
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	e, _ := Parse("2D6")
+	dist := e.Distribution()
+
+	if len(dist) != 11 || dist[0].Value != 2 || dist[10].Value != 12 {
+		t.Fatalf("expected totals 2 to 12, got %+v", dist)
+	}
+	if math.Abs(dist[5].Probability-6.0/36) > 1e-9 {
+		t.Errorf("expected a 6/36 chance of 7, got %v", dist[5].Probability)
+	}
+
+	total := 0.0
+	for _, o := range dist {
+		total += o.Probability
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("expected probabilities to add up to 1, got %v", total)
+	}
+
+	if math.Abs(dist.Mean()-e.Mean()) > 1e-9 {
+		t.Errorf("expected mean %v, got %v", e.Mean(), dist.Mean())
+	}
+	if math.Abs(dist.AtLeast(11)-3.0/36) > 1e-9 {
+		t.Errorf("expected a 3/36 chance of 11 or more, got %v", dist.AtLeast(11))
+	}
+
+	capped := dist.Map(func(v int) int { return min(v, 4) })
+	if len(capped) != 3 || math.Abs(capped[2].Probability-33.0/36) > 1e-9 {
+		t.Errorf("expected values capped at 4, got %+v", capped)
+	}
+
+	fixed := Fixed(2).Distribution()
+	if len(fixed) != 1 || fixed[0] != (Outcome{Value: 2, Probability: 1}) {
+		t.Errorf("expected a single certain outcome, got %+v", fixed)
+	}
+}
+
+func TestCompound(t *testing.T) {
+	d3 := Expr{Count: 1, Sides: 3}.Distribution()
+	if got := d3.Repeat(2); len(got) != 5 || got[0].Value != 2 || math.Abs(got.Mean()-4) > 1e-9 {
+		t.Errorf("expected 2D3, got %+v", got)
+	}
+
+	// D3 rolls of D3 average 2 * 2.
+	compound := d3.Compound(d3)
+	if math.Abs(compound.Mean()-4) > 1e-9 || compound[0].Value != 1 || compound[len(compound)-1].Value != 9 {
+		t.Errorf("expected 1 to 9 averaging 4, got %+v", compound)
+	}
+
+	mixed := Mixture([]float64{0.5, 0.5}, []Distribution{Fixed(0).Distribution(), Fixed(4).Distribution()})
+	if len(mixed) != 2 || mixed.Mean() != 2 {
+		t.Errorf("expected an even mix of 0 and 4, got %+v", mixed)
+	}
+}
+
+func TestRoll(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	e := Expr{Count: 2, Sides: 6, Modifier: 1}
+
+	counts := make(map[int]int)
+	for range 10000 {
+		v := e.Roll(r)
+		if v < e.Min() || v > e.Max() {
+			t.Fatalf("rolled %d outside %d-%d", v, e.Min(), e.Max())
+		}
+		counts[v]++
+	}
+
+	dist := FromCounts(counts)
+	if math.Abs(dist.Mean()-e.Mean()) > 0.1 {
+		t.Errorf("expected rolls to average about %v, got %v", e.Mean(), dist.Mean())
+	}
+	if dist.Percentile(0.5) != 8 || dist.Percentile(0) != 3 || dist.Percentile(1) != 13 {
+		t.Errorf("expected a median of 8 between 3 and 13, got %d between %d and %d", dist.Percentile(0.5), dist.Percentile(0), dist.Percentile(1))
+	}
+
+	again := rand.New(rand.NewPCG(1, 2))
+	first := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		if e.Roll(again) != e.Roll(first) {
+			t.Fatal("expected the same seed to roll the same values")
+		}
+	}
+}
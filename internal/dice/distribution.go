@@ -0,0 +1,134 @@
+package dice
+
+import "sort"
+
+// Outcome is a value and the chance of getting it.
+type Outcome struct {
+	Value       int     `json:"value"`
+	Probability float64 `json:"probability"`
+}
+
+// Distribution lists outcomes in increasing order of value, with
+// probabilities adding up to 1.
+type Distribution []Outcome
+
+// Add returns the distribution of the sum of a value from d and one from
+// other, rolled independently.
+func (d Distribution) Add(other Distribution) Distribution {
+	sums := make(map[int]float64, len(d)+len(other))
+	for _, a := range d {
+		for _, b := range other {
+			sums[a.Value+b.Value] += a.Probability * b.Probability
+		}
+	}
+	return fromMap(sums)
+}
+
+// Map returns the distribution of f applied to the values of d.
+func (d Distribution) Map(f func(int) int) Distribution {
+	values := make(map[int]float64, len(d))
+	for _, o := range d {
+		values[f(o.Value)] += o.Probability
+	}
+	return fromMap(values)
+}
+
+// Repeat returns the distribution of the sum of n independent values from d.
+func (d Distribution) Repeat(n int) Distribution {
+	sum := Distribution{{Value: 0, Probability: 1}}
+	for range n {
+		sum = sum.Add(d)
+	}
+	return sum
+}
+
+// Compound returns the distribution of the sum of a random number of
+// independent values from d, the number following count.
+func (d Distribution) Compound(count Distribution) Distribution {
+	values := make(map[int]float64)
+	sum := Distribution{{Value: 0, Probability: 1}}
+	n := 0
+	for _, c := range count {
+		for ; n < c.Value; n++ {
+			sum = sum.Add(d)
+		}
+		for _, o := range sum {
+			values[o.Value] += c.Probability * o.Probability
+		}
+	}
+	return fromMap(values)
+}
+
+// Mixture returns the distribution of taking a value from dists[i] with
+// chance weights[i]. The weights should add up to 1.
+func Mixture(weights []float64, dists []Distribution) Distribution {
+	values := make(map[int]float64)
+	for i, dist := range dists {
+		for _, o := range dist {
+			values[o.Value] += weights[i] * o.Probability
+		}
+	}
+	return fromMap(values)
+}
+
+// Mean is the average value of d.
+func (d Distribution) Mean() float64 {
+	mean := 0.0
+	for _, o := range d {
+		mean += float64(o.Value) * o.Probability
+	}
+	return mean
+}
+
+// AtLeast returns the chance of a value of n or more.
+func (d Distribution) AtLeast(n int) float64 {
+	p := 0.0
+	for _, o := range d {
+		if o.Value >= n {
+			p += o.Probability
+		}
+	}
+	return p
+}
+
+// Percentile returns the smallest value with at least a p chance of rolling
+// it or less, so Percentile(0.5) is the median.
+func (d Distribution) Percentile(p float64) int {
+	cumulative := 0.0
+	for _, o := range d {
+		cumulative += o.Probability
+		// Allow for rounding in the sum of the probabilities.
+		if cumulative >= p-1e-9 {
+			return o.Value
+		}
+	}
+	if len(d) == 0 {
+		return 0
+	}
+	return d[len(d)-1].Value
+}
+
+// FromCounts returns the distribution of values seen counts[value] times.
+func FromCounts(counts map[int]int) Distribution {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+
+	values := make(map[int]float64, len(counts))
+	for v, n := range counts {
+		values[v] = float64(n) / float64(total)
+	}
+	return fromMap(values)
+}
+
+func fromMap(values map[int]float64) Distribution {
+	dist := make(Distribution, 0, len(values))
+	for v, p := range values {
+		if p > 0 {
+			dist = append(dist, Outcome{Value: v, Probability: p})
+		}
+	}
+	sort.Slice(dist, func(i, j int) bool { return dist[i].Value < dist[j].Value })
+	return dist
+}
@@ -7,5 +7,32 @@ var (
 	ErrMissingID        = errors.New("id paramater required")
 	ErrMissingUnitID    = errors.New("unit id parameter required")
 	ErrMissingFactionID = errors.New("faction id paramater required")
+	ErrMissingGameID    = errors.New("game id parameter required")
+	ErrMissingName      = errors.New("name parameter required")
+	ErrMissingText      = errors.New("text parameter required")
 	ErrNotFound         = errors.New("resource not found")
+	// ErrUnsupportedFormat is returned when an export format is not known
+	ErrUnsupportedFormat = errors.New("unsupported format")
+	// ErrInvalidReference is returned when a write points at a row that does not exist
+	ErrInvalidReference = errors.New("referenced resource does not exist")
+	// ErrInvalidRoster is returned when an uploaded file is not a BattleScribe roster
+	ErrInvalidRoster = errors.New("invalid roster file")
+	// ErrInvalidStat is returned when a stat given in a request does not parse
+	ErrInvalidStat = errors.New("invalid stat value")
+	// ErrInvalidModelCount is returned when a model count is out of range
+	ErrInvalidModelCount = errors.New("invalid model count")
+	// ErrInvalidIterations is returned when a simulation asks for too many or too few iterations
+	ErrInvalidIterations = errors.New("invalid iteration count")
+	// ErrInvalidLimit is returned when a page size is out of range
+	ErrInvalidLimit = errors.New("invalid limit")
+	// ErrInvalidSort is returned when a list cannot be sorted by the field asked for
+	ErrInvalidSort = errors.New("invalid sort")
+	// ErrInvalidCursor is returned when a cursor is malformed or from a different sort
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrMissingQuery is returned when a search has no query
+	ErrMissingQuery = errors.New("query parameter required")
+	// ErrInvalidSearchType is returned when a search asks for a type of hit that does not exist
+	ErrInvalidSearchType = errors.New("invalid search type")
+	// ErrMissingRegimentOfRenownID is returned when a list takes Regiment of Renown units without hiring one
+	ErrMissingRegimentOfRenownID = errors.New("regiment of renown id required")
 )
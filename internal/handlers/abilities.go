@@ -5,7 +5,6 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
-	"go.uber.org/zap"
 
 	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
 	"github.com/JohnG-Dev/army_builder_api/internal/services"
@@ -17,115 +16,7 @@ type AbilitiesHandlers struct {
 }
 
 func (h *AbilitiesHandlers) GetAbilities(w http.ResponseWriter, r *http.Request) {
-	unitID := r.URL.Query().Get("unit_id")
-	factionID := r.URL.Query().Get("faction_id")
-	typeName := r.URL.Query().Get("type")
-	phase := r.URL.Query().Get("phase")
-
-	if unitID != "" {
-		h.getAbilitiesForUnit(w, r)
-		return
-	}
-
-	if factionID != "" {
-		h.getAbilitiesForFaction(w, r)
-		return
-	}
-
-	if typeName != "" {
-		h.getAbilitiesByType(w, r)
-		return
-	}
-
-	if phase != "" {
-		h.getAbilitiesByPhase(w, r)
-		return
-	}
-
-	h.getAllAbilities(w, r)
-}
-
-func (h *AbilitiesHandlers) getAllAbilities(w http.ResponseWriter, r *http.Request) {
-	abilities, err := services.GetAllAbilities(h.S, r.Context())
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "abilities not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch abilities", err)
-		}
-		logRequestError(h.S, r, "failed to fetch abilities", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched abilities", zap.Int("count", len(abilities)))
-	respondWithJSON(w, http.StatusOK, abilities)
-}
-
-func (h *AbilitiesHandlers) getAbilitiesForUnit(w http.ResponseWriter, r *http.Request) {
-	unitIDStr := r.URL.Query().Get("unit_id")
-
-	if unitIDStr == "" {
-		respondWithError(w, http.StatusBadRequest, "missing unit id", nil)
-		return
-	}
-
-	unitID, err := uuid.Parse(unitIDStr)
-	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "invalid unit id", err)
-		return
-	}
-
-	abilities, err := services.GetAbilitiesForUnit(h.S, r.Context(), unitID)
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrMissingUnitID):
-			respondWithError(w, http.StatusBadRequest, "unit id required", err)
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "unit not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch abilities", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch abilities", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched abilities", zap.Int("count", len(abilities)))
-	respondWithJSON(w, http.StatusOK, abilities)
-}
-
-func (h *AbilitiesHandlers) getAbilitiesForFaction(w http.ResponseWriter, r *http.Request) {
-	factionIDStr := r.URL.Query().Get("faction_id")
-
-	if factionIDStr == "" {
-		respondWithError(w, http.StatusBadRequest, "missing faction id", nil)
-		return
-	}
-
-	factionID, err := uuid.Parse(factionIDStr)
-	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "invalid faction id", err)
-		return
-	}
-
-	abilities, err := services.GetAbilitiesForFaction(h.S, r.Context(), factionID)
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrMissingFactionID):
-			respondWithError(w, http.StatusBadRequest, "faction id required", err)
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "abilities not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch abilities", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch abilities", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfuly fetched abilities", zap.Int("count", len(abilities)))
-	respondWithJSON(w, http.StatusOK, abilities)
+	serveList(h.S, w, r, "abilities", services.ListAbilities, "game_id", "faction_id", "unit_id", "type", "phase", "allegiance")
 }
 
 func (h *AbilitiesHandlers) GetAbilityByID(w http.ResponseWriter, r *http.Request) {
@@ -158,56 +49,3 @@ func (h *AbilitiesHandlers) GetAbilityByID(w http.ResponseWriter, r *http.Reques
 	logRequestInfo(h.S, r, "Successfully fetched ability")
 	respondWithJSON(w, http.StatusOK, ability)
 }
-
-func (h *AbilitiesHandlers) getAbilitiesByType(w http.ResponseWriter, r *http.Request) {
-	typeStr := r.URL.Query().Get("type")
-
-	if typeStr == "" {
-		respondWithError(w, http.StatusBadRequest, "missing ability type", nil)
-		return
-	}
-
-	ability, err := services.GetAbilitiesByType(h.S, r.Context(), typeStr)
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrMissingID):
-			respondWithError(w, http.StatusBadRequest, "missing ability type", err)
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "ability not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch ability", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch ability", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched ability")
-	respondWithJSON(w, http.StatusOK, ability)
-}
-
-func (h *AbilitiesHandlers) getAbilitiesByPhase(w http.ResponseWriter, r *http.Request) {
-	phaseStr := r.URL.Query().Get("phase")
-
-	if phaseStr == "" {
-		respondWithError(w, http.StatusBadRequest, "missing phase type", nil)
-		return
-	}
-
-	ability, err := services.GetAbilitiesByPhase(h.S, r.Context(), phaseStr)
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrMissingID):
-			respondWithError(w, http.StatusBadRequest, "missing phase", err)
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "ability not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch ability", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch ability", err)
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched ability")
-	respondWithJSON(w, http.StatusOK, ability)
-}
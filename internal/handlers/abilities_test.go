@@ -168,6 +168,67 @@ func TestGetAbilities_FilterByFactionID(t *testing.T) {
 	}
 }
 
+func TestGetAbilities_FilterByGameID(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID1 := createTestGameWithName(t, s, "Age of Sigmar")
+	gameID2 := createTestGameWithName(t, s, "Warhammer 40k")
+
+	_, err := s.DB.CreateAbility(ctx, database.CreateAbilityParams{
+		UnitID:    uuid.NullUUID{},
+		FactionID: uuid.NullUUID{},
+		GameID:    database.UUIDToNullUUID(gameID1),
+		Name:      "Test Fly Ability",
+		Type:      "Passive",
+		Phase:     "Passive",
+		Version:   "1.0",
+		Source:    "Test Source",
+	})
+	if err != nil {
+		t.Fatalf("failed to create test fly ability, %v", err)
+	}
+
+	_, err = s.DB.CreateAbility(ctx, database.CreateAbilityParams{
+		UnitID:    uuid.NullUUID{},
+		FactionID: uuid.NullUUID{},
+		GameID:    database.UUIDToNullUUID(gameID2),
+		Name:      "Test Deep Strike Ability",
+		Type:      "Passive",
+		Phase:     "Passive",
+		Version:   "1.0",
+		Source:    "Test Source",
+	})
+	if err != nil {
+		t.Fatalf("failed to create test deep strike ability, %v", err)
+	}
+
+	handler := &AbilitiesHandlers{S: s}
+
+	req := httptest.NewRequest(http.MethodGet, "/abilities?game_id="+gameID1.String(), nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAbilities(w, req)
+
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	body, _ := io.ReadAll(res.Body)
+	bodyStr := string(body)
+
+	if !strings.Contains(bodyStr, "Test Fly Ability") {
+		t.Errorf("expected body to contain 'test fly ability', got %s", bodyStr)
+	}
+
+	if strings.Contains(bodyStr, "Test Deep Strike Ability") {
+		t.Errorf("expected body to NOT contain 'test deep strike ability', got %s", bodyStr)
+	}
+}
+
 func TestGetAbilites_FilterByType(t *testing.T) {
 	s := setupTestDB(t)
 	ctx := context.Background()
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/services"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+type ArmiesHandlers struct {
+	S *state.State
+}
+
+func (h *ArmiesHandlers) GetArmies(w http.ResponseWriter, r *http.Request) {
+	factionIDStr := r.URL.Query().Get("faction_id")
+	var factionID *uuid.UUID
+
+	if factionIDStr != "" {
+		id, err := uuid.Parse(factionIDStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid faction id", err)
+			return
+		}
+		factionID = &id
+	}
+
+	armies, err := services.GetArmyLists(h.S, r.Context(), factionID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to fetch armies", err)
+		logRequestError(h.S, r, "failed to fetch armies", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully fetched armies", zap.Int("count", len(armies)))
+	respondWithJSON(w, http.StatusOK, armies)
+}
+
+func (h *ArmiesHandlers) GetArmyByID(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid army id", err)
+		return
+	}
+
+	army, err := services.GetArmyListByID(h.S, r.Context(), id)
+	if err != nil {
+		h.respondWithArmyError(w, r, "failed to fetch army", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully fetched army")
+	respondWithJSON(w, http.StatusOK, army)
+}
+
+func (h *ArmiesHandlers) CreateArmy(w http.ResponseWriter, r *http.Request) {
+	var req models.ArmyListRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	army, err := services.CreateArmyList(h.S, r.Context(), req)
+	if err != nil {
+		h.respondWithArmyError(w, r, "failed to create army", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully created army", zap.Bool("is_valid", army.IsValid))
+	respondWithJSON(w, http.StatusCreated, army)
+}
+
+func (h *ArmiesHandlers) UpdateArmy(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid army id", err)
+		return
+	}
+
+	var req models.ArmyListRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	army, err := services.UpdateArmyList(h.S, r.Context(), id, req)
+	if err != nil {
+		h.respondWithArmyError(w, r, "failed to update army", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully updated army", zap.Bool("is_valid", army.IsValid))
+	respondWithJSON(w, http.StatusOK, army)
+}
+
+func (h *ArmiesHandlers) DeleteArmy(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid army id", err)
+		return
+	}
+
+	err = services.DeleteArmyList(h.S, r.Context(), id)
+	if err != nil {
+		h.respondWithArmyError(w, r, "failed to delete army", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully deleted army")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ArmiesHandlers) ImportArmy(w http.ResponseWriter, r *http.Request) {
+	var req models.ArmyImportRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	imported, err := services.ImportArmyList(h.S, r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErr.ErrMissingText):
+			respondWithError(w, http.StatusBadRequest, "army text required", err)
+		case errors.Is(err, appErr.ErrMissingFactionID):
+			respondWithError(w, http.StatusBadRequest, "faction id required, no faction is named in the text", err)
+		case errors.Is(err, appErr.ErrNotFound):
+			respondWithError(w, http.StatusNotFound, "faction not found", err)
+		default:
+			respondWithError(w, http.StatusInternalServerError, "failed to import army", err)
+		}
+		logRequestError(h.S, r, "failed to import army", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully imported army", zap.Int("report_lines", len(imported.Report)))
+	respondWithJSON(w, http.StatusOK, imported)
+}
+
+// maxRosterUpload caps the size of a roster sent to POST /armies/import/roster.
+const maxRosterUpload = 4 << 20
+
+// ImportArmyRoster reads a BattleScribe .ros or .rosz file sent as the body.
+// The faction comes from the faction_id query parameter or else from the
+// roster's catalogue.
+func (h *ArmiesHandlers) ImportArmyRoster(w http.ResponseWriter, r *http.Request) {
+	var gameID, factionID uuid.UUID
+	var err error
+
+	if gameIDStr := r.URL.Query().Get("game_id"); gameIDStr != "" {
+		gameID, err = uuid.Parse(gameIDStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid game id", err)
+			return
+		}
+	}
+	if factionIDStr := r.URL.Query().Get("faction_id"); factionIDStr != "" {
+		factionID, err = uuid.Parse(factionIDStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid faction id", err)
+			return
+		}
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRosterUpload))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondWithError(w, http.StatusRequestEntityTooLarge, "roster too large", err)
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	imported, err := services.ImportArmyRoster(h.S, r.Context(), gameID, factionID, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErr.ErrInvalidRoster):
+			respondWithError(w, http.StatusBadRequest, "body must be a BattleScribe .ros or .rosz file", err)
+		case errors.Is(err, appErr.ErrMissingFactionID):
+			respondWithError(w, http.StatusBadRequest, "faction id required, no faction matches the roster", err)
+		case errors.Is(err, appErr.ErrNotFound):
+			respondWithError(w, http.StatusNotFound, "faction not found", err)
+		default:
+			respondWithError(w, http.StatusInternalServerError, "failed to import army", err)
+		}
+		logRequestError(h.S, r, "failed to import roster", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully imported roster", zap.Int("report_lines", len(imported.Report)))
+	respondWithJSON(w, http.StatusOK, imported)
+}
+
+func (h *ArmiesHandlers) ExportArmy(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid army id", err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = services.ExportText
+	}
+
+	var contentType string
+	switch format {
+	case services.ExportText:
+		contentType = "text/plain; charset=utf-8"
+	case services.ExportMarkdown:
+		contentType = "text/markdown; charset=utf-8"
+	case services.ExportRoster:
+		contentType = "application/xml"
+	case services.ExportJSON:
+	default:
+		respondWithError(w, http.StatusBadRequest, "format must be text, markdown, json or ros", appErr.ErrUnsupportedFormat)
+		return
+	}
+
+	export, err := services.GetArmyListExport(h.S, r.Context(), id)
+	if err != nil {
+		h.respondWithArmyError(w, r, "failed to export army", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully exported army", zap.String("format", format))
+
+	if format == services.ExportJSON {
+		respondWithJSON(w, http.StatusOK, export)
+		return
+	}
+
+	if format == services.ExportRoster {
+		game, err := services.GetGame(h.S, r.Context(), export.Army.GameID)
+		if err != nil {
+			h.respondWithArmyError(w, r, "failed to export army", err)
+			return
+		}
+
+		body, err := services.RenderArmyListRoster(export, game)
+		if err != nil {
+			h.respondWithArmyError(w, r, "failed to export army", err)
+			return
+		}
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.Army.Name + ".ros"}))
+		respondWithText(w, http.StatusOK, contentType, string(body))
+		return
+	}
+
+	body, err := services.RenderArmyListExport(export, format)
+	if err != nil {
+		h.respondWithArmyError(w, r, "failed to export army", err)
+		return
+	}
+	respondWithText(w, http.StatusOK, contentType, body)
+}
+
+func (h *ArmiesHandlers) respondWithArmyError(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	switch {
+	case errors.Is(err, appErr.ErrMissingID):
+		respondWithError(w, http.StatusBadRequest, "army id required", err)
+	case errors.Is(err, appErr.ErrMissingGameID):
+		respondWithError(w, http.StatusBadRequest, "game id required", err)
+	case errors.Is(err, appErr.ErrMissingFactionID):
+		respondWithError(w, http.StatusBadRequest, "faction id required", err)
+	case errors.Is(err, appErr.ErrMissingName):
+		respondWithError(w, http.StatusBadRequest, "army name required", err)
+	case errors.Is(err, appErr.ErrMissingRegimentOfRenownID):
+		respondWithError(w, http.StatusBadRequest, "regiment of renown id required for regiment of renown units", err)
+	case errors.Is(err, appErr.ErrInvalidReference):
+		respondWithError(w, http.StatusBadRequest, "army references an unknown game, faction, unit, enhancement or battle formation", err)
+	case errors.Is(err, appErr.ErrNotFound):
+		respondWithError(w, http.StatusNotFound, "army not found", err)
+	default:
+		respondWithError(w, http.StatusInternalServerError, msg, err)
+	}
+
+	logRequestError(h.S, r, msg, err)
+}
@@ -0,0 +1,541 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+func createTestArmy(t *testing.T, s *state.State, armyReq models.ArmyListRequest) models.ArmyList {
+	jsonData, err := json.Marshal(armyReq)
+	if err != nil {
+		t.Fatalf("failed to marshal army req: %v", err)
+	}
+
+	handler := &ArmiesHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPost, "/armies", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateArmy(w, req)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", res.StatusCode)
+	}
+
+	var army models.ArmyList
+	err = json.NewDecoder(res.Body).Decode(&army)
+	if err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+
+	return army
+}
+
+func TestCreateArmy_Success(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	heroID := createTestHero(t, s, gameID, factionID)
+	unitID := createTestUnit(t, s, factionID)
+	enhancementID := createTestEnhancement(t, s, factionID)
+
+	army := createTestArmy(t, s, models.ArmyListRequest{
+		GameID:      gameID,
+		FactionID:   factionID,
+		Name:        "Test List",
+		PointsLimit: 2000,
+		Regiments: []models.ArmyRegimentRequest{
+			{
+				Name:      "Regiment 1",
+				IsGeneral: true,
+				Units: []models.ArmyListUnitRequest{
+					{UnitID: heroID, Quantity: 1, EnhancementIDs: []uuid.UUID{enhancementID}},
+					{UnitID: unitID, Quantity: 1, Reinforced: true},
+				},
+			},
+		},
+		Auxiliaries: []models.ArmyListUnitRequest{{UnitID: unitID, Quantity: 1}},
+	})
+
+	if army.Name != "Test List" {
+		t.Errorf("expected army name 'Test List', got %s", army.Name)
+	}
+
+	if !army.IsValid {
+		t.Errorf("expected army to be valid, got errors: %v", army.Validation.Errors)
+	}
+
+	if army.TotalPoints != 470 {
+		t.Errorf("expected 470 points, got %d", army.TotalPoints)
+	}
+
+	if len(army.Regiments) != 1 || len(army.Regiments[0].Units) != 2 {
+		t.Fatalf("expected 1 regiment with 2 units, got %+v", army.Regiments)
+	}
+
+	if !army.Regiments[0].IsGeneral {
+		t.Errorf("expected the regiment to be the general's")
+	}
+
+	if len(army.Regiments[0].Units[0].EnhancementIDs) != 1 {
+		t.Errorf("expected 1 enhancement, got %d", len(army.Regiments[0].Units[0].EnhancementIDs))
+	}
+
+	if !army.Regiments[0].Units[1].Reinforced {
+		t.Errorf("expected the second unit to be reinforced")
+	}
+
+	if len(army.Auxiliaries) != 1 {
+		t.Errorf("expected 1 auxiliary unit, got %d", len(army.Auxiliaries))
+	}
+
+	if army.Validation.Drops != 2 {
+		t.Errorf("expected 2 drops, got %d", army.Validation.Drops)
+	}
+}
+
+func TestCreateArmy_RegimentOfRenown(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	heroID := createTestHero(t, s, gameID, factionID)
+	renownID := createTestRegimentOfRenown(t, s, gameID, "Hired Blades", 150)
+	bladeID := createTestUnitWithName(t, s, renownID, "Hired Blade")
+
+	army := createTestArmy(t, s, models.ArmyListRequest{
+		GameID:             gameID,
+		FactionID:          factionID,
+		Name:               "Hired List",
+		PointsLimit:        2000,
+		RegimentOfRenownID: &renownID,
+		Regiments: []models.ArmyRegimentRequest{
+			{
+				Name:      "Regiment 1",
+				IsGeneral: true,
+				Units:     []models.ArmyListUnitRequest{{UnitID: heroID, Quantity: 1}},
+			},
+		},
+		RegimentOfRenownUnits: []models.ArmyListUnitRequest{{UnitID: bladeID, Quantity: 1}},
+	})
+
+	if army.RegimentOfRenownID == nil || *army.RegimentOfRenownID != renownID {
+		t.Errorf("expected regiment of renown %v, got %v", renownID, army.RegimentOfRenownID)
+	}
+
+	if len(army.RegimentOfRenownUnits) != 1 || army.RegimentOfRenownUnits[0].UnitID != bladeID {
+		t.Errorf("expected the regiment of renown unit to be saved, got %+v", army.RegimentOfRenownUnits)
+	}
+
+	if len(army.Regiments) != 1 || len(army.Auxiliaries) != 0 {
+		t.Errorf("expected the regiment of renown unit to stay out of the regiments and auxiliaries, got %+v and %+v", army.Regiments, army.Auxiliaries)
+	}
+
+	if !army.IsValid {
+		t.Errorf("expected army to be valid, got errors: %v", army.Validation.Errors)
+	}
+
+	if army.TotalPoints != 300 {
+		t.Errorf("expected 300 points, got %d", army.TotalPoints)
+	}
+}
+
+func TestCreateArmy_RegimentOfRenownUnitsWithoutRegiment(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	renownID := createTestRegimentOfRenown(t, s, gameID, "Hired Blades", 150)
+	bladeID := createTestUnitWithName(t, s, renownID, "Hired Blade")
+
+	jsonData, err := json.Marshal(models.ArmyListRequest{
+		GameID:                gameID,
+		FactionID:             factionID,
+		Name:                  "Hired List",
+		PointsLimit:           2000,
+		RegimentOfRenownUnits: []models.ArmyListUnitRequest{{UnitID: bladeID, Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal army req: %v", err)
+	}
+
+	handler := &ArmiesHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPost, "/armies", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateArmy(w, req)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status code 400, got %d", res.StatusCode)
+	}
+
+	body, _ := io.ReadAll(res.Body)
+	if !strings.Contains(string(body), "regiment of renown id required") {
+		t.Errorf("expected the error to name the regiment of renown, got %s", body)
+	}
+}
+
+func TestCreateArmy_MissingName(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+
+	jsonData, err := json.Marshal(models.ArmyListRequest{
+		GameID:      gameID,
+		FactionID:   factionID,
+		PointsLimit: 2000,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal army req: %v", err)
+	}
+
+	handler := &ArmiesHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPost, "/armies", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateArmy(w, req)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status code 400, got %d", res.StatusCode)
+	}
+}
+
+func TestImportArmy(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	heroID := createTestHero(t, s, gameID, factionID)
+
+	jsonData, err := json.Marshal(models.ArmyImportRequest{
+		GameID: gameID,
+		Text:   "Imported 150/1000 pts\n\nTest Faction\n\nGeneral's Regiment\nTest Hero (150)\n• General\nNo Such Unit (100)\n",
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal import req: %v", err)
+	}
+
+	handler := &ArmiesHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPost, "/armies/import", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.ImportArmy(w, req)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var imported models.ArmyImportResponse
+	err = json.NewDecoder(res.Body).Decode(&imported)
+	if err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if imported.Army.FactionID != factionID || imported.Army.PointsLimit != 1000 {
+		t.Errorf("expected a 1000 point list for the test faction, got %+v", imported.Army)
+	}
+
+	if len(imported.Army.Regiments) != 1 || len(imported.Army.Regiments[0].Units) != 1 || imported.Army.Regiments[0].Units[0].UnitID != heroID {
+		t.Errorf("expected one regiment led by the hero, got %+v", imported.Army.Regiments)
+	}
+
+	if len(imported.Report) != 1 || imported.Report[0].Status != models.ImportUnmatched {
+		t.Errorf("expected the unknown unit to be reported, got %+v", imported.Report)
+	}
+}
+
+func TestImportArmy_MissingText(t *testing.T) {
+	s := setupTestDB(t)
+
+	handler := &ArmiesHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPost, "/armies/import", strings.NewReader(`{"text": ""}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.ImportArmy(w, req)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status code 400, got %d", res.StatusCode)
+	}
+}
+
+func TestImportArmyRoster(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	heroID := createTestHero(t, s, gameID, factionID)
+
+	roster := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<roster id="r1" name="Roster List" battleScribeVersion="2.03" gameSystemId="gs1" gameSystemName="Test Game" xmlns="http://www.battlescribe.net/schema/rosterSchema">
+  <costLimits><costLimit name="pts" typeId="points" value="1000.0"/></costLimits>
+  <forces>
+    <force id="f1" name="Army" entryId="army1" catalogueId="cat1" catalogueName="Test Faction">
+      <forces>
+        <force id="f2" name="Regiment" entryId="reg1" catalogueId="cat1" catalogueName="Test Faction">
+          <selections>
+            <selection id="s1" name="Test Hero" entryId="hero1" number="1" type="unit">
+              <selections><selection id="s2" name="General" entryId="gen1" number="1" type="upgrade"/></selections>
+            </selection>
+            <selection id="s3" name="No Such Unit" entryId="none1" number="1" type="unit"/>
+          </selections>
+        </force>
+      </forces>
+    </force>
+  </forces>
+</roster>`
+
+	handler := &ArmiesHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPost, "/armies/import/roster?game_id="+gameID.String(), strings.NewReader(roster))
+	w := httptest.NewRecorder()
+
+	handler.ImportArmyRoster(w, req)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var imported models.ArmyImportResponse
+	err := json.NewDecoder(res.Body).Decode(&imported)
+	if err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if imported.Army.FactionID != factionID || imported.Army.Name != "Roster List" || imported.Army.PointsLimit != 1000 {
+		t.Errorf("expected a 1000 point Roster List for the test faction, got %+v", imported.Army)
+	}
+
+	if len(imported.Army.Regiments) != 1 || !imported.Army.Regiments[0].IsGeneral || len(imported.Army.Regiments[0].Units) != 1 || imported.Army.Regiments[0].Units[0].UnitID != heroID {
+		t.Errorf("expected the general's regiment led by the hero, got %+v", imported.Army.Regiments)
+	}
+
+	if len(imported.Report) != 1 || imported.Report[0].Status != models.ImportUnmatched {
+		t.Errorf("expected the unknown unit to be reported, got %+v", imported.Report)
+	}
+}
+
+func TestImportArmyRoster_Invalid(t *testing.T) {
+	s := setupTestDB(t)
+
+	tests := []struct {
+		name         string
+		url          string
+		body         string
+		expectedCode int
+	}{
+		{name: "not a roster", url: "/armies/import/roster", body: "Test Faction 1000 pts", expectedCode: http.StatusBadRequest},
+		{name: "empty body", url: "/armies/import/roster", body: "", expectedCode: http.StatusBadRequest},
+		{name: "invalid faction id", url: "/armies/import/roster?faction_id=abc", body: "<roster/>", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &ArmiesHandlers{S: s}
+			req := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.ImportArmyRoster(w, req)
+			res := w.Result()
+			defer func() { _ = res.Body.Close() }()
+
+			if res.StatusCode != tt.expectedCode {
+				t.Errorf("expected status code %d, got %d", tt.expectedCode, res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestExportArmy(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	heroID := createTestHero(t, s, gameID, factionID)
+
+	army := createTestArmy(t, s, models.ArmyListRequest{
+		GameID:      gameID,
+		FactionID:   factionID,
+		Name:        "Export List",
+		PointsLimit: 1000,
+		Regiments: []models.ArmyRegimentRequest{
+			{Name: "Regiment 1", IsGeneral: true, Units: []models.ArmyListUnitRequest{{UnitID: heroID, Quantity: 1}}},
+		},
+	})
+
+	tests := []struct {
+		format       string
+		expectedCode int
+		expectedType string
+		expectedBody string
+	}{
+		{format: "", expectedCode: http.StatusOK, expectedType: "text/plain", expectedBody: "Export List 150/1000 pts"},
+		{format: "markdown", expectedCode: http.StatusOK, expectedType: "text/markdown", expectedBody: "### Test Hero (150 pts)"},
+		{format: "json", expectedCode: http.StatusOK, expectedType: "application/json", expectedBody: `"name":"Test Hero"`},
+		{format: "ros", expectedCode: http.StatusOK, expectedType: "application/xml", expectedBody: `name="Test Hero"`},
+		{format: "pdf", expectedCode: http.StatusBadRequest, expectedType: "application/json", expectedBody: "format must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			handler := &ArmiesHandlers{S: s}
+			req := httptest.NewRequest(http.MethodGet, "/armies/"+army.ID.String()+"/export?format="+tt.format, nil)
+			req.SetPathValue("id", army.ID.String())
+			w := httptest.NewRecorder()
+
+			handler.ExportArmy(w, req)
+			res := w.Result()
+			defer func() { _ = res.Body.Close() }()
+
+			if res.StatusCode != tt.expectedCode {
+				t.Fatalf("expected status code %d, got %d", tt.expectedCode, res.StatusCode)
+			}
+
+			if contentType := res.Header.Get("Content-Type"); !strings.HasPrefix(contentType, tt.expectedType) {
+				t.Errorf("expected content type %s, got %s", tt.expectedType, contentType)
+			}
+
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				t.Fatalf("failed to read response body: %v", err)
+			}
+			if !strings.Contains(string(body), tt.expectedBody) {
+				t.Errorf("expected body to contain %q, got:\n%s", tt.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestExportArmy_NotFound(t *testing.T) {
+	s := setupTestDB(t)
+
+	handler := &ArmiesHandlers{S: s}
+	id := uuid.New().String()
+	req := httptest.NewRequest(http.MethodGet, "/armies/"+id+"/export", nil)
+	req.SetPathValue("id", id)
+	w := httptest.NewRecorder()
+
+	handler.ExportArmy(w, req)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status code 404, got %d", res.StatusCode)
+	}
+}
+
+func TestUpdateArmy_RecomputesValidation(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+
+	armyReq := models.ArmyListRequest{
+		GameID:      gameID,
+		FactionID:   factionID,
+		Name:        "Test List",
+		PointsLimit: 2000,
+		Regiments: []models.ArmyRegimentRequest{
+			{Units: []models.ArmyListUnitRequest{{UnitID: unitID, Quantity: 4}}},
+		},
+	}
+	army := createTestArmy(t, s, armyReq)
+
+	armyReq.PointsLimit = 300
+	jsonData, err := json.Marshal(armyReq)
+	if err != nil {
+		t.Fatalf("failed to marshal army req: %v", err)
+	}
+
+	handler := &ArmiesHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPut, "/armies/"+army.ID.String(), bytes.NewBuffer(jsonData))
+	req.SetPathValue("id", army.ID.String())
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.UpdateArmy(w, req)
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var updated models.ArmyList
+	err = json.NewDecoder(res.Body).Decode(&updated)
+	if err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+
+	if updated.ID != army.ID {
+		t.Errorf("expected army id %s to be kept, got %s", army.ID, updated.ID)
+	}
+
+	if updated.IsValid {
+		t.Errorf("expected army to be invalid after lowering the points limit")
+	}
+
+	if !strings.Contains(strings.Join(updated.Validation.Errors, " "), "exceeds point limit") {
+		t.Errorf("expected points limit error, got %v", updated.Validation.Errors)
+	}
+}
+
+func TestDeleteArmy(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	army := createTestArmy(t, s, models.ArmyListRequest{
+		GameID:      gameID,
+		FactionID:   factionID,
+		Name:        "Test List",
+		PointsLimit: 2000,
+	})
+
+	handler := &ArmiesHandlers{S: s}
+
+	req := httptest.NewRequest(http.MethodDelete, "/armies/"+army.ID.String(), nil)
+	req.SetPathValue("id", army.ID.String())
+	w := httptest.NewRecorder()
+	handler.DeleteArmy(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status code 204, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/armies/"+army.ID.String(), nil)
+	req.SetPathValue("id", army.ID.String())
+	w = httptest.NewRecorder()
+	handler.GetArmyByID(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status code 404 after delete, got %d", w.Code)
+	}
+}
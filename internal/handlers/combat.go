@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/combat"
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/services"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+type CombatHandlers struct {
+	S *state.State
+}
+
+func (h *CombatHandlers) CalculateDamage(w http.ResponseWriter, r *http.Request) {
+	var req models.DamageRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	resp, err := services.CalculateDamage(h.S, r.Context(), req)
+	if err != nil {
+		respondWithCombatError(w, err, "failed to calculate damage")
+		logRequestError(h.S, r, "failed to calculate damage", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Calculated damage",
+		zap.String("attacker", resp.Attacker),
+		zap.Float64("expected_damage", resp.ExpectedDamage),
+	)
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *CombatHandlers) SimulateCombat(w http.ResponseWriter, r *http.Request) {
+	var req models.CombatSimulationRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	resp, err := services.SimulateCombat(h.S, r.Context(), req)
+	if err != nil {
+		respondWithCombatError(w, err, "failed to simulate combat")
+		logRequestError(h.S, r, "failed to simulate combat", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Simulated combat",
+		zap.String("attacker", resp.Attacker.Name),
+		zap.String("defender", resp.Defender.Name),
+		zap.Int("iterations", resp.Iterations),
+		zap.Int64("seed", resp.Seed),
+	)
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+func respondWithCombatError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, appErr.ErrMissingID):
+		respondWithError(w, http.StatusBadRequest, "unit id required", err)
+	case errors.Is(err, appErr.ErrNotFound):
+		respondWithError(w, http.StatusNotFound, "unit not found", err)
+	case errors.Is(err, appErr.ErrInvalidReference):
+		respondWithError(w, http.StatusBadRequest, "target, army entry, weapon or effect source not found", err)
+	case errors.Is(err, appErr.ErrInvalidStat):
+		respondWithError(w, http.StatusBadRequest, "save and ward must look like 4+, crit must be none, mortal, 2_hits or auto_wound", err)
+	case errors.Is(err, appErr.ErrInvalidModelCount):
+		respondWithError(w, http.StatusBadRequest, "model count out of range", err)
+	case errors.Is(err, appErr.ErrInvalidIterations):
+		respondWithError(w, http.StatusBadRequest, "iterations out of range", err)
+	case errors.Is(err, combat.ErrUnsupported):
+		respondWithError(w, http.StatusUnprocessableEntity, "defender health cannot be simulated", err)
+	default:
+		respondWithError(w, http.StatusInternalServerError, msg, err)
+	}
+}
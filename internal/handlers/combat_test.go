@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/database"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+func postDamage(t *testing.T, s *state.State, body models.DamageRequest) *http.Response {
+	t.Helper()
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal damage req: %v", err)
+	}
+
+	handler := &CombatHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPost, "/calculate/damage", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CalculateDamage(w, req)
+	return w.Result()
+}
+
+func TestCalculateDamage(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+	weaponID := createTestWeapon(t, s, unitID)
+
+	res := postDamage(t, s, models.DamageRequest{AttackerID: unitID, TargetID: unitID})
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var resp models.DamageResponse
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	if err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// 4 models with 5 attacks each hitting and wounding on 3+, against a
+	// 3+ save worsened to 4+ by rend and a 6+ ward on each of 2 damage.
+	expected := 20 * (4.0 / 6) * (4.0 / 6) * 0.5 * 2 * (5.0 / 6)
+	if resp.ModelCount != 4 || math.Abs(resp.ExpectedDamage-expected) > 1e-9 {
+		t.Errorf("expected %v damage from 4 models, got %v from %d", expected, resp.ExpectedDamage, resp.ModelCount)
+	}
+
+	if len(resp.Weapons) != 1 || resp.Weapons[0].WeaponID != weaponID || resp.Weapons[0].Attacks != 20 {
+		t.Errorf("expected the test weapon to make 20 attacks, got %+v", resp.Weapons)
+	}
+
+	if resp.Target.Save != "3+" || resp.Target.Ward != "6+" {
+		t.Errorf("expected the target's save and ward, got %+v", resp.Target)
+	}
+
+	if len(resp.Distribution) == 0 || resp.Distribution[len(resp.Distribution)-1].Value != 40 {
+		t.Errorf("expected a distribution up to 40 damage, got %+v", resp.Distribution)
+	}
+}
+
+func TestCalculateDamage_RawTarget(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+	createTestWeapon(t, s, unitID)
+
+	res := postDamage(t, s, models.DamageRequest{AttackerID: unitID, ModelCount: 1, Save: "6+", Crit: "mortal"})
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var resp models.DamageResponse
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	if err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// 5 attacks crit on a 6 for 2 mortal damage each.
+	if math.Abs(resp.ExpectedMortalDamage-5.0/6*2) > 1e-9 || resp.Weapons[0].Crit != "mortal" {
+		t.Errorf("expected mortal damage from crits, got %+v", resp)
+	}
+}
+
+func TestCalculateDamage_Effects(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+	createTestWeapon(t, s, unitID)
+	abilityID := createTestAbilityUnit(t, s, unitID)
+	createTestEffect(t, s, database.CreateAbilityEffectParams{AbilityID: database.UUIDToNullUUID(abilityID), Stat: "rend", Modifier: 1})
+
+	res := postDamage(t, s, models.DamageRequest{
+		AttackerID: unitID,
+		ModelCount: 1,
+		Save:       "4+",
+		Effects:    &models.EffectSelection{AbilityIDs: []uuid.UUID{abilityID}},
+	})
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var resp models.DamageResponse
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	if err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// 5 attacks hitting and wounding on 3+ against a 4+ save worsened to 6+
+	// by rend 2, then 2 damage each.
+	expected := 5 * (4.0 / 6) * (4.0 / 6) * (5.0 / 6) * 2
+	if math.Abs(resp.ExpectedDamage-expected) > 1e-9 {
+		t.Errorf("expected %v damage with the extra rend, got %v", expected, resp.ExpectedDamage)
+	}
+
+	if len(resp.Effects) != 1 || !resp.Effects[0].Applied || resp.Effects[0].To != "-2" {
+		t.Errorf("expected the rend effect to be traced, got %+v", resp.Effects)
+	}
+}
+
+func TestCalculateDamage_Invalid(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+	createTestWeapon(t, s, unitID)
+
+	tests := []struct {
+		name     string
+		req      models.DamageRequest
+		expected int
+	}{
+		{name: "missing attacker", req: models.DamageRequest{}, expected: http.StatusBadRequest},
+		{name: "unknown attacker", req: models.DamageRequest{AttackerID: uuid.New()}, expected: http.StatusNotFound},
+		{name: "unknown target", req: models.DamageRequest{AttackerID: unitID, TargetID: uuid.New()}, expected: http.StatusBadRequest},
+		{name: "foreign weapon", req: models.DamageRequest{AttackerID: unitID, WeaponIDs: []uuid.UUID{uuid.New()}}, expected: http.StatusBadRequest},
+		{name: "bad save", req: models.DamageRequest{AttackerID: unitID, Save: "four"}, expected: http.StatusBadRequest},
+		{name: "bad crit", req: models.DamageRequest{AttackerID: unitID, Crit: "exploding"}, expected: http.StatusBadRequest},
+		{name: "too many models", req: models.DamageRequest{AttackerID: unitID, ModelCount: 1000}, expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := postDamage(t, s, tt.req)
+			defer func() { _ = res.Body.Close() }()
+
+			if res.StatusCode != tt.expected {
+				t.Errorf("expected status code %d, got %d", tt.expected, res.StatusCode)
+			}
+		})
+	}
+}
+
+func postSimulation(t *testing.T, s *state.State, body models.CombatSimulationRequest) *http.Response {
+	t.Helper()
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal simulation req: %v", err)
+	}
+
+	handler := &CombatHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPost, "/simulate/combat", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.SimulateCombat(w, req)
+	return w.Result()
+}
+
+func decodeSimulation(t *testing.T, res *http.Response) models.CombatSimulationResponse {
+	t.Helper()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var resp models.CombatSimulationResponse
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	if err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestSimulateCombat(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+	createTestWeapon(t, s, unitID)
+
+	seed := int64(42)
+	req := models.CombatSimulationRequest{
+		Attacker:   models.SimulationUnit{UnitID: unitID},
+		Defender:   models.SimulationUnit{UnitID: unitID},
+		Iterations: 2000,
+		Seed:       &seed,
+	}
+
+	resp := decodeSimulation(t, postSimulation(t, s, req))
+	again := decodeSimulation(t, postSimulation(t, s, req))
+
+	if resp.Seed != 42 || resp.Iterations != 2000 || resp.Defender.ModelCount != 4 || resp.Defender.Health != "4" {
+		t.Errorf("unexpected simulation setup %+v", resp)
+	}
+
+	if resp.KillProbability != again.KillProbability || resp.MeanDamage != again.MeanDamage || len(resp.Damage) != len(again.Damage) {
+		t.Errorf("expected the same seed to give the same results, got %+v and %+v", resp, again)
+	}
+
+	if resp.ModelsSlainPercentiles.P10 > resp.ModelsSlainPercentiles.P50 || resp.ModelsSlainPercentiles.P50 > resp.ModelsSlainPercentiles.P90 {
+		t.Errorf("expected increasing percentiles, got %+v", resp.ModelsSlainPercentiles)
+	}
+
+	// 20 attacks averaging 7.4 damage against 16 health.
+	if resp.KillProbability > 0.05 || math.Abs(resp.MeanDamage-20*(4.0/6)*(4.0/6)*0.5*2*(5.0/6)) > 0.5 {
+		t.Errorf("unexpected simulated damage %v and kill probability %v", resp.MeanDamage, resp.KillProbability)
+	}
+}
+
+func TestSimulateCombat_ArmyEntry(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	heroID := createTestHero(t, s, gameID, factionID)
+	unitID := createTestUnit(t, s, factionID)
+	createTestWeapon(t, s, unitID)
+
+	army := createTestArmy(t, s, models.ArmyListRequest{
+		GameID:      gameID,
+		FactionID:   factionID,
+		Name:        "Simulated List",
+		PointsLimit: 2000,
+		Regiments: []models.ArmyRegimentRequest{
+			{
+				Name:      "Regiment 1",
+				IsGeneral: true,
+				Units: []models.ArmyListUnitRequest{
+					{UnitID: heroID, Quantity: 1},
+					{UnitID: unitID, Quantity: 1, Reinforced: true},
+				},
+			},
+		},
+	})
+
+	resp := decodeSimulation(t, postSimulation(t, s, models.CombatSimulationRequest{
+		Attacker:   models.SimulationUnit{ArmyID: army.ID, ArmyUnitID: army.Regiments[0].Units[1].ID},
+		Defender:   models.SimulationUnit{UnitID: unitID, ModelCount: 1},
+		Iterations: 100,
+	}))
+
+	if resp.Attacker.UnitID != unitID || resp.Attacker.ModelCount != 8 || resp.Defender.ModelCount != 1 {
+		t.Errorf("expected 8 reinforced attackers against 1 model, got %+v and %+v", resp.Attacker, resp.Defender)
+	}
+}
+
+func TestSimulateCombat_Invalid(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+	unit := models.SimulationUnit{UnitID: unitID}
+
+	tests := []struct {
+		name     string
+		req      models.CombatSimulationRequest
+		expected int
+	}{
+		{name: "missing defender", req: models.CombatSimulationRequest{Attacker: unit}, expected: http.StatusBadRequest},
+		{name: "unknown attacker", req: models.CombatSimulationRequest{Attacker: models.SimulationUnit{UnitID: uuid.New()}, Defender: unit}, expected: http.StatusNotFound},
+		{name: "unknown army entry", req: models.CombatSimulationRequest{Attacker: models.SimulationUnit{ArmyID: uuid.New(), ArmyUnitID: uuid.New()}, Defender: unit}, expected: http.StatusBadRequest},
+		{name: "too many iterations", req: models.CombatSimulationRequest{Attacker: unit, Defender: unit, Iterations: 1000000}, expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := postSimulation(t, s, tt.req)
+			defer func() { _ = res.Body.Close() }()
+
+			if res.StatusCode != tt.expected {
+				t.Errorf("expected status code %d, got %d", tt.expected, res.StatusCode)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -52,30 +53,32 @@ func TestGetEnhancements_FilterByFactionID(t *testing.T) {
 	handler := &EnhancementsHandlers{S: s}
 
 	_, err := s.DB.CreateEnhancement(ctx, database.CreateEnhancementParams{
-		FactionID:       factionID1,
-		Name:            "Test StormCast Enhancement",
-		EnhancementType: "artefact",
-		Description:     "Test Description",
-		Restrictions:    "Test Restrictions",
-		Points:          10,
-		IsUnique:        true,
-		Version:         "1.0",
-		Source:          "Test Source",
+		FactionID:        factionID1,
+		Name:             "Test StormCast Enhancement",
+		EnhancementType:  "artefact",
+		Description:      "Test Description",
+		Restrictions:     "Test Restrictions",
+		RequiredKeywords: json.RawMessage("[]"),
+		Points:           10,
+		IsUnique:         true,
+		Version:          "1.0",
+		Source:           "Test Source",
 	})
 	if err != nil {
 		t.Fatalf("failed to create stormcast enhancement, %v", err)
 	}
 
 	_, err = s.DB.CreateEnhancement(ctx, database.CreateEnhancementParams{
-		FactionID:       factionID2,
-		Name:            "Test Skaven Enhancement",
-		EnhancementType: "artefact",
-		Description:     "Test Description",
-		Restrictions:    "Test Restrictions",
-		Points:          10,
-		IsUnique:        true,
-		Version:         "1.0",
-		Source:          "Test Source",
+		FactionID:        factionID2,
+		Name:             "Test Skaven Enhancement",
+		EnhancementType:  "artefact",
+		Description:      "Test Description",
+		Restrictions:     "Test Restrictions",
+		RequiredKeywords: json.RawMessage("[]"),
+		Points:           10,
+		IsUnique:         true,
+		Version:          "1.0",
+		Source:           "Test Source",
 	})
 	if err != nil {
 		t.Fatalf("failed to create skaven enhancement, %v", err)
@@ -131,3 +131,29 @@ func (h *FactionsHandlers) GetFactionByID(w http.ResponseWriter, r *http.Request
 	logRequestInfo(h.S, r, "Successfully fetched faction")
 	respondWithJSON(w, http.StatusOK, faction)
 }
+
+func (h *FactionsHandlers) GetFactionRoster(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	factionID, err := uuid.Parse(id)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid faction id", err)
+		return
+	}
+
+	units, err := services.GetFactionRoster(h.S, r.Context(), factionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErr.ErrNotFound):
+			respondWithError(w, http.StatusNotFound, "faction not found", err)
+		default:
+			respondWithError(w, http.StatusInternalServerError, "failed to fetch faction roster", err)
+		}
+
+		logRequestError(h.S, r, "failed to fetch faction roster", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully fetched faction roster", zap.Int("count", len(units)))
+	respondWithJSON(w, http.StatusOK, units)
+}
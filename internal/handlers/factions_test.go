@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
 )
 
 func TestListFactions_ReturnsFaction(t *testing.T) {
@@ -166,3 +169,63 @@ func TestGetFactionsByName(t *testing.T) {
 		t.Errorf("expected body to contain 'Test Faction' got %s", bodyStr)
 	}
 }
+
+func TestGetFactionRoster_ArmyOfRenown(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	parentID := createTestFaction(t, s, gameID)
+	renownID := createTestArmyOfRenown(t, s, gameID, parentID, "Renowned Host")
+
+	createTestUnitWithName(t, s, parentID, "Allowed Unit")
+	createTestUnitWithName(t, s, parentID, "Forbidden Unit")
+	createTestUnitWithName(t, s, renownID, "Renowned Unit")
+	createTestAllowedUnit(t, s, renownID, "Allowed Unit")
+
+	handler := &FactionsHandlers{S: s}
+
+	req := httptest.NewRequest(http.MethodGet, "/factions/"+renownID.String()+"/roster", nil)
+	req.SetPathValue("id", renownID.String())
+	w := httptest.NewRecorder()
+
+	handler.GetFactionRoster(w, req)
+
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", res.StatusCode)
+	}
+
+	var units []models.Unit
+	err := json.NewDecoder(res.Body).Decode(&units)
+	if err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+
+	names := make([]string, len(units))
+	for i, u := range units {
+		names[i] = u.Name
+	}
+
+	if strings.Join(names, ",") != "Allowed Unit,Renowned Unit" {
+		t.Errorf("expected the allowed parent unit and the army's own unit, got %v", names)
+	}
+}
+
+func TestGetFactionRoster_NotFound(t *testing.T) {
+	s := setupTestDB(t)
+
+	handler := &FactionsHandlers{S: s}
+
+	id := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/factions/"+id.String()+"/roster", nil)
+	req.SetPathValue("id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.GetFactionRoster(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
@@ -42,3 +42,13 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 		return
 	}
 }
+
+func respondWithText(w http.ResponseWriter, code int, contentType, body string) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+
+	_, err := w.Write([]byte(body))
+	if err != nil {
+		log.Printf("Error writing response: %s", err)
+	}
+}
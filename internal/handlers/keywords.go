@@ -17,104 +17,7 @@ type KeywordsHandlers struct {
 }
 
 func (h *KeywordsHandlers) GetKeywords(w http.ResponseWriter, r *http.Request) {
-	gameID := r.URL.Query().Get("game_id")
-	unitID := r.URL.Query().Get("unit_id")
-
-	if gameID != "" {
-		h.getKeywordsForGame(w, r)
-		return
-	}
-
-	if unitID != "" {
-		h.getKeywordsForUnit(w, r)
-		return
-	}
-
-	h.getAllKeywords(w, r)
-}
-
-func (h *KeywordsHandlers) getAllKeywords(w http.ResponseWriter, r *http.Request) {
-	keywords, err := services.GetAllKeywords(h.S, r.Context())
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "keywords not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch keywords", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch keywords", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched keywords", zap.Int("count", len(keywords)))
-	respondWithJSON(w, http.StatusOK, keywords)
-}
-
-func (h *KeywordsHandlers) getKeywordsForGame(w http.ResponseWriter, r *http.Request) {
-	gameStr := r.URL.Query().Get("game_id")
-
-	if gameStr == "" {
-		respondWithError(w, http.StatusBadRequest, "missing game id", nil)
-		return
-	}
-
-	gameID, err := uuid.Parse(gameStr)
-	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "invalid game id", err)
-		return
-	}
-
-	keywords, err := services.GetKeywordsForGame(h.S, r.Context(), gameID)
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrMissingID):
-			respondWithError(w, http.StatusBadRequest, "missing game id", err)
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "keywords not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch keywords", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch keywords", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched keywords", zap.Int("count", len(keywords)))
-	respondWithJSON(w, http.StatusOK, keywords)
-}
-
-func (h *KeywordsHandlers) getKeywordsForUnit(w http.ResponseWriter, r *http.Request) {
-	unitStr := r.URL.Query().Get("unit_id")
-
-	if unitStr == "" {
-		respondWithError(w, http.StatusBadRequest, "missing unit id", nil)
-		return
-	}
-
-	unitID, err := uuid.Parse(unitStr)
-	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "invalid unit id", err)
-		return
-	}
-
-	keywords, err := services.GetKeywordsForUnit(h.S, r.Context(), unitID)
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrMissingID):
-			respondWithError(w, http.StatusBadRequest, "missing unit id", err)
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "keywords not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch keywords", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch keywords", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched keywords", zap.Int("count", len(keywords)))
-	respondWithJSON(w, http.StatusOK, keywords)
+	serveList(h.S, w, r, "keywords", services.ListKeywords, "game_id", "unit_id")
 }
 
 func (h *KeywordsHandlers) GetUnitsWithKeyword(w http.ResponseWriter, r *http.Request) {
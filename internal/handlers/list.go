@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/keywordexpr"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+// listFilter reads one query parameter into the filters of a list.
+type listFilter func(value string, f *models.ListFilters) error
+
+// listFilters are the filters list endpoints can take, by query parameter.
+var listFilters = map[string]listFilter{
+	"game_id":          uuidFilter(func(f *models.ListFilters) **uuid.UUID { return &f.GameID }),
+	"faction_id":       uuidFilter(func(f *models.ListFilters) **uuid.UUID { return &f.FactionID }),
+	"unit_id":          uuidFilter(func(f *models.ListFilters) **uuid.UUID { return &f.UnitID }),
+	"points_min":       intFilter(func(f *models.ListFilters) **int { return &f.PointsMin }),
+	"points_max":       intFilter(func(f *models.ListFilters) **int { return &f.PointsMax }),
+	"is_unique":        boolFilter(func(f *models.ListFilters) **bool { return &f.IsUnique }),
+	"matched_play":     boolFilter(func(f *models.ListFilters) **bool { return &f.MatchedPlay }),
+	"is_manifestation": boolFilter(func(f *models.ListFilters) **bool { return &f.IsManifestation }),
+	"allegiance":       textFilter(func(f *models.ListFilters) **string { return &f.Allegiance }),
+	"type":             textFilter(func(f *models.ListFilters) **string { return &f.Type }),
+	"phase":            textFilter(func(f *models.ListFilters) **string { return &f.Phase }),
+	"keywords":         keywordsFilter,
+}
+
+func uuidFilter(field func(*models.ListFilters) **uuid.UUID) listFilter {
+	return func(value string, f *models.ListFilters) error {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return err
+		}
+		*field(f) = &id
+		return nil
+	}
+}
+
+func intFilter(field func(*models.ListFilters) **int) listFilter {
+	return func(value string, f *models.ListFilters) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(f) = &n
+		return nil
+	}
+}
+
+func boolFilter(field func(*models.ListFilters) **bool) listFilter {
+	return func(value string, f *models.ListFilters) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(f) = &b
+		return nil
+	}
+}
+
+func textFilter(field func(*models.ListFilters) **string) listFilter {
+	return func(value string, f *models.ListFilters) error {
+		*field(f) = &value
+		return nil
+	}
+}
+
+// keywordsFilter parses a keyword expression; its errors point at the bad
+// token.
+func keywordsFilter(value string, f *models.ListFilters) error {
+	e, err := keywordexpr.Parse(value)
+	if err != nil {
+		return err
+	}
+	f.Keywords = e
+	return nil
+}
+
+// parseListParams reads limit, cursor, sort and the named filters from the
+// query string. Parameters an endpoint does not name are ignored.
+func parseListParams(r *http.Request, filters ...string) (models.ListParams, error) {
+	q := r.URL.Query()
+	p := models.ListParams{Cursor: q.Get("cursor"), Sort: q.Get("sort")}
+
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return models.ListParams{}, fmt.Errorf("%w: %q", appErr.ErrInvalidLimit, limit)
+		}
+		p.Limit = n
+	}
+
+	for _, name := range filters {
+		value := q.Get(name)
+		if value == "" {
+			continue
+		}
+		err := listFilters[name](value, &p.Filters)
+		if err != nil {
+			return models.ListParams{}, fmt.Errorf("invalid %s %q: %w", name, value, err)
+		}
+	}
+
+	return p, nil
+}
+
+// serveList answers a list endpoint: it reads the list params and the
+// named filters, runs list and writes the page.
+func serveList[T any](s *state.State, w http.ResponseWriter, r *http.Request, what string, list func(*state.State, context.Context, models.ListParams) (models.Page[T], error), filters ...string) {
+	params, err := parseListParams(r, filters...)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	page, err := list(s, r.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErr.ErrInvalidLimit):
+			respondWithError(w, http.StatusBadRequest, "invalid limit", err)
+		case errors.Is(err, appErr.ErrInvalidSort):
+			respondWithError(w, http.StatusBadRequest, "invalid sort", err)
+		case errors.Is(err, appErr.ErrInvalidCursor):
+			respondWithError(w, http.StatusBadRequest, "invalid cursor", err)
+		case errors.Is(err, appErr.ErrMissingGameID):
+			respondWithError(w, http.StatusBadRequest, "game_id or faction_id required", err)
+		default:
+			respondWithError(w, http.StatusInternalServerError, "failed to fetch "+what, err)
+		}
+
+		logRequestError(s, r, "failed to fetch "+what, err)
+		return
+	}
+
+	logRequestInfo(s, r, "Successfully fetched "+what,
+		zap.Int("count", len(page.Items)),
+		zap.Bool("more", page.NextCursor != ""),
+	)
+	respondWithJSON(w, http.StatusOK, page)
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/database"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+func TestParseListParams(t *testing.T) {
+	factionID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/units?limit=5&sort=-points&points_min=100&is_unique=true&allegiance=Order&faction_id="+factionID.String()+"&phase=Hero", nil)
+
+	p, err := parseListParams(req, "faction_id", "points_min", "is_unique", "allegiance")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.Limit != 5 || p.Sort != "-points" {
+		t.Errorf("expected limit 5 sorted by -points, got %+v", p)
+	}
+	f := p.Filters
+	if f.FactionID == nil || *f.FactionID != factionID || f.PointsMin == nil || *f.PointsMin != 100 || f.IsUnique == nil || !*f.IsUnique || f.Allegiance == nil || *f.Allegiance != "Order" {
+		t.Errorf("unexpected filters %+v", f)
+	}
+	if f.Phase != nil || f.PointsMax != nil {
+		t.Errorf("expected filters the endpoint does not take to be ignored, got %+v", f)
+	}
+
+	for _, query := range []string{"limit=ten", "points_min=many", "is_unique=maybe", "faction_id=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/units?"+query, nil)
+		_, err := parseListParams(req, "faction_id", "points_min", "is_unique")
+		if err == nil {
+			t.Errorf("expected %q to be rejected", query)
+		}
+	}
+}
+
+func getUnitsPage(t *testing.T, handler *UnitsHandlers, query url.Values) models.Page[models.Unit] {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/units?"+query.Encode(), nil)
+	w := httptest.NewRecorder()
+	handler.GetUnits(w, req)
+
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var page models.Page[models.Unit]
+	err := json.NewDecoder(res.Body).Decode(&page)
+	if err != nil {
+		t.Fatalf("failed to decode page: %v", err)
+	}
+	return page
+}
+
+func TestGetUnits_Pagination(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	for _, name := range []string{"Vindictors", "Liberators", "Prosecutors", "Gryph-hounds", "Annihilators"} {
+		createTestUnitWithName(t, s, factionID, name)
+	}
+	createTestManifestation(t, s, factionID)
+
+	handler := &UnitsHandlers{S: s}
+	query := url.Values{"limit": {"2"}, "faction_id": {factionID.String()}}
+
+	var names []string
+	for range 5 {
+		page := getUnitsPage(t, handler, query)
+		for _, u := range page.Items {
+			names = append(names, u.Name)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", page.NextCursor)
+	}
+
+	expected := []string{"Annihilators", "Gryph-hounds", "Liberators", "Prosecutors", "Vindictors"}
+	if len(names) != len(expected) {
+		t.Fatalf("expected %v over three pages, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, names)
+			break
+		}
+	}
+
+	desc := getUnitsPage(t, handler, url.Values{"sort": {"-name"}, "limit": {"1"}})
+	if len(desc.Items) != 1 || desc.Items[0].Name != "Vindictors" || desc.NextCursor == "" {
+		t.Errorf("expected Vindictors first in descending order, got %+v", desc)
+	}
+
+	manifestations := getUnitsPage(t, handler, url.Values{"is_manifestation": {"true"}})
+	if len(manifestations.Items) != 1 || manifestations.Items[0].Name != "Test Manifestation" {
+		t.Errorf("expected only the manifestation, got %+v", manifestations.Items)
+	}
+
+	none := getUnitsPage(t, handler, url.Values{"points_min": {"101"}})
+	if len(none.Items) != 0 {
+		t.Errorf("expected no unit above 100 points, got %+v", none.Items)
+	}
+}
+
+func TestGetUnits_InvalidListParams(t *testing.T) {
+	s := setupTestDB(t)
+	handler := &UnitsHandlers{S: s}
+
+	for _, query := range []string{"sort=health", "limit=100000", "cursor=garbage", "matched_play=sometimes"} {
+		req := httptest.NewRequest(http.MethodGet, "/units?"+query, nil)
+		w := httptest.NewRecorder()
+		handler.GetUnits(w, req)
+
+		res := w.Result()
+		_ = res.Body.Close()
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status code 400 for %q, got %d", query, res.StatusCode)
+		}
+	}
+}
+
+func TestGetUnits_Keywords(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	hero := createTestKeywordWithName(t, s, gameID, "HERO")
+	wizard := createTestKeywordWithName(t, s, gameID, "WIZARD")
+	priest := createTestKeywordWithName(t, s, gameID, "PRIEST")
+	unique := createTestKeywordWithName(t, s, gameID, "UNIQUE")
+	stormcast := createTestKeywordWithName(t, s, gameID, "Stormcast Eternals")
+
+	for name, keywords := range map[string][]uuid.UUID{
+		"Knight-Incantor":      {hero, wizard, stormcast},
+		"Lord-Relictor":        {hero, priest, stormcast},
+		"Yndrasta":             {hero, wizard, unique, stormcast},
+		"Lord-Vigilant":        {hero, stormcast},
+		"Vindictors":           {stormcast},
+		"Knight-Arcanum (Old)": {wizard},
+	} {
+		unitID := createTestUnitWithName(t, s, factionID, name)
+		for _, keywordID := range keywords {
+			err := s.DB.AddKeywordToUnit(ctx, database.AddKeywordToUnitParams{
+				UnitID:    unitID,
+				KeywordID: keywordID,
+			})
+			if err != nil {
+				t.Fatalf("failed to add keyword: %v", err)
+			}
+		}
+	}
+
+	handler := &UnitsHandlers{S: s}
+
+	tests := []struct {
+		keywords string
+		expected []string
+	}{
+		{"HERO AND (WIZARD OR PRIEST) AND NOT UNIQUE", []string{"Knight-Incantor", "Lord-Relictor"}},
+		{"hero and not (wizard or priest)", []string{"Lord-Vigilant"}},
+		{"WIZARD AND NOT STORMCAST ETERNALS", []string{"Knight-Arcanum (Old)"}},
+		{"NOT HERO", []string{"Knight-Arcanum (Old)", "Vindictors"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keywords, func(t *testing.T) {
+			page := getUnitsPage(t, handler, url.Values{"keywords": {tt.keywords}, "game_id": {gameID.String()}})
+
+			var names []string
+			for _, u := range page.Items {
+				names = append(names, u.Name)
+			}
+			if strings.Join(names, ", ") != strings.Join(tt.expected, ", ") {
+				t.Errorf("expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestGetUnits_InvalidKeywords(t *testing.T) {
+	s := setupTestDB(t)
+	handler := &UnitsHandlers{S: s}
+	factionID := uuid.New()
+
+	tests := []struct {
+		query    url.Values
+		expected string
+	}{
+		{url.Values{"keywords": {"HERO AND OR WIZARD"}, "faction_id": {factionID.String()}}, `unexpected \"OR\", expected a keyword, NOT or \"(\" at position 10`},
+		{url.Values{"keywords": {"(HERO OR WIZARD"}, "faction_id": {factionID.String()}}, "at position 16"},
+		{url.Values{"keywords": {"HERO"}}, "game_id or faction_id required"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/units?"+tt.query.Encode(), nil)
+		w := httptest.NewRecorder()
+		handler.GetUnits(w, req)
+
+		res := w.Result()
+		body := w.Body.String()
+		_ = res.Body.Close()
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status code 400 for %v, got %d", tt.query, res.StatusCode)
+		}
+		if !strings.Contains(body, tt.expected) {
+			t.Errorf("expected error containing %s, got %s", tt.expected, body)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/services"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+type SearchHandlers struct {
+	S *state.State
+}
+
+func (h *SearchHandlers) Search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	params := models.SearchParams{Query: q.Get("q")}
+
+	if types := q.Get("type"); types != "" {
+		params.Types = strings.Split(types, ",")
+	}
+
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+		params.Limit = n
+	}
+
+	if gameStr := q.Get("game_id"); gameStr != "" {
+		gameID, err := uuid.Parse(gameStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid game id", err)
+			return
+		}
+		params.GameID = &gameID
+	}
+
+	if factionStr := q.Get("faction_id"); factionStr != "" {
+		factionID, err := uuid.Parse(factionStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid faction id", err)
+			return
+		}
+		params.FactionID = &factionID
+	}
+
+	results, err := services.Search(h.S, r.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, appErr.ErrMissingQuery):
+			respondWithError(w, http.StatusBadRequest, "missing search query", err)
+		case errors.Is(err, appErr.ErrInvalidLimit):
+			respondWithError(w, http.StatusBadRequest, "invalid limit", err)
+		case errors.Is(err, appErr.ErrInvalidSearchType):
+			respondWithError(w, http.StatusBadRequest, "invalid search type", err)
+		default:
+			respondWithError(w, http.StatusInternalServerError, "failed to search", err)
+		}
+
+		logRequestError(h.S, r, "failed to search", err)
+		return
+	}
+
+	logRequestInfo(h.S, r, "Successfully searched",
+		zap.String("query", results.Query),
+		zap.Int("hits", len(results.Hits)),
+	)
+	respondWithJSON(w, http.StatusOK, results)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/database"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+func search(t *testing.T, handler *SearchHandlers, query url.Values) models.SearchResults {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/search?"+query.Encode(), nil)
+	w := httptest.NewRecorder()
+	handler.Search(w, req)
+
+	res := w.Result()
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var results models.SearchResults
+	err := json.NewDecoder(res.Body).Decode(&results)
+	if err != nil {
+		t.Fatalf("failed to decode search results: %v", err)
+	}
+	return results
+}
+
+func TestSearch(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+	createTestRule(t, s, gameID)
+	createTestEnhancement(t, s, factionID)
+
+	ability, err := s.DB.CreateAbility(context.Background(), database.CreateAbilityParams{
+		UnitID:      database.UUIDToNullUUID(unitID),
+		Name:        "Lightning Strike",
+		Description: "Roll a dice for each enemy unit within 3\". On a 4+, inflict D3 mortal wounds on that unit.",
+		Type:        "Passive",
+		Phase:       "Combat",
+	})
+	if err != nil {
+		t.Fatalf("failed to create ability: %v", err)
+	}
+
+	handler := &SearchHandlers{S: s}
+
+	results := search(t, handler, url.Values{"q": {"mortal wounds"}})
+	if len(results.Hits) != 1 {
+		t.Fatalf("expected 1 hit, got %+v", results.Hits)
+	}
+	hit := results.Hits[0]
+	if hit.Type != models.SearchTypeAbility || hit.ID != ability.ID || hit.UnitID == nil || *hit.UnitID != unitID {
+		t.Errorf("expected the unit's ability, got %+v", hit)
+	}
+	if hit.FactionID == nil || *hit.FactionID != factionID || hit.GameID != gameID {
+		t.Errorf("expected the ability scoped to the unit's faction and game, got %+v", hit)
+	}
+	if !strings.Contains(hit.Snippet, "<mark>mortal</mark>") {
+		t.Errorf("expected a highlighted snippet, got %q", hit.Snippet)
+	}
+
+	results = search(t, handler, url.Values{"q": {"description"}})
+	if len(results.Hits) != 2 {
+		t.Errorf("expected the rule and the enhancement, got %+v", results.Hits)
+	}
+
+	results = search(t, handler, url.Values{"q": {"description"}, "faction_id": {factionID.String()}})
+	if len(results.Hits) != 1 || results.Hits[0].Type != models.SearchTypeEnhancement {
+		t.Errorf("expected only the enhancement within the faction, got %+v", results.Hits)
+	}
+
+	results = search(t, handler, url.Values{"q": {"description"}, "type": {"rule"}})
+	if len(results.Hits) != 1 || results.Hits[0].Type != models.SearchTypeRule {
+		t.Errorf("expected only the rule, got %+v", results.Hits)
+	}
+}
+
+func TestSearch_InvalidParams(t *testing.T) {
+	s := setupTestDB(t)
+	handler := &SearchHandlers{S: s}
+
+	for _, query := range []string{"", "q=fly&type=weapon", "q=fly&limit=1000", "q=fly&game_id=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/search?"+query, nil)
+		w := httptest.NewRecorder()
+		handler.Search(w, req)
+
+		res := w.Result()
+		_ = res.Body.Close()
+
+		if res.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected status code 400 for %q, got %d", query, res.StatusCode)
+		}
+	}
+}
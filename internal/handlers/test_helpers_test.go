@@ -26,7 +26,7 @@ func setupTestDB(t *testing.T) *state.State {
 	queries := database.New(dbpool)
 
 	tables := []string{
-		"ability_effects", "abilities", "unit_keywords", "weapons", "units", "factions",
+		"army_lists", "ability_effects", "abilities", "unit_keywords", "weapons", "units", "factions",
 		"keywords", "battle_formations", "enhancements", "rules", "games",
 	}
 
@@ -104,6 +104,7 @@ func createTestUnit(t *testing.T, s *state.State, factionID uuid.UUID) uuid.UUID
 		MatchedPlay:     true,
 		IsManifestation: false,
 		IsUnique:        false,
+		CanBeReinforced: true,
 		AdditionalStats: json.RawMessage("{}"),
 		Version:         "1.0",
 		Source:          "Test Source",
@@ -171,6 +172,7 @@ func createTestUnitWithName(t *testing.T, s *state.State, factionID uuid.UUID, n
 		MatchedPlay:     true,
 		IsManifestation: false,
 		IsUnique:        false,
+		CanBeReinforced: true,
 		AdditionalStats: json.RawMessage("{}"),
 		Version:         "1.0",
 		Source:          "Test Source",
@@ -276,15 +278,16 @@ func createTestEnhancement(t *testing.T, s *state.State, factionID uuid.UUID) uu
 	ctx := context.Background()
 
 	enhancement, err := s.DB.CreateEnhancement(ctx, database.CreateEnhancementParams{
-		FactionID:       factionID,
-		Name:            "Test Enhancement",
-		EnhancementType: "artefact",
-		Description:     "Enhancement Description",
-		Restrictions:    "Restrictions",
-		Points:          20,
-		IsUnique:        true,
-		Version:         "1.0",
-		Source:          "Test Source",
+		FactionID:        factionID,
+		Name:             "Test Enhancement",
+		EnhancementType:  "artefact",
+		Description:      "Enhancement Description",
+		Restrictions:     "Restrictions",
+		RequiredKeywords: json.RawMessage("[]"),
+		Points:           20,
+		IsUnique:         true,
+		Version:          "1.0",
+		Source:           "Test Source",
 	})
 	if err != nil {
 		t.Fatalf("failed to create enhancement: %v", err)
@@ -364,3 +367,102 @@ func createTestAbilityFaction(t *testing.T, s *state.State, factionID uuid.UUID)
 
 	return ability.ID
 }
+
+func createTestHero(t *testing.T, s *state.State, gameID, factionID uuid.UUID) uuid.UUID {
+	ctx := context.Background()
+
+	unit, err := s.DB.CreateUnit(ctx, database.CreateUnitParams{
+		FactionID:       factionID,
+		Name:            "Test Hero",
+		Move:            "5\"",
+		HealthWounds:    "6",
+		SaveStats:       "3+",
+		ControlOc:       "2",
+		Points:          150,
+		MinUnitSize:     1,
+		MaxUnitSize:     1,
+		MatchedPlay:     true,
+		AdditionalStats: json.RawMessage("{}"),
+		Version:         "1.0",
+		Source:          "Test Source",
+	})
+	if err != nil {
+		t.Fatalf("failed to create hero: %v", err)
+	}
+
+	err = s.DB.AddKeywordToUnit(ctx, database.AddKeywordToUnitParams{
+		UnitID:    unit.ID,
+		KeywordID: createTestKeywordWithName(t, s, gameID, "HERO"),
+	})
+	if err != nil {
+		t.Fatalf("failed to add HERO keyword: %v", err)
+	}
+
+	return unit.ID
+}
+
+func createTestArmyOfRenown(t *testing.T, s *state.State, gameID, parentID uuid.UUID, name string) uuid.UUID {
+	ctx := context.Background()
+
+	faction, err := s.DB.CreateFaction(ctx, database.CreateFactionParams{
+		GameID:             gameID,
+		Name:               name,
+		Allegiance:         "Test Allegiance",
+		Version:            "1.0",
+		Source:             "Test Source",
+		IsArmyOfRenown:     true,
+		IsRegimentOfRenown: false,
+		ParentFactionID:    database.UUIDToNullUUID(parentID),
+	})
+	if err != nil {
+		t.Fatalf("failed to create army of renown: %v", err)
+	}
+
+	return faction.ID
+}
+
+func createTestAllowedUnit(t *testing.T, s *state.State, factionID uuid.UUID, unitName string) {
+	ctx := context.Background()
+
+	_, err := s.DB.CreateAllowedUnit(ctx, database.CreateAllowedUnitParams{
+		FactionID: factionID,
+		UnitName:  unitName,
+	})
+	if err != nil {
+		t.Fatalf("failed to create allowed unit: %v", err)
+	}
+}
+
+func createTestRegimentOfRenown(t *testing.T, s *state.State, gameID uuid.UUID, name string, points int32) uuid.UUID {
+	ctx := context.Background()
+
+	faction, err := s.DB.CreateFaction(ctx, database.CreateFactionParams{
+		GameID:             gameID,
+		Name:               name,
+		Allegiance:         "Test Allegiance",
+		Version:            "1.0",
+		Source:             "Test Source",
+		IsArmyOfRenown:     false,
+		IsRegimentOfRenown: true,
+		ParentFactionID:    uuid.NullUUID{},
+		Points:             points,
+	})
+	if err != nil {
+		t.Fatalf("failed to create regiment of renown: %v", err)
+	}
+
+	return faction.ID
+}
+
+func createTestEffect(t *testing.T, s *state.State, params database.CreateAbilityEffectParams) uuid.UUID {
+	ctx := context.Background()
+
+	params.Version = "1.0"
+	params.Source = "Test Source"
+	effect, err := s.DB.CreateAbilityEffect(ctx, params)
+	if err != nil {
+		t.Fatalf("failed to create ability effect: %v", err)
+	}
+
+	return effect.ID
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 
@@ -18,113 +19,68 @@ type UnitsHandlers struct {
 }
 
 func (h *UnitsHandlers) GetUnits(w http.ResponseWriter, r *http.Request) {
-	matchedPlay := r.URL.Query().Get("matched_play")
-	factionID := r.URL.Query().Get("faction_id")
-
-	if matchedPlay == "true" {
-		if factionID == "" {
-			respondWithError(w, http.StatusBadRequest, "missing faction id for matched play", nil)
-			return
-		}
+	serveList(h.S, w, r, "units", services.ListUnits, "game_id", "faction_id", "points_min", "points_max", "is_unique", "matched_play", "is_manifestation", "allegiance", "keywords")
+}
 
-		h.getUnitsByMatchedPlay(w, r)
-		return
-	}
+func (h *UnitsHandlers) GetUnitByID(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
 
-	if factionID != "" {
-		h.getUnitsByFaction(w, r)
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid unit id", err)
 		return
 	}
 
-	h.getAllUnits(w, r)
-}
-
-func (h *UnitsHandlers) getAllUnits(w http.ResponseWriter, r *http.Request) {
-	factionIDStr := r.URL.Query().Get("faction_id")
-	var units []models.Unit
-	var err error
-
-	if factionIDStr == "" {
-		units, err = services.GetUnits(h.S, r.Context(), nil)
-	} else {
-		factionID, parseErr := uuid.Parse(factionIDStr)
-		if parseErr != nil {
-			respondWithError(w, http.StatusBadRequest, "invalid faction id", parseErr)
-			return
-		}
-		units, err = services.GetUnits(h.S, r.Context(), &factionID)
-	}
-
+	unit, err := services.GetUnitByID(h.S, r.Context(), id)
 	if err != nil {
 		switch {
 		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "units not found", err)
+			respondWithError(w, http.StatusNotFound, "unit not found", err)
 		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch units", err)
+			respondWithError(w, http.StatusInternalServerError, "failed to fetch unit", err)
 		}
-		logRequestError(h.S, r, "failed to fetch units", err)
+
+		logRequestError(h.S, r, "failed to fetch unit", err)
 		return
 	}
 
-	logRequestInfo(h.S, r, "Successfully fetched units", zap.Int("count", len(units)))
-	respondWithJSON(w, http.StatusOK, units)
+	logRequestInfo(h.S, r, "Successfully fetched unit")
+	respondWithJSON(w, http.StatusOK, unit)
 }
 
-func (h *UnitsHandlers) getUnitsByFaction(w http.ResponseWriter, r *http.Request) {
-	factionIDStr := r.URL.Query().Get("faction_id")
-	if factionIDStr == "" {
-		respondWithError(w, http.StatusBadRequest, "missing faction id", nil)
-		return
-	}
-
-	factionID, err := uuid.Parse(factionIDStr)
+func (h *UnitsHandlers) ResolveUnitStats(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "invalid faction id", err)
+		respondWithError(w, http.StatusBadRequest, "invalid unit id", err)
 		return
 	}
 
-	units, err := services.GetUnitsByFaction(h.S, r.Context(), factionID)
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrMissingFactionID):
-			respondWithError(w, http.StatusBadRequest, "missing faction id", err)
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "faction not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch factions", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch factions", err)
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched factions", zap.Int("count", len(units)))
-	respondWithJSON(w, http.StatusOK, units)
-}
-
-func (h *UnitsHandlers) GetUnitByID(w http.ResponseWriter, r *http.Request) {
-	idStr := r.PathValue("id")
-
-	id, err := uuid.Parse(idStr)
+	var sel models.EffectSelection
+	err = json.NewDecoder(r.Body).Decode(&sel)
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "invalid unit id", err)
+		respondWithError(w, http.StatusBadRequest, "invalid request body", err)
 		return
 	}
 
-	unit, err := services.GetUnitByID(h.S, r.Context(), id)
+	resolved, err := services.ResolveUnitStats(h.S, r.Context(), id, sel)
 	if err != nil {
 		switch {
+		case errors.Is(err, appErr.ErrMissingID):
+			respondWithError(w, http.StatusBadRequest, "invalid unit id", err)
 		case errors.Is(err, appErr.ErrNotFound):
 			respondWithError(w, http.StatusNotFound, "unit not found", err)
+		case errors.Is(err, appErr.ErrInvalidReference):
+			respondWithError(w, http.StatusBadRequest, "ability, enhancement or battle formation not found", err)
 		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch unit", err)
+			respondWithError(w, http.StatusInternalServerError, "failed to resolve unit stats", err)
 		}
 
-		logRequestError(h.S, r, "failed to fetch unit", err)
+		logRequestError(h.S, r, "failed to resolve unit stats", err)
 		return
 	}
 
-	logRequestInfo(h.S, r, "Successfully fetched unit")
-	respondWithJSON(w, http.StatusOK, unit)
+	logRequestInfo(h.S, r, "Resolved unit stats", zap.Int("changes", len(resolved.Trace)))
+	respondWithJSON(w, http.StatusOK, resolved)
 }
 
 func (h *UnitsHandlers) GetManifestations(w http.ResponseWriter, r *http.Request) {
@@ -187,36 +143,3 @@ func (h *UnitsHandlers) GetManifestationByID(w http.ResponseWriter, r *http.Requ
 	logRequestInfo(h.S, r, "Successfully fetched manifestation")
 	respondWithJSON(w, http.StatusOK, manifestation)
 }
-
-func (h *UnitsHandlers) getUnitsByMatchedPlay(w http.ResponseWriter, r *http.Request) {
-	factionIDStr := r.URL.Query().Get("faction_id")
-
-	if factionIDStr == "" {
-		respondWithError(w, http.StatusBadRequest, "missing faction id", nil)
-		return
-	}
-
-	factionID, err := uuid.Parse(factionIDStr)
-	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "invalid faction id", err)
-		return
-	}
-
-	units, err := services.GetUnitsByMatchedPlay(h.S, r.Context(), factionID)
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrMissingID):
-			respondWithError(w, http.StatusBadRequest, "missing faction id", err)
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "units not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch units", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch units", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched units", zap.Int("count", len(units)))
-	respondWithJSON(w, http.StatusOK, units)
-}
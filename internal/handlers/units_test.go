@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +10,10 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/database"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
 )
 
 func TestGetUnits_ReturnsUnits(t *testing.T) {
@@ -241,3 +247,90 @@ func TestGetManifestationByID_NotFound(t *testing.T) {
 		t.Errorf("expected status code 404, got %d", res.StatusCode)
 	}
 }
+
+func postResolve(t *testing.T, s *state.State, unitID uuid.UUID, sel models.EffectSelection) *http.Response {
+	t.Helper()
+
+	jsonData, err := json.Marshal(sel)
+	if err != nil {
+		t.Fatalf("failed to marshal effect selection: %v", err)
+	}
+
+	handler := &UnitsHandlers{S: s}
+	req := httptest.NewRequest(http.MethodPost, "/units/"+unitID.String()+"/resolve", bytes.NewBuffer(jsonData))
+	req.SetPathValue("id", unitID.String())
+	w := httptest.NewRecorder()
+
+	handler.ResolveUnitStats(w, req)
+	return w.Result()
+}
+
+func TestResolveUnitStats(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+	createTestWeapon(t, s, unitID)
+	abilityID := createTestAbilityUnit(t, s, unitID)
+	enhancementID := createTestEnhancement(t, s, factionID)
+	formationID := createTestBattleFormation(t, s, gameID, factionID)
+
+	createTestEffect(t, s, database.CreateAbilityEffectParams{AbilityID: database.UUIDToNullUUID(abilityID), Stat: "save", Modifier: 1})
+	createTestEffect(t, s, database.CreateAbilityEffectParams{EnhancementID: database.UUIDToNullUUID(enhancementID), Stat: "damage", Modifier: 1})
+	createTestEffect(t, s, database.CreateAbilityEffectParams{BattleFormationID: database.UUIDToNullUUID(formationID), Stat: "attacks", Modifier: 1, Condition: "charged"})
+
+	res := postResolve(t, s, unitID, models.EffectSelection{
+		AbilityIDs:        []uuid.UUID{abilityID},
+		EnhancementIDs:    []uuid.UUID{enhancementID},
+		BattleFormationID: formationID,
+	})
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", res.StatusCode)
+	}
+
+	var resolved models.ResolvedUnit
+	err := json.NewDecoder(res.Body).Decode(&resolved)
+	if err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resolved.Unit.Save != "2+" || resolved.Unit.Stats.Save.Min != 2 {
+		t.Errorf("expected a 2+ save, got %q", resolved.Unit.Save)
+	}
+	if len(resolved.Unit.Weapons) != 1 || resolved.Unit.Weapons[0].Damage != "3" || resolved.Unit.Weapons[0].Attacks != "5" {
+		t.Errorf("expected 3 damage and unchanged attacks, got %+v", resolved.Unit.Weapons)
+	}
+
+	if len(resolved.Trace) != 3 || resolved.Trace[2].Applied || resolved.Trace[2].SourceType != models.EffectSourceBattleFormation {
+		t.Errorf("expected the formation's effect to be skipped, got %+v", resolved.Trace)
+	}
+}
+
+func TestResolveUnitStats_UnknownSource(t *testing.T) {
+	s := setupTestDB(t)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+
+	res := postResolve(t, s, unitID, models.EffectSelection{EnhancementIDs: []uuid.UUID{uuid.New()}})
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status code 400, got %d", res.StatusCode)
+	}
+}
+
+func TestResolveUnitStats_NilID(t *testing.T) {
+	s := setupTestDB(t)
+
+	res := postResolve(t, s, uuid.Nil, models.EffectSelection{})
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status code 400, got %d", res.StatusCode)
+	}
+}
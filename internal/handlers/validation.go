@@ -45,6 +45,7 @@ func (h *ValidationHandlers) ValidateArmy(w http.ResponseWriter, r *http.Request
 	if err != nil {
 		logRequestError(h.S, r, "validation service failure", err)
 		respondWithError(w, http.StatusInternalServerError, "failed to validate army", err)
+		return
 	}
 
 	logRequestInfo(h.S, r, "Army validation completed", zap.Bool("is_valid", resp.IsValid))
@@ -23,7 +23,8 @@ func TestValidateArmy_Success(t *testing.T) {
 		FactionID:   factionID,
 		PointsLimit: 2000,
 		Units: []models.ArmyUnit{
-			{UnitID: unitID, Quantity: 4},
+			{UnitID: unitID, Quantity: 2},
+			{UnitID: unitID, Quantity: 1, Reinforced: true},
 		},
 	}
 
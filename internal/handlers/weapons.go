@@ -5,7 +5,6 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
-	"go.uber.org/zap"
 
 	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
 	"github.com/JohnG-Dev/army_builder_api/internal/services"
@@ -17,65 +16,7 @@ type WeaponsHandlers struct {
 }
 
 func (h *WeaponsHandlers) GetWeapons(w http.ResponseWriter, r *http.Request) {
-	unitID := r.URL.Query().Get("unit id")
-
-	if unitID != "" {
-		h.getWeaponsForUnit(w, r)
-		return
-	}
-
-	h.getAllWeapons(w, r)
-}
-
-func (h *WeaponsHandlers) getAllWeapons(w http.ResponseWriter, r *http.Request) {
-	weapons, err := services.GetAllWeapons(h.S, r.Context())
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "weapons not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch weapons", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch weapons", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched weapons", zap.Int("count", len(weapons)))
-	respondWithJSON(w, http.StatusOK, weapons)
-}
-
-func (h *WeaponsHandlers) getWeaponsForUnit(w http.ResponseWriter, r *http.Request) {
-	unitIDStr := r.URL.Query().Get("unit_id")
-
-	if unitIDStr == "" {
-		respondWithError(w, http.StatusBadRequest, "missing unit id", nil)
-		return
-	}
-
-	unitID, err := uuid.Parse(unitIDStr)
-	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "invalid unit id", err)
-		return
-	}
-
-	weapons, err := services.GetWeaponsForUnit(h.S, r.Context(), &unitID)
-	if err != nil {
-		switch {
-		case errors.Is(err, appErr.ErrMissingUnitID):
-			respondWithError(w, http.StatusBadRequest, "unit id required", err)
-		case errors.Is(err, appErr.ErrNotFound):
-			respondWithError(w, http.StatusNotFound, "weapons not found", err)
-		default:
-			respondWithError(w, http.StatusInternalServerError, "failed to fetch weapons", err)
-		}
-
-		logRequestError(h.S, r, "failed to fetch weapons", err)
-		return
-	}
-
-	logRequestInfo(h.S, r, "Successfully fetched weapons", zap.Int("count", len(weapons)))
-	respondWithJSON(w, http.StatusOK, weapons)
+	serveList(h.S, w, r, "weapons", services.ListWeapons, "game_id", "faction_id", "unit_id", "type", "allegiance")
 }
 
 func (h *WeaponsHandlers) GetWeaponByID(w http.ResponseWriter, r *http.Request) {
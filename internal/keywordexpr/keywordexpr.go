@@ -0,0 +1,277 @@
+// Package keywordexpr parses boolean keyword expressions such as
+// "HERO AND (WIZARD OR PRIEST) AND NOT UNIQUE".
+package keywordexpr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalid is returned for strings that are not a keyword expression.
+var ErrInvalid = errors.New("invalid keyword expression")
+
+// SyntaxError points at the token an expression went wrong at. Pos is the
+// 1-based character position of the token.
+type SyntaxError struct {
+	Pos   int
+	Token string
+	Msg   string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return ErrInvalid
+}
+
+// Expr is a parsed expression: a Keyword, Not, And or Or.
+type Expr interface {
+	String() string
+}
+
+// Keyword matches units that have the keyword. Names are compared without
+// regard to case.
+type Keyword struct {
+	Name string
+}
+
+// Not matches units X does not match.
+type Not struct {
+	X Expr
+}
+
+// And matches units both X and Y match.
+type And struct {
+	X, Y Expr
+}
+
+// Or matches units X or Y match.
+type Or struct {
+	X, Y Expr
+}
+
+func (k Keyword) String() string {
+	if strings.ContainsAny(k.Name, "()") || slices.ContainsFunc(strings.Fields(k.Name), isOperator) {
+		return `"` + k.Name + `"`
+	}
+	return k.Name
+}
+
+func (n Not) String() string { return "NOT " + group(n.X) }
+func (a And) String() string { return group(a.X) + " AND " + group(a.Y) }
+func (o Or) String() string  { return group(o.X) + " OR " + group(o.Y) }
+
+func group(e Expr) string {
+	switch e.(type) {
+	case And, Or:
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+func isOperator(word string) bool {
+	switch strings.ToUpper(word) {
+	case "AND", "OR", "NOT":
+		return true
+	}
+	return false
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokQuoted
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lex splits s into tokens. Positions count characters from 1.
+func lex(s string) ([]token, error) {
+	var tokens []token
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "(", i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")", i + 1})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, &SyntaxError{Pos: i + 1, Token: `"`, Msg: "unterminated quote"}
+			}
+			name := strings.Join(strings.Fields(string(runes[i+1:end])), " ")
+			if name == "" {
+				return nil, &SyntaxError{Pos: i + 1, Token: string(runes[i : end+1]), Msg: "empty keyword"}
+			}
+			tokens = append(tokens, token{tokQuoted, name, i + 1})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			kind := tokWord
+			switch strings.ToUpper(word) {
+			case "AND":
+				kind = tokAnd
+			case "OR":
+				kind = tokOr
+			case "NOT":
+				kind = tokNot
+			}
+			tokens = append(tokens, token{kind, word, i + 1})
+			i = end
+		}
+	}
+
+	return append(tokens, token{tokEOF, "", len(runes) + 1}), nil
+}
+
+// Parse reads an expression of keywords joined by AND, OR and NOT, with
+// parentheses for grouping. NOT binds tightest, then AND, then OR; the
+// operators are case-insensitive. Bare words in a row make up one keyword,
+// so "STORMCAST ETERNALS" needs no quotes; a keyword that is itself an
+// operator, or holds one, is written in double quotes.
+//
+// Errors are a *SyntaxError pointing at the offending token.
+func Parse(s string) (Expr, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, &SyntaxError{Pos: p.peek().pos, Msg: "empty expression"}
+	}
+
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.unexpected(t, "AND, OR or end of expression")
+	}
+	return e, nil
+}
+
+type parser struct {
+	tokens []token
+	i      int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token, expected string) error {
+	return &SyntaxError{
+		Pos:   t.pos,
+		Token: t.text,
+		Msg:   fmt.Sprintf("unexpected %s, expected %s", t, expected),
+	}
+}
+
+func (p *parser) or() (Expr, error) {
+	x, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		y, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		x = Or{x, y}
+	}
+	return x, nil
+}
+
+func (p *parser) and() (Expr, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		y, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		x = And{x, y}
+	}
+	return x, nil
+}
+
+func (p *parser) unary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNot:
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{x}, nil
+	case tokLParen:
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.unexpected(closing, `")"`)
+		}
+		return x, nil
+	case tokQuoted:
+		return Keyword{t.text}, nil
+	case tokWord:
+		words := []string{t.text}
+		for p.peek().kind == tokWord {
+			words = append(words, p.next().text)
+		}
+		return Keyword{strings.Join(words, " ")}, nil
+	}
+	return nil, p.unexpected(t, "a keyword, NOT or \"(\"")
+}
@@ -0,0 +1,83 @@
+package keywordexpr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected Expr
+		str      string
+	}{
+		{in: "HERO", expected: Keyword{"HERO"}, str: "HERO"},
+		{in: "  Stormcast   Eternals ", expected: Keyword{"Stormcast Eternals"}, str: "Stormcast Eternals"},
+		{in: `"NOT A KEYWORD"`, expected: Keyword{"NOT A KEYWORD"}, str: `"NOT A KEYWORD"`},
+		{in: "not unique", expected: Not{Keyword{"unique"}}, str: "NOT unique"},
+		{
+			in:       "HERO AND (WIZARD OR PRIEST) AND NOT UNIQUE",
+			expected: And{And{Keyword{"HERO"}, Or{Keyword{"WIZARD"}, Keyword{"PRIEST"}}}, Not{Keyword{"UNIQUE"}}},
+			str:      "(HERO AND (WIZARD OR PRIEST)) AND NOT UNIQUE",
+		},
+		{
+			in:       "WIZARD OR PRIEST AND HERO",
+			expected: Or{Keyword{"WIZARD"}, And{Keyword{"PRIEST"}, Keyword{"HERO"}}},
+			str:      "WIZARD OR (PRIEST AND HERO)",
+		},
+		{
+			in:       "NOT (CAVALRY OR MONSTER)",
+			expected: Not{Or{Keyword{"CAVALRY"}, Keyword{"MONSTER"}}},
+			str:      "NOT (CAVALRY OR MONSTER)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(e, tt.expected) {
+				t.Errorf("expected %#v, got %#v", tt.expected, e)
+			}
+			if e.String() != tt.str {
+				t.Errorf("expected %q, got %q", tt.str, e.String())
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		in    string
+		pos   int
+		token string
+	}{
+		{in: "", pos: 1},
+		{in: "HERO AND", pos: 9},
+		{in: "HERO AND OR WIZARD", pos: 10, token: "OR"},
+		{in: "(HERO OR WIZARD", pos: 16},
+		{in: "HERO)", pos: 5, token: ")"},
+		{in: "HERO (WIZARD)", pos: 6, token: "("},
+		{in: `HERO AND "WIZARD`, pos: 10, token: `"`},
+		{in: "NOT", pos: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := Parse(tt.in)
+			if !errors.Is(err, ErrInvalid) {
+				t.Fatalf("expected ErrInvalid, got %v", err)
+			}
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a *SyntaxError, got %T", err)
+			}
+			if syntaxErr.Pos != tt.pos || syntaxErr.Token != tt.token {
+				t.Errorf("expected %q at %d, got %q at %d (%v)", tt.token, tt.pos, syntaxErr.Token, syntaxErr.Pos, err)
+			}
+		})
+	}
+}
@@ -24,15 +24,21 @@ type Ability struct {
 	Effects []AbilityEffect `json:"effects,omitempty"`
 }
 
+// AbilityEffect changes a stat by Modifier. It is granted by exactly one of
+// an ability, an enhancement or a battle formation. Stat names a field of a
+// unit's or weapon's stat line, such as "save" or "damage"; Condition, when
+// set, must be met for the effect to apply.
 type AbilityEffect struct {
-	ID          uuid.UUID `json:"id"`
-	AbilityID   uuid.UUID `json:"ability_id"`
-	Stat        string    `json:"stat"`
-	Modifier    int       `json:"modifier"`
-	Condition   string    `json:"condition"`
-	Description string    `json:"description"`
-	Version     string    `json:"version"`
-	Source      string    `json:"source"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                uuid.UUID  `json:"id"`
+	AbilityID         *uuid.UUID `json:"ability_id,omitempty"`
+	EnhancementID     *uuid.UUID `json:"enhancement_id,omitempty"`
+	BattleFormationID *uuid.UUID `json:"battle_formation_id,omitempty"`
+	Stat              string     `json:"stat"`
+	Modifier          int        `json:"modifier"`
+	Condition         string     `json:"condition"`
+	Description       string     `json:"description"`
+	Version           string     `json:"version"`
+	Source            string     `json:"source"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
 }
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// AllowedUnit is a parent faction unit an Army of Renown may take. It is
+// matched on BattlescribeID when both sides have one, otherwise on the name.
+type AllowedUnit struct {
+	ID             uuid.UUID `json:"id"`
+	FactionID      uuid.UUID `json:"faction_id"`
+	UnitName       string    `json:"unit_name"`
+	BattlescribeID string    `json:"battlescribe_id,omitempty"`
+}
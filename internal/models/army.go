@@ -4,20 +4,74 @@ import (
 	"github.com/google/uuid"
 )
 
+// ArmyValidationRequest describes a list as regiments plus auxiliary units.
+// Units is the older flat form: those units are only checked for points,
+// reinforcement and faction, not for regiment composition.
 type ArmyValidationRequest struct {
-	GameID      uuid.UUID  `json:"game_id"`
-	FactionID   uuid.UUID  `json:"faction_id"`
-	PointsLimit int        `json:"points_limit"`
-	Units       []ArmyUnit `json:"units"`
+	GameID            uuid.UUID           `json:"game_id"`
+	FactionID         uuid.UUID           `json:"faction_id"`
+	PointsLimit       int                 `json:"points_limit"`
+	BattleFormationID *uuid.UUID          `json:"battle_formation_id"`
+	Regiments         []RegimentSelection `json:"regiments"`
+	Auxiliaries       []ArmyUnit          `json:"auxiliaries"`
+	Units             []ArmyUnit          `json:"units"`
+
+	RegimentsOfRenown []RegimentOfRenownSelection `json:"regiments_of_renown"`
+}
+
+// RegimentSelection is a HERO leading a regiment and the units that join it.
+// Exactly one regiment in a list belongs to the general.
+type RegimentSelection struct {
+	Leader    ArmyUnit   `json:"leader"`
+	Units     []ArmyUnit `json:"units"`
+	IsGeneral bool       `json:"is_general"`
 }
 
+// RegimentOfRenownSelection hires a Regiment of Renown for its fixed points.
+// Units may list the regiment's units to check they belong to it; they cost
+// nothing further and do not count towards the rest of the army.
+type RegimentOfRenownSelection struct {
+	FactionID uuid.UUID  `json:"faction_id"`
+	Units     []ArmyUnit `json:"units,omitempty"`
+}
+
+// ArmyUnit is Quantity entries of a unit, each fielded at its minimum size or
+// at double that when Reinforced. A Quantity below one counts as one entry.
 type ArmyUnit struct {
-	UnitID   uuid.UUID `json:"unit_id"`
-	Quantity int       `json:"quantity"`
+	UnitID         uuid.UUID   `json:"unit_id"`
+	Quantity       int         `json:"quantity"`
+	Reinforced     bool        `json:"reinforced"`
+	EnhancementIDs []uuid.UUID `json:"enhancement_ids,omitempty"`
 }
 
+// ValidationResponse is the outcome of checking a list against Ruleset.
+// Issues holds everything the rules found; Errors and Warnings repeat the
+// messages of the error and warning issues. FiredRules lists the ID of every
+// rule that reported an issue.
 type ValidationResponse struct {
-	IsValid     bool     `json:"is_valid"`
-	TotalPoints int      `json:"total_points"`
-	Errors      []string `json:"errors"`
+	IsValid         bool              `json:"is_valid"`
+	Ruleset         string            `json:"ruleset"`
+	TotalPoints     int               `json:"total_points"`
+	AuxiliaryUnits  int               `json:"auxiliary_units"`
+	Drops           int               `json:"drops"`
+	ReinforcedUnits int               `json:"reinforced_units"`
+	Issues          []ValidationIssue `json:"issues"`
+	Errors          []string          `json:"errors"`
+	Warnings        []string          `json:"warnings"`
+	FiredRules      []string          `json:"fired_rules"`
+}
+
+// ValidationIssue is one problem found in a list. Code is stable for clients
+// to match on and Message is for display. Paths locate the entries involved
+// in the request, such as "regiments[0].units[1]", and Params holds the
+// values behind the message, such as "limit" and "actual".
+type ValidationIssue struct {
+	Code           string         `json:"code"`
+	Severity       string         `json:"severity"`
+	Rule           string         `json:"rule"`
+	Message        string         `json:"message"`
+	Paths          []string       `json:"paths,omitempty"`
+	UnitIDs        []uuid.UUID    `json:"unit_ids,omitempty"`
+	EnhancementIDs []uuid.UUID    `json:"enhancement_ids,omitempty"`
+	Params         map[string]any `json:"params,omitempty"`
 }
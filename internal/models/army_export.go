@@ -0,0 +1,13 @@
+package models
+
+// ArmyListExport is a saved list with everything it refers to, as returned by
+// GET /armies/{id}/export?format=json. Units and Enhancements hold each unit
+// and enhancement of the list once, fully hydrated.
+type ArmyListExport struct {
+	Army             ArmyList         `json:"army"`
+	Faction          Faction          `json:"faction"`
+	BattleFormation  *BattleFormation `json:"battle_formation,omitempty"`
+	RegimentOfRenown *Faction         `json:"regiment_of_renown,omitempty"`
+	Units            []Unit           `json:"units"`
+	Enhancements     []Enhancement    `json:"enhancements"`
+}
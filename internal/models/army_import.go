@@ -0,0 +1,40 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// ArmyImportRequest is the body of POST /armies/import. Text is a list as
+// exported by the official app. FactionID picks the faction; without it the
+// faction named in Text is looked up among the factions of GameID.
+type ArmyImportRequest struct {
+	GameID    uuid.UUID `json:"game_id"`
+	FactionID uuid.UUID `json:"faction_id"`
+	Text      string    `json:"text"`
+}
+
+// ArmyImportResponse holds the imported list, ready to save through
+// POST /armies, its validation and the lines that could not be imported as is.
+type ArmyImportResponse struct {
+	Army       ArmyListRequest    `json:"army"`
+	Validation ValidationResponse `json:"validation"`
+	Report     []ImportReportLine `json:"report"`
+}
+
+const (
+	ImportUnmatched      = "unmatched"
+	ImportAmbiguous      = "ambiguous"
+	ImportSkipped        = "skipped"
+	ImportPointsMismatch = "points_mismatch"
+)
+
+// ImportReportLine is a line of the imported text, or a selection of the
+// imported roster, that was left out of the list or kept with a difference
+// worth checking. Line numbers start at 1; roster selections have none.
+type ImportReportLine struct {
+	Line       int      `json:"line,omitempty"`
+	Text       string   `json:"text"`
+	Status     string   `json:"status"`
+	Message    string   `json:"message"`
+	Candidates []string `json:"candidates,omitempty"`
+}
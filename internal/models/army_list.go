@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArmyList is a saved list. Validation is recomputed every time it is saved.
+type ArmyList struct {
+	ID                 uuid.UUID          `json:"id"`
+	GameID             uuid.UUID          `json:"game_id"`
+	FactionID          uuid.UUID          `json:"faction_id"`
+	Name               string             `json:"name"`
+	PointsLimit        int                `json:"points_limit"`
+	BattleFormationID  *uuid.UUID         `json:"battle_formation_id"`
+	RegimentOfRenownID *uuid.UUID         `json:"regiment_of_renown_id"`
+	TotalPoints        int                `json:"total_points"`
+	IsValid            bool               `json:"is_valid"`
+	Validation         ValidationResponse `json:"validation"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+
+	Regiments             []ArmyRegiment `json:"regiments,omitempty"`
+	Auxiliaries           []ArmyListUnit `json:"auxiliaries,omitempty"`
+	RegimentOfRenownUnits []ArmyListUnit `json:"regiment_of_renown_units,omitempty"`
+}
+
+// ArmyRegiment is led by its first unit.
+type ArmyRegiment struct {
+	ID        uuid.UUID      `json:"id"`
+	Name      string         `json:"name"`
+	IsGeneral bool           `json:"is_general"`
+	Units     []ArmyListUnit `json:"units"`
+}
+
+type ArmyListUnit struct {
+	ID             uuid.UUID   `json:"id"`
+	UnitID         uuid.UUID   `json:"unit_id"`
+	Quantity       int         `json:"quantity"`
+	Reinforced     bool        `json:"reinforced"`
+	EnhancementIDs []uuid.UUID `json:"enhancement_ids"`
+}
+
+// ArmyListRequest is the body of POST and PUT /armies.
+// RegimentOfRenownUnits are the units taken from the hired Regiment of Renown.
+type ArmyListRequest struct {
+	GameID                uuid.UUID             `json:"game_id"`
+	FactionID             uuid.UUID             `json:"faction_id"`
+	Name                  string                `json:"name"`
+	PointsLimit           int                   `json:"points_limit"`
+	BattleFormationID     *uuid.UUID            `json:"battle_formation_id"`
+	RegimentOfRenownID    *uuid.UUID            `json:"regiment_of_renown_id"`
+	Regiments             []ArmyRegimentRequest `json:"regiments"`
+	Auxiliaries           []ArmyListUnitRequest `json:"auxiliaries"`
+	RegimentOfRenownUnits []ArmyListUnitRequest `json:"regiment_of_renown_units,omitempty"`
+}
+
+// ArmyRegimentRequest lists the leader of the regiment first.
+type ArmyRegimentRequest struct {
+	Name      string                `json:"name"`
+	IsGeneral bool                  `json:"is_general"`
+	Units     []ArmyListUnitRequest `json:"units"`
+}
+
+type ArmyListUnitRequest struct {
+	UnitID         uuid.UUID   `json:"unit_id"`
+	Quantity       int         `json:"quantity"`
+	Reinforced     bool        `json:"reinforced"`
+	EnhancementIDs []uuid.UUID `json:"enhancement_ids"`
+}
@@ -17,4 +17,7 @@ type BattleFormation struct {
 	Source      string    `json:"source"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Effects []AbilityEffect `json:"effects,omitempty"`
 }
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/dice"
+)
+
+// DamageRequest is the body of POST /calculate/damage. WeaponIDs picks
+// weapons of the attacker, all of them when empty, and ModelCount defaults
+// to the attacker's minimum unit size. The target's save, ward and invuln
+// come from TargetID; Save and Ward override them or stand in for a target.
+// Crit overrides the crit ability of every weapon. Effects, when set, are
+// applied to the attacker's weapons first.
+type DamageRequest struct {
+	AttackerID uuid.UUID   `json:"attacker_id"`
+	WeaponIDs  []uuid.UUID `json:"weapon_ids"`
+	ModelCount int         `json:"model_count"`
+	TargetID   uuid.UUID   `json:"target_id"`
+	Save       string      `json:"save"`
+	Ward       string      `json:"ward"`
+	Crit       string      `json:"crit"`
+
+	Effects *EffectSelection `json:"effects,omitempty"`
+}
+
+// DamageTarget is the stat line attacks were resolved against.
+type DamageTarget struct {
+	UnitID    *uuid.UUID `json:"unit_id,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	Save      string     `json:"save"`
+	Ward      string     `json:"ward"`
+	Invuln    string     `json:"invuln,omitempty"`
+	Toughness string     `json:"toughness,omitempty"`
+}
+
+// DamageResponse holds the expected damage of the attacking unit and the
+// chance of every total, summed over the weapons that could be worked out.
+type DamageResponse struct {
+	AttackerID           uuid.UUID         `json:"attacker_id"`
+	Attacker             string            `json:"attacker"`
+	ModelCount           int               `json:"model_count"`
+	Target               DamageTarget      `json:"target"`
+	Weapons              []WeaponDamage    `json:"weapons"`
+	ExpectedDamage       float64           `json:"expected_damage"`
+	ExpectedMortalDamage float64           `json:"expected_mortal_damage"`
+	Distribution         dice.Distribution `json:"distribution"`
+
+	// Effects traces the effects applied to the attacker, if any were
+	// requested.
+	Effects []StatChange `json:"effects,omitempty"`
+}
+
+// WeaponDamage is the average attack sequence of one weapon. Weapons whose
+// profile cannot be worked out, such as a damage of "See ability", are
+// listed with the reason in Skipped and count for nothing.
+type WeaponDamage struct {
+	WeaponID             uuid.UUID `json:"weapon_id"`
+	Name                 string    `json:"name"`
+	Crit                 string    `json:"crit"`
+	Attacks              float64   `json:"attacks"`
+	Hits                 float64   `json:"hits"`
+	Crits                float64   `json:"crits"`
+	Wounds               float64   `json:"wounds"`
+	Unsaved              float64   `json:"unsaved"`
+	ExpectedDamage       float64   `json:"expected_damage"`
+	ExpectedMortalDamage float64   `json:"expected_mortal_damage"`
+	Skipped              string    `json:"skipped,omitempty"`
+}
@@ -8,16 +8,22 @@ import (
 
 // Enhancement represents artefacts, relics, command traits, etc.
 type Enhancement struct {
-	ID              uuid.UUID `json:"id"`
-	FactionID       uuid.UUID `json:"faction_id"`
-	Name            string    `json:"name"`
-	EnhancementType string    `json:"enhancement_type"`
-	Description     string    `json:"description"`
-	Points          int       `json:"points"`
-	IsUnique        bool      `json:"is_unique"`
-	Restrictions    string    `json:"restrictions"`
-	Version         string    `json:"version"`
-	Source          string    `json:"source"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	FactionID        uuid.UUID  `json:"faction_id"`
+	Name             string     `json:"name"`
+	EnhancementType  string     `json:"enhancement_type"`
+	Description      string     `json:"description"`
+	Points           int        `json:"points"`
+	IsUnique         bool       `json:"is_unique"`
+	Restrictions     string     `json:"restrictions"`
+	RequiredKeywords [][]string `json:"required_keywords"` // bearer needs every keyword of one group
+	Version          string     `json:"version"`
+	Source           string     `json:"source"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	BattlescribeID string `json:"battlescribe_id,omitempty"`
+
+	// Relationships
+	Effects []AbilityEffect `json:"effects,omitempty"`
 }
@@ -15,8 +15,17 @@ type Faction struct {
 	Name               string     `json:"name"`
 	Description        string     `json:"description"`
 	Allegiance         string     `json:"allegiance"`
+	Points             int        `json:"points"` // Regiments of Renown only
 	Version            string     `json:"version"`
 	Source             string     `json:"source"`
 	CreatedAt          time.Time  `json:"created_at"`
 	UpdatedAt          time.Time  `json:"updated_at"`
+
+	AllowedUnits []AllowedUnit `json:"allowed_units,omitempty"`
+	HireableBy   []string      `json:"hireable_by,omitempty"`
+
+	// BattlescribeID is the catalogue the faction comes from. Regiments of
+	// Renown share one catalogue and are told apart by their force entry.
+	BattlescribeID      string `json:"battlescribe_id,omitempty"`
+	BattlescribeForceID string `json:"battlescribe_force_id,omitempty"`
 }
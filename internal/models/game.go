@@ -14,4 +14,9 @@ type Game struct {
 	Source    string    `json:"source"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// BattlescribeID is the ID of the game system in BattleScribe data and
+	// BattlescribeForces the force entries rosters are built from, by role.
+	BattlescribeID     string            `json:"battlescribe_id,omitempty"`
+	BattlescribeForces map[string]string `json:"battlescribe_forces,omitempty"`
 }
@@ -16,6 +16,9 @@ type Keyword struct {
 	Source      string    `json:"source"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Value is the unit's value of the keyword when listed for a unit.
+	Value string `json:"value,omitempty"`
 }
 
 // UnitKeyword links a keyword and an optional value to a unit (e.g., WARD 5+).
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/keywordexpr"
+)
+
+// ListParams pages, sorts and filters a list endpoint. Sort names a field,
+// prefixed with "-" for descending order; Cursor is the NextCursor of the
+// previous page.
+type ListParams struct {
+	Limit   int
+	Cursor  string
+	Sort    string
+	Filters ListFilters
+}
+
+// ListFilters narrows a list. Nil filters match everything; each endpoint
+// reads only those that apply to it.
+type ListFilters struct {
+	GameID          *uuid.UUID
+	FactionID       *uuid.UUID
+	UnitID          *uuid.UUID
+	PointsMin       *int
+	PointsMax       *int
+	IsUnique        *bool
+	MatchedPlay     *bool
+	IsManifestation *bool
+	Allegiance      *string
+	Type            *string
+	Phase           *string
+	Keywords        keywordexpr.Expr
+}
+
+// Page is one page of a list. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
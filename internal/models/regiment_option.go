@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// RegimentOption names units a HERO can take in its regiment: every unit with
+// Keyword, or the unit called UnitName. Hero options admit one other HERO
+// each, the rest admit any number of non-HERO units.
+type RegimentOption struct {
+	ID       uuid.UUID `json:"id"`
+	UnitID   uuid.UUID `json:"unit_id"`
+	Keyword  string    `json:"keyword,omitempty"`
+	UnitName string    `json:"unit_name,omitempty"`
+	IsHero   bool      `json:"is_hero"`
+}
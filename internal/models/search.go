@@ -0,0 +1,42 @@
+package models
+
+import "github.com/google/uuid"
+
+// Search hit types.
+const (
+	SearchTypeUnit            = "unit"
+	SearchTypeAbility         = "ability"
+	SearchTypeRule            = "rule"
+	SearchTypeEnhancement     = "enhancement"
+	SearchTypeBattleFormation = "battle_formation"
+)
+
+// SearchParams is a full-text query. Types limits the hits to those types;
+// FactionID keeps only hits belonging to that faction, which leaves out rules
+// and game-wide abilities.
+type SearchParams struct {
+	Query     string
+	Types     []string
+	GameID    *uuid.UUID
+	FactionID *uuid.UUID
+	Limit     int
+}
+
+// SearchHit is one match. Snippet is the matching part of its description
+// with the matched words wrapped in <mark> tags.
+type SearchHit struct {
+	Type      string     `json:"type"`
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	GameID    uuid.UUID  `json:"game_id"`
+	FactionID *uuid.UUID `json:"faction_id,omitempty"`
+	UnitID    *uuid.UUID `json:"unit_id,omitempty"`
+	Rank      float32    `json:"rank"`
+	Snippet   string     `json:"snippet"`
+}
+
+// SearchResults are the hits for a query, best first.
+type SearchResults struct {
+	Query string      `json:"query"`
+	Hits  []SearchHit `json:"hits"`
+}
@@ -1,8 +1,24 @@
 package models
 
 type SeedData struct {
-	GameName string        `yaml:"game_name"`
-	Factions []FactionSeed `yaml:"factions"`
+	GameName      string        `yaml:"game_name"`
+	Version       string        `yaml:"version,omitempty"`
+	Source        string        `yaml:"source,omitempty"`
+	Rules         []RuleSeed    `yaml:"rules,omitempty"`
+	GameAbilities []AbilitySeed `yaml:"game_abilities,omitempty"`
+	Factions      []FactionSeed `yaml:"factions"`
+
+	// Only the game's core rules file carries the BattleScribe IDs of the
+	// game system and its force entries.
+	BattlescribeID     string            `yaml:"battlescribe_id,omitempty"`
+	BattlescribeForces map[string]string `yaml:"battlescribe_forces,omitempty"`
+}
+
+type RuleSeed struct {
+	Name        string `yaml:"name"`
+	RuleType    string `yaml:"rule_type"` // "core", "battle_tactic", "grand_strategy"
+	Description string `yaml:"description"`
+	Text        string `yaml:"text"`
 }
 
 type FactionSeed struct {
@@ -17,45 +33,72 @@ type FactionSeed struct {
 	Units              []UnitSeed            `yaml:"units"`
 	BattleFormations   []BattleFormationSeed `yaml:"battle_formations"`
 	Enhancements       []EnhancementSeed     `yaml:"enhancements"`
+	Abilities          []AbilitySeed         `yaml:"abilities"`
+	AllowedUnits       []AllowedUnitSeed     `yaml:"allowed_units,omitempty"`
+	Points             int                   `yaml:"points,omitempty"`      // Regiments of Renown only
+	HireableBy         []string              `yaml:"hireable_by,omitempty"` // Regiments of Renown only
+
+	BattlescribeID      string `yaml:"battlescribe_id,omitempty"`
+	BattlescribeForceID string `yaml:"battlescribe_force_id,omitempty"` // Regiments of Renown only
+}
+
+// AllowedUnitSeed is a parent faction unit an Army of Renown may take.
+type AllowedUnitSeed struct {
+	Name           string `yaml:"name"`
+	BattlescribeID string `yaml:"battlescribe_id,omitempty"`
 }
 
 type UnitSeed struct {
-	Name            string            `yaml:"name"`
-	Description     string            `yaml:"description"`
-	IsManifestation bool              `yaml:"is_manifestation"`
-	IsUnique        bool              `yaml:"is_unique"`
-	Move            string            `yaml:"move"`
-	Health          string            `yaml:"health"`
-	Save            string            `yaml:"save"`
-	Ward            string            `yaml:"ward"`
-	Invuln          string            `yaml:"invuln"`
-	Control         string            `yaml:"control"`
-	Toughness       string            `yaml:"toughness"`
-	Leadership      string            `yaml:"leadership"`
-	AdditionalStats map[string]string `yaml:"additional_stats"`
-	Points          int               `yaml:"points"`
-	SummonCost      string            `yaml:"summon_cost"`
-	Banishment      string            `yaml:"banishment"`
-	MinUnitSize     int               `yaml:"min_unit_size"`
-	MaxUnitSize     int               `yaml:"max_unit_size"`
-	MatchedPlay     bool              `yaml:"matched_play"`
-	Version         string            `yaml:"version"`
-	Source          string            `yaml:"source"`
-	Keywords        []string          `yaml:"keywords"`
-	Weapons         []WeaponSeed      `yaml:"weapons"`
-	Abilities       []AbilitySeed     `yaml:"abilities"`
+	Name            string               `yaml:"name"`
+	BattlescribeID  string               `yaml:"battlescribe_id,omitempty"`
+	Description     string               `yaml:"description"`
+	IsManifestation bool                 `yaml:"is_manifestation"`
+	IsUnique        bool                 `yaml:"is_unique"`
+	Move            string               `yaml:"move"`
+	Health          string               `yaml:"health"`
+	Save            string               `yaml:"save"`
+	Ward            string               `yaml:"ward"`
+	Invuln          string               `yaml:"invuln"`
+	Control         string               `yaml:"control"`
+	Toughness       string               `yaml:"toughness"`
+	Leadership      string               `yaml:"leadership"`
+	AdditionalStats map[string]string    `yaml:"additional_stats"`
+	Points          int                  `yaml:"points"`
+	SummonCost      string               `yaml:"summon_cost"`
+	Banishment      string               `yaml:"banishment"`
+	MinUnitSize     int                  `yaml:"min_unit_size"`
+	MaxUnitSize     int                  `yaml:"max_unit_size"`
+	CanBeReinforced bool                 `yaml:"can_be_reinforced"`
+	MatchedPlay     bool                 `yaml:"matched_play"`
+	Version         string               `yaml:"version"`
+	Source          string               `yaml:"source"`
+	Keywords        []string             `yaml:"keywords"`
+	RegimentOptions []RegimentOptionSeed `yaml:"regiment_options,omitempty"`
+	Weapons         []WeaponSeed         `yaml:"weapons"`
+	Abilities       []AbilitySeed        `yaml:"abilities"`
+}
+
+// RegimentOptionSeed is one entry of a hero's regiment options: either every
+// unit with Keyword or the single unit called UnitName. Hero options admit
+// other HEROES, the rest admit non-HERO units.
+type RegimentOptionSeed struct {
+	Keyword  string `yaml:"keyword,omitempty"`
+	UnitName string `yaml:"unit_name,omitempty"`
+	IsHero   bool   `yaml:"is_hero,omitempty"`
 }
 
 type WeaponSeed struct {
-	Name    string `yaml:"name"`
-	Range   string `yaml:"range"`
-	Attacks string `yaml:"attacks"`
-	ToHit   string `yaml:"to_hit"`
-	ToWound string `yaml:"to_wound"`
-	Rend    string `yaml:"rend"`
-	Damage  string `yaml:"damage"`
-	Version string `yaml:"version"`
-	Source  string `yaml:"source"`
+	Name      string `yaml:"name"`
+	Type      string `yaml:"type"` // "melee", "ranged"
+	Range     string `yaml:"range"`
+	Attacks   string `yaml:"attacks"`
+	ToHit     string `yaml:"to_hit"`
+	ToWound   string `yaml:"to_wound"`
+	Rend      string `yaml:"rend"`
+	Damage    string `yaml:"damage"`
+	Abilities string `yaml:"abilities"`
+	Version   string `yaml:"version"`
+	Source    string `yaml:"source"`
 }
 
 type AbilitySeed struct {
@@ -76,13 +119,19 @@ type AbilityEffectSeed struct {
 type BattleFormationSeed struct {
 	Name        string `yaml:"name"`
 	Description string `yaml:"description"`
+
+	Effects []AbilityEffectSeed `yaml:"effects,omitempty"`
 }
 
 type EnhancementSeed struct {
-	Name            string `yaml:"name"`
-	EnhancementType string `yaml:"enhancement_type"` // "Type of Power", "Hero Trait"
-	Description     string `yaml:"description"`
-	Restrictions    string `yaml:"restrictions"`
-	Points          int    `yaml:"points"`
-	IsUnique        bool   `yaml:"is_unique"`
+	Name             string     `yaml:"name"`
+	BattlescribeID   string     `yaml:"battlescribe_id,omitempty"`
+	EnhancementType  string     `yaml:"enhancement_type"` // "Type of Power", "Hero Trait"
+	Description      string     `yaml:"description"`
+	Restrictions     string     `yaml:"restrictions"`
+	RequiredKeywords [][]string `yaml:"required_keywords,omitempty"` // bearer needs every keyword of one group
+	Points           int        `yaml:"points"`
+	IsUnique         bool       `yaml:"is_unique"`
+
+	Effects []AbilityEffectSeed `yaml:"effects,omitempty"`
 }
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/dice"
+)
+
+// CombatSimulationRequest is the body of POST /simulate/combat. Every weapon
+// of the attacker attacks the defender once per iteration. Iterations
+// defaults to 10000, and a random seed is picked and returned when none is
+// given.
+type CombatSimulationRequest struct {
+	Attacker   SimulationUnit `json:"attacker"`
+	Defender   SimulationUnit `json:"defender"`
+	Iterations int            `json:"iterations"`
+	Seed       *int64         `json:"seed"`
+	Crit       string         `json:"crit"`
+}
+
+// SimulationUnit names a unit directly, or an entry of a saved army through
+// ArmyID and ArmyUnitID, whose model count then follows the entry's unit
+// size and reinforcement. ModelCount overrides either.
+type SimulationUnit struct {
+	UnitID     uuid.UUID `json:"unit_id"`
+	ArmyID     uuid.UUID `json:"army_id"`
+	ArmyUnitID uuid.UUID `json:"army_unit_id"`
+	ModelCount int       `json:"model_count"`
+}
+
+// SimulatedUnit is a unit as it took part in the simulation.
+type SimulatedUnit struct {
+	UnitID     uuid.UUID `json:"unit_id"`
+	Name       string    `json:"name"`
+	ModelCount int       `json:"model_count"`
+	Health     string    `json:"health,omitempty"`
+}
+
+// Percentiles of a simulated result.
+type Percentiles struct {
+	P10 int `json:"p10"`
+	P25 int `json:"p25"`
+	P50 int `json:"p50"`
+	P75 int `json:"p75"`
+	P90 int `json:"p90"`
+}
+
+// SimulatedWeapon is a weapon of the attacker. Weapons whose profile cannot
+// be rolled are listed with the reason in Skipped and do not attack.
+type SimulatedWeapon struct {
+	WeaponID uuid.UUID `json:"weapon_id"`
+	Name     string    `json:"name"`
+	Crit     string    `json:"crit"`
+	Skipped  string    `json:"skipped,omitempty"`
+}
+
+// CombatSimulationResponse holds how often the defender was destroyed and
+// how many of its models were slain, and how much damage it took, across
+// the iterations. Running the same request with the same seed gives the same
+// response.
+type CombatSimulationResponse struct {
+	Seed                   int64             `json:"seed"`
+	Iterations             int               `json:"iterations"`
+	Attacker               SimulatedUnit     `json:"attacker"`
+	Defender               SimulatedUnit     `json:"defender"`
+	Weapons                []SimulatedWeapon `json:"weapons"`
+	KillProbability        float64           `json:"kill_probability"`
+	MeanModelsSlain        float64           `json:"mean_models_slain"`
+	ModelsSlain            dice.Distribution `json:"models_slain"`
+	ModelsSlainPercentiles Percentiles       `json:"models_slain_percentiles"`
+	MeanDamage             float64           `json:"mean_damage"`
+	Damage                 dice.Distribution `json:"damage"`
+	DamagePercentiles      Percentiles       `json:"damage_percentiles"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "github.com/google/uuid"
+
+// EffectSelection names the abilities, enhancements and battle formation
+// active on a unit. Effects whose condition is neither a weapon type, a
+// weapon name nor one of Conditions are left out.
+type EffectSelection struct {
+	AbilityIDs        []uuid.UUID `json:"ability_ids"`
+	EnhancementIDs    []uuid.UUID `json:"enhancement_ids"`
+	BattleFormationID uuid.UUID   `json:"battle_formation_id"`
+	Conditions        []string    `json:"conditions"`
+}
+
+// Effect sources.
+const (
+	EffectSourceAbility         = "ability"
+	EffectSourceEnhancement     = "enhancement"
+	EffectSourceBattleFormation = "battle_formation"
+)
+
+// StatChange records what one effect did to one stat line: the unit's, or a
+// weapon's when WeaponID is set. Effects that changed nothing are listed
+// with the reason.
+type StatChange struct {
+	EffectID   uuid.UUID  `json:"effect_id"`
+	SourceType string     `json:"source_type"`
+	SourceID   uuid.UUID  `json:"source_id"`
+	SourceName string     `json:"source_name"`
+	Stat       string     `json:"stat"`
+	Modifier   int        `json:"modifier"`
+	Condition  string     `json:"condition,omitempty"`
+	WeaponID   *uuid.UUID `json:"weapon_id,omitempty"`
+	Weapon     string     `json:"weapon,omitempty"`
+	From       string     `json:"from,omitempty"`
+	To         string     `json:"to,omitempty"`
+	Applied    bool       `json:"applied"`
+	Reason     string     `json:"reason,omitempty"`
+}
+
+// ResolvedUnit is a unit with the selected effects applied to its stat line
+// and weapons, raw and parsed, and the trace of every change.
+type ResolvedUnit struct {
+	Unit  Unit         `json:"unit"`
+	Trace []StatChange `json:"trace"`
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
 )
 
 type Unit struct {
@@ -27,13 +29,19 @@ type Unit struct {
 	Banishment      string            `json:"banishment"`  // Conditional: only when is_manifestation = true
 	MinUnitSize     int               `json:"min_unit_size"`
 	MaxUnitSize     int               `json:"max_unit_size"`
+	CanBeReinforced bool              `json:"can_be_reinforced"`
 	MatchedPlay     bool              `json:"matched_play"`
 	Version         string            `json:"version"`
 	Source          string            `json:"source"`
+	BattlescribeID  string            `json:"battlescribe_id,omitempty"`
 	CreatedAt       time.Time         `json:"created_at"`
 	UpdatedAt       time.Time         `json:"updated_at"`
 
-	Weapons   []Weapon      `json:"weapons,omitempty"`
-	Abilities []Ability     `json:"abilities,omitempty"`
-	Keywords  []UnitKeyword `json:"keywords,omitempty"`
+	// Stats is the stat line above parsed into typed values.
+	Stats stats.UnitStats `json:"stats"`
+
+	Weapons         []Weapon         `json:"weapons,omitempty"`
+	Abilities       []Ability        `json:"abilities,omitempty"`
+	Keywords        []UnitKeyword    `json:"keywords,omitempty"`
+	RegimentOptions []RegimentOption `json:"regiment_options,omitempty"`
 }
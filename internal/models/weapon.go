@@ -4,20 +4,27 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
 )
 
 type Weapon struct {
 	ID            uuid.UUID `json:"id"`
 	UnitID        uuid.UUID `json:"unit_id"`
 	Name          string    `json:"name"`
+	WeaponType    string    `json:"weapon_type"`
 	Range         string    `json:"range"`
 	Attacks       string    `json:"attacks"`
 	HitStats      string    `json:"hit_stats"`
 	WoundStrength string    `json:"wound_strength"`
 	RendAP        string    `json:"rend_ap"`
 	Damage        string    `json:"damage"`
+	Abilities     string    `json:"abilities"`
 	Version       string    `json:"version"`
 	Source        string    `json:"source"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
+
+	// Stats is the profile above parsed into typed values.
+	Stats stats.WeaponStats `json:"stats"`
 }
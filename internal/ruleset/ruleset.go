@@ -0,0 +1,179 @@
+// Package ruleset describes which army validation rules apply to a game.
+// Rulesets are YAML files; the ones under rulesets/ are built in.
+package ruleset
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity decides whether a rule's findings make a list invalid. Only
+// errors do; warnings and info are reported alongside.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Ruleset is the rules checked for the games it names. The Default ruleset
+// is used for games no ruleset names.
+type Ruleset struct {
+	Name    string   `yaml:"name"`
+	Games   []string `yaml:"games"`
+	Default bool     `yaml:"default"`
+	Rules   []Rule   `yaml:"rules"`
+}
+
+// Rule turns on the check with the given ID. A missing severity is an error.
+type Rule struct {
+	ID       string   `yaml:"id"`
+	Severity Severity `yaml:"severity"`
+	Params   Params   `yaml:"params"`
+}
+
+// Params configures a rule. Each rule reads only the fields it documents and
+// falls back to its own default for the zero value.
+type Params struct {
+	Max            int           `yaml:"max"`
+	GeneralMax     int           `yaml:"general_max"`
+	Keywords       []string      `yaml:"keywords"`
+	ExemptKeywords []string      `yaml:"exempt_keywords"`
+	ExemptMax      int           `yaml:"exempt_max"`
+	Limits         []PointsLimit `yaml:"limits"`
+}
+
+// PointsLimit caps something at Max for armies of up to Points points.
+type PointsLimit struct {
+	Points int `yaml:"points"`
+	Max    int `yaml:"max"`
+}
+
+// Registry holds the rulesets a server knows about.
+type Registry struct {
+	rulesets []Ruleset
+}
+
+// New returns a registry of the given rulesets.
+func New(rulesets ...Ruleset) *Registry {
+	return &Registry{rulesets: rulesets}
+}
+
+//go:embed rulesets/*.yaml
+var builtinFS embed.FS
+
+var builtin = sync.OnceValues(func() (*Registry, error) {
+	return load(builtinFS, "rulesets/*.yaml")
+})
+
+// Builtin returns the rulesets shipped with the API.
+func Builtin() (*Registry, error) {
+	return builtin()
+}
+
+// LoadDir reads every .yaml file in dir as a ruleset.
+func LoadDir(dir string) (*Registry, error) {
+	return load(os.DirFS(dir), "*.yaml")
+}
+
+func load(fsys fs.FS, pattern string) (*Registry, error) {
+	paths, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no rulesets match %s", pattern)
+	}
+
+	reg := &Registry{}
+	for _, path := range paths {
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+
+		rs, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		reg.rulesets = append(reg.rulesets, rs)
+	}
+
+	defaults := 0
+	for _, rs := range reg.rulesets {
+		if rs.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return nil, fmt.Errorf("%d rulesets are marked default, expected at most one", defaults)
+	}
+
+	return reg, nil
+}
+
+// Parse reads one ruleset.
+func Parse(data []byte) (Ruleset, error) {
+	var rs Ruleset
+	err := yaml.Unmarshal(data, &rs)
+	if err != nil {
+		return Ruleset{}, err
+	}
+
+	if rs.Name == "" {
+		return Ruleset{}, fmt.Errorf("ruleset has no name")
+	}
+
+	seen := make(map[string]bool, len(rs.Rules))
+	for i, r := range rs.Rules {
+		if r.ID == "" {
+			return Ruleset{}, fmt.Errorf("rule %d of %s has no id", i+1, rs.Name)
+		}
+		if seen[r.ID] {
+			return Ruleset{}, fmt.Errorf("rule %s is listed twice in %s", r.ID, rs.Name)
+		}
+		seen[r.ID] = true
+
+		switch r.Severity {
+		case "":
+			rs.Rules[i].Severity = SeverityError
+		case SeverityError, SeverityWarning, SeverityInfo:
+		default:
+			return Ruleset{}, fmt.Errorf("rule %s has unknown severity %q", r.ID, r.Severity)
+		}
+	}
+
+	return rs, nil
+}
+
+// Rulesets returns every ruleset in the registry.
+func (r *Registry) Rulesets() []Ruleset {
+	return r.rulesets
+}
+
+// ForGame returns the ruleset naming game, ignoring case, or else the
+// default one.
+func (r *Registry) ForGame(game string) (Ruleset, bool) {
+	for _, rs := range r.rulesets {
+		for _, g := range rs.Games {
+			if strings.EqualFold(g, game) {
+				return rs, true
+			}
+		}
+	}
+
+	for _, rs := range r.rulesets {
+		if rs.Default {
+			return rs, true
+		}
+	}
+
+	return Ruleset{}, false
+}
@@ -0,0 +1,84 @@
+package ruleset
+
+import (
+	"testing"
+)
+
+func TestBuiltin(t *testing.T) {
+	reg, err := Builtin()
+	if err != nil {
+		t.Fatalf("failed to load built-in rulesets: %v", err)
+	}
+
+	tests := []struct {
+		game     string
+		expected string
+	}{
+		{game: "Age of Sigmar 4.0", expected: "Age of Sigmar"},
+		{game: "warhammer 40,000", expected: "Warhammer 40,000"},
+		{game: "Unknown Game", expected: "Age of Sigmar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.game, func(t *testing.T) {
+			rs, ok := reg.ForGame(tt.game)
+			if !ok {
+				t.Fatalf("expected a ruleset for %s", tt.game)
+			}
+			if rs.Name != tt.expected {
+				t.Errorf("expected ruleset %s, got %s", tt.expected, rs.Name)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	rs, err := Parse([]byte(`
+name: Test
+rules:
+  - id: points_limit
+  - id: unique_units
+    severity: warning
+    params:
+      max: 2
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rs.Rules[0].Severity != SeverityError {
+		t.Errorf("expected missing severity to default to error, got %s", rs.Rules[0].Severity)
+	}
+	if rs.Rules[1].Severity != SeverityWarning || rs.Rules[1].Params.Max != 2 {
+		t.Errorf("expected a warning with max 2, got %+v", rs.Rules[1])
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "No Name", data: "rules:\n  - id: points_limit\n"},
+		{name: "No Rule ID", data: "name: Test\nrules:\n  - severity: error\n"},
+		{name: "Duplicate Rule", data: "name: Test\nrules:\n  - id: points_limit\n  - id: points_limit\n"},
+		{name: "Unknown Severity", data: "name: Test\nrules:\n  - id: points_limit\n    severity: fatal\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestForGame_NoDefault(t *testing.T) {
+	reg := New(Ruleset{Name: "Test", Games: []string{"Test Game"}})
+
+	if _, ok := reg.ForGame("Other Game"); ok {
+		t.Errorf("expected no ruleset without a default")
+	}
+}
@@ -15,16 +15,18 @@ import (
 
 func mapDBAbilityEffectToModel(e database.AbilityEffect) models.AbilityEffect {
 	return models.AbilityEffect{
-		ID:          e.ID,
-		AbilityID:   e.AbilityID,
-		Stat:        e.Stat,
-		Modifier:    int(e.Modifier),
-		Condition:   e.Condition,
-		Description: e.Description,
-		Version:     e.Version,
-		Source:      e.Source,
-		CreatedAt:   e.CreatedAt,
-		UpdatedAt:   e.UpdatedAt,
+		ID:                e.ID,
+		AbilityID:         database.NullUUIDToPtr(e.AbilityID),
+		EnhancementID:     database.NullUUIDToPtr(e.EnhancementID),
+		BattleFormationID: database.NullUUIDToPtr(e.BattleFormationID),
+		Stat:              e.Stat,
+		Modifier:          int(e.Modifier),
+		Condition:         e.Condition,
+		Description:       e.Description,
+		Version:           e.Version,
+		Source:            e.Source,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
 	}
 }
 
@@ -51,7 +53,59 @@ func GetAbilityEffectsForAbility(s *state.State, ctx context.Context, abilityID
 		return nil, appErr.ErrMissingID
 	}
 
-	dbEffects, err := s.DB.GetAbilityEffectsForAbility(ctx, abilityID)
+	dbEffects, err := s.DB.GetAbilityEffectsForAbility(ctx, database.UUIDToNullUUID(abilityID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []models.AbilityEffect{}, nil
+		}
+
+		return nil, err
+	}
+
+	if dbEffects == nil {
+		return []models.AbilityEffect{}, nil
+	}
+
+	effects := make([]models.AbilityEffect, len(dbEffects))
+	for i, e := range dbEffects {
+		effects[i] = mapDBAbilityEffectToModel(e)
+	}
+
+	return effects, nil
+}
+
+func GetAbilityEffectsForEnhancement(s *state.State, ctx context.Context, enhancementID uuid.UUID) ([]models.AbilityEffect, error) {
+	if enhancementID == uuid.Nil {
+		return nil, appErr.ErrMissingID
+	}
+
+	dbEffects, err := s.DB.GetAbilityEffectsForEnhancement(ctx, database.UUIDToNullUUID(enhancementID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []models.AbilityEffect{}, nil
+		}
+
+		return nil, err
+	}
+
+	if dbEffects == nil {
+		return []models.AbilityEffect{}, nil
+	}
+
+	effects := make([]models.AbilityEffect, len(dbEffects))
+	for i, e := range dbEffects {
+		effects[i] = mapDBAbilityEffectToModel(e)
+	}
+
+	return effects, nil
+}
+
+func GetAbilityEffectsForBattleFormation(s *state.State, ctx context.Context, battleFormationID uuid.UUID) ([]models.AbilityEffect, error) {
+	if battleFormationID == uuid.Nil {
+		return nil, appErr.ErrMissingID
+	}
+
+	dbEffects, err := s.DB.GetAbilityEffectsForBattleFormation(ctx, database.UUIDToNullUUID(battleFormationID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return []models.AbilityEffect{}, nil
@@ -150,6 +204,34 @@ func GetAbilitiesForFaction(s *state.State, ctx context.Context, factionID uuid.
 	return abilities, nil
 }
 
+func GetAbilitiesForGame(s *state.State, ctx context.Context, gameID uuid.UUID) ([]models.Ability, error) {
+	if gameID == uuid.Nil {
+		return nil, appErr.ErrMissingID
+	}
+
+	dbAbilities, err := s.DB.GetAbilitiesForGame(ctx, database.UUIDToNullUUID(gameID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []models.Ability{}, nil
+		}
+
+		return nil, err
+	}
+
+	if dbAbilities == nil {
+		return []models.Ability{}, nil
+	}
+
+	abilities := make([]models.Ability, len(dbAbilities))
+	for i, a := range dbAbilities {
+		effects, _ := GetAbilityEffectsForAbility(s, ctx, a.ID)
+
+		abilities[i] = mapDBAbilityToModel(a, effects)
+	}
+
+	return abilities, nil
+}
+
 func GetAbilityByID(s *state.State, ctx context.Context, id uuid.UUID) (models.Ability, error) {
 	if id == uuid.Nil {
 		return models.Ability{}, appErr.ErrMissingID
@@ -223,3 +305,36 @@ func GetAbilitiesByPhase(s *state.State, ctx context.Context, phase string) ([]m
 	}
 	return abilities, nil
 }
+
+// ListAbilities returns a page of abilities with their effects.
+func ListAbilities(s *state.State, ctx context.Context, p models.ListParams) (models.Page[models.Ability], error) {
+	lp, err := newListPage(p, "name", "created_at")
+	if err != nil {
+		return models.Page[models.Ability]{}, err
+	}
+
+	f := p.Filters
+	dbAbilities, err := s.DB.ListAbilities(ctx, database.ListAbilitiesParams{
+		UnitID:         nullUUID(f.UnitID),
+		FactionID:      nullUUID(f.FactionID),
+		GameID:         nullUUID(f.GameID),
+		Type:           nullText(f.Type),
+		Phase:          nullText(f.Phase),
+		Allegiance:     nullText(f.Allegiance),
+		AfterID:        lp.afterID(),
+		Sort:           lp.sort,
+		AfterName:      lp.afterName(),
+		AfterCreatedAt: lp.afterCreatedAt(),
+		PageSize:       lp.pageSize(),
+	})
+	if err != nil {
+		return models.Page[models.Ability]{}, err
+	}
+
+	return paginate(lp, dbAbilities, func(a database.Ability) listCursor {
+		return listCursor{ID: a.ID, Name: a.Name, CreatedAt: a.CreatedAt}
+	}, func(a database.Ability) models.Ability {
+		effects, _ := GetAbilityEffectsForAbility(s, ctx, a.ID)
+		return mapDBAbilityToModel(a, effects)
+	}), nil
+}
@@ -54,7 +54,7 @@ func TestGetAbilityByID_WithEffects(t *testing.T) {
 	abilityID := createTestAbilityUnit(t, s, unitID)
 
 	_, err := s.DB.CreateAbilityEffect(ctx, database.CreateAbilityEffectParams{
-		AbilityID:   abilityID,
+		AbilityID:   database.UUIDToNullUUID(abilityID),
 		Stat:        "Save",
 		Modifier:    1,
 		Condition:   "7+ prayer points",
@@ -67,7 +67,7 @@ func TestGetAbilityByID_WithEffects(t *testing.T) {
 	}
 
 	_, err = s.DB.CreateAbilityEffect(ctx, database.CreateAbilityEffectParams{
-		AbilityID:   abilityID,
+		AbilityID:   database.UUIDToNullUUID(abilityID),
 		Stat:        "Rend",
 		Modifier:    1,
 		Condition:   "7+ prayer points",
@@ -100,7 +100,7 @@ func TestGetAbilityByID_WithEffects(t *testing.T) {
 	foundRend := false
 
 	for _, eff := range ability.Effects {
-		if eff.AbilityID != abilityID {
+		if eff.AbilityID == nil || *eff.AbilityID != abilityID {
 			t.Errorf("effect %v is linked to wrong ability: expected %v, got %v", eff.ID, abilityID, eff.AbilityID)
 		}
 
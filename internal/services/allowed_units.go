@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/database"
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+func mapDBAllowedUnitToModel(a database.FactionAllowedUnit) models.AllowedUnit {
+	return models.AllowedUnit{
+		ID:             a.ID,
+		FactionID:      a.FactionID,
+		UnitName:       a.UnitName,
+		BattlescribeID: a.BattlescribeID,
+	}
+}
+
+func GetAllowedUnitsForFaction(s *state.State, ctx context.Context, factionID uuid.UUID) ([]models.AllowedUnit, error) {
+	if factionID == uuid.Nil {
+		return nil, appErr.ErrMissingFactionID
+	}
+
+	dbAllowed, err := s.DB.GetAllowedUnitsForFaction(ctx, factionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []models.AllowedUnit{}, nil
+		}
+		return nil, err
+	}
+
+	allowed := make([]models.AllowedUnit, len(dbAllowed))
+	for i, a := range dbAllowed {
+		allowed[i] = mapDBAllowedUnitToModel(a)
+	}
+
+	return allowed, nil
+}
+
+// GetFactionRoster returns every unit a list for the faction can take. For an
+// Army of Renown that is its own units plus the parent faction units it
+// permits; parent units sharing a name with one of its own are left out.
+func GetFactionRoster(s *state.State, ctx context.Context, factionID uuid.UUID) ([]models.Unit, error) {
+	faction, err := GetFactionByID(s, ctx, factionID)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := GetUnitsByFaction(s, ctx, factionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !faction.IsArmyOfRenown || faction.ParentFactionID == nil {
+		return units, nil
+	}
+
+	parentUnits, err := GetUnitsByFaction(s, ctx, *faction.ParentFactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	own := make(map[string]bool, len(units))
+	for _, u := range units {
+		own[u.Name] = true
+	}
+
+	for _, u := range parentUnits {
+		if !own[u.Name] && factionPermitsUnit(faction, u) {
+			units = append(units, u)
+		}
+	}
+
+	sort.Slice(units, func(i, j int) bool { return units[i].Name < units[j].Name })
+	return units, nil
+}
+
+// factionPermitsUnit reports whether a list for faction can take unit. An Army
+// of Renown can also take the parent faction units on its allow-list, or any
+// of them when no allow-list was captured for it.
+func factionPermitsUnit(faction models.Faction, unit models.Unit) bool {
+	if unit.FactionID == faction.ID {
+		return true
+	}
+
+	if !isParentUnit(faction, unit) {
+		return false
+	}
+
+	if len(faction.AllowedUnits) == 0 {
+		return true
+	}
+
+	for _, a := range faction.AllowedUnits {
+		if a.BattlescribeID != "" && unit.BattlescribeID != "" {
+			if a.BattlescribeID == unit.BattlescribeID {
+				return true
+			}
+			continue
+		}
+		if a.UnitName == unit.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isParentUnit(faction models.Faction, unit models.Unit) bool {
+	return faction.IsArmyOfRenown && faction.ParentFactionID != nil && unit.FactionID == *faction.ParentFactionID
+}
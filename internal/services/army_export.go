@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+// Formats GET /armies/{id}/export can render a list in.
+const (
+	ExportText     = "text"
+	ExportMarkdown = "markdown"
+	ExportJSON     = "json"
+)
+
+const exportFooter = "Created with Army Builder API"
+
+// GetArmyListExport loads a saved list with its faction, battle formation,
+// Regiment of Renown, units and enhancements.
+func GetArmyListExport(s *state.State, ctx context.Context, id uuid.UUID) (models.ArmyListExport, error) {
+	list, err := GetArmyListByID(s, ctx, id)
+	if err != nil {
+		return models.ArmyListExport{}, err
+	}
+
+	faction, err := GetFactionByID(s, ctx, list.FactionID)
+	if err != nil {
+		return models.ArmyListExport{}, err
+	}
+
+	export := models.ArmyListExport{
+		Army:         list,
+		Faction:      faction,
+		Units:        []models.Unit{},
+		Enhancements: []models.Enhancement{},
+	}
+
+	if list.BattleFormationID != nil {
+		formation, err := GetBattleFormationByID(s, ctx, *list.BattleFormationID)
+		if err != nil {
+			return models.ArmyListExport{}, err
+		}
+		export.BattleFormation = &formation
+	}
+
+	if list.RegimentOfRenownID != nil {
+		ror, err := GetFactionByID(s, ctx, *list.RegimentOfRenownID)
+		if err != nil {
+			return models.ArmyListExport{}, err
+		}
+		export.RegimentOfRenown = &ror
+	}
+
+	seenUnits := make(map[uuid.UUID]bool)
+	seenEnhancements := make(map[uuid.UUID]bool)
+	for _, u := range armyListUnits(list) {
+		if !seenUnits[u.UnitID] {
+			seenUnits[u.UnitID] = true
+			unit, err := GetUnitByID(s, ctx, u.UnitID)
+			if err != nil {
+				return models.ArmyListExport{}, err
+			}
+			export.Units = append(export.Units, unit)
+		}
+
+		for _, id := range u.EnhancementIDs {
+			if seenEnhancements[id] {
+				continue
+			}
+			seenEnhancements[id] = true
+			enhancement, err := GetEnhancementByID(s, ctx, id)
+			if err != nil {
+				return models.ArmyListExport{}, err
+			}
+			export.Enhancements = append(export.Enhancements, enhancement)
+		}
+	}
+
+	return export, nil
+}
+
+// RenderArmyListExport renders export in the given format: the text layout
+// of the official app, which POST /armies/import reads back, or a Markdown
+// sheet with every unit's profile.
+func RenderArmyListExport(export models.ArmyListExport, format string) (string, error) {
+	switch format {
+	case ExportText:
+		return renderArmyListText(export), nil
+	case ExportMarkdown:
+		return renderArmyListMarkdown(export), nil
+	default:
+		return "", appErr.ErrUnsupportedFormat
+	}
+}
+
+func renderArmyListText(export models.ArmyListExport) string {
+	list := export.Army
+	units := exportUnitsByID(export)
+	enhancements := exportEnhancementsByID(export)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d/%d pts\n\n", list.Name, list.TotalPoints, list.PointsLimit)
+	fmt.Fprintln(&b, export.Faction.Name)
+	if export.BattleFormation != nil {
+		fmt.Fprintln(&b, export.BattleFormation.Name)
+	}
+	fmt.Fprintf(&b, "Drops: %d\n", list.Validation.Drops)
+
+	writeUnit := func(u models.ArmyListUnit, isGeneral bool) {
+		unit := units[u.UnitID]
+		if u.Quantity > 1 {
+			fmt.Fprintf(&b, "%dx ", u.Quantity)
+		}
+		fmt.Fprintf(&b, "%s (%d)\n", unit.Name, armyListUnitPoints(unit, u))
+		if isGeneral {
+			fmt.Fprintln(&b, "• General")
+		}
+		if u.Reinforced {
+			fmt.Fprintln(&b, "• Reinforced")
+		}
+		for _, id := range u.EnhancementIDs {
+			e := enhancements[id]
+			fmt.Fprintf(&b, "• %s: %s (%d)\n", e.EnhancementType, e.Name, e.Points)
+		}
+	}
+
+	regimentNumber := 0
+	for _, reg := range list.Regiments {
+		b.WriteString("\n")
+		if reg.IsGeneral {
+			fmt.Fprintln(&b, "General's Regiment")
+		} else {
+			regimentNumber++
+			fmt.Fprintf(&b, "Regiment %d\n", regimentNumber)
+		}
+		for i, u := range reg.Units {
+			writeUnit(u, reg.IsGeneral && i == 0)
+		}
+	}
+
+	if len(list.Auxiliaries) > 0 {
+		b.WriteString("\nAuxiliary Units\n")
+		for _, u := range list.Auxiliaries {
+			writeUnit(u, false)
+		}
+	}
+
+	if export.RegimentOfRenown != nil {
+		fmt.Fprintf(&b, "\nRegiment of Renown\n%s (%d)\n", export.RegimentOfRenown.Name, export.RegimentOfRenown.Points)
+		for _, u := range list.RegimentOfRenownUnits {
+			writeUnit(u, false)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%s\n", exportFooter)
+	return b.String()
+}
+
+func renderArmyListMarkdown(export models.ArmyListExport) string {
+	list := export.Army
+	units := exportUnitsByID(export)
+	enhancements := exportEnhancementsByID(export)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", markdownEscape(list.Name))
+	fmt.Fprintf(&b, "- **Faction:** %s\n", markdownEscape(export.Faction.Name))
+	if export.BattleFormation != nil {
+		fmt.Fprintf(&b, "- **Battle Formation:** %s\n", markdownEscape(export.BattleFormation.Name))
+	}
+	if export.RegimentOfRenown != nil {
+		fmt.Fprintf(&b, "- **Regiment of Renown:** %s (%d pts)\n", markdownEscape(export.RegimentOfRenown.Name), export.RegimentOfRenown.Points)
+	}
+	fmt.Fprintf(&b, "- **Points:** %d / %d\n", list.TotalPoints, list.PointsLimit)
+	fmt.Fprintf(&b, "- **Drops:** %d\n", list.Validation.Drops)
+
+	if list.IsValid {
+		b.WriteString("- **Validation:** Valid\n")
+	} else {
+		b.WriteString("- **Validation:** Invalid\n")
+	}
+	for _, issue := range list.Validation.Issues {
+		fmt.Fprintf(&b, "  - %s: %s\n", issue.Severity, markdownEscape(issue.Message))
+	}
+
+	writeUnit := func(u models.ArmyListUnit, isGeneral bool) {
+		unit := units[u.UnitID]
+
+		b.WriteString("\n### ")
+		if u.Quantity > 1 {
+			fmt.Fprintf(&b, "%dx ", u.Quantity)
+		}
+		fmt.Fprintf(&b, "%s (%d pts)\n\n", markdownEscape(unit.Name), armyListUnitPoints(unit, u))
+
+		var notes []string
+		if isGeneral {
+			notes = append(notes, "General")
+		}
+		if u.Reinforced {
+			notes = append(notes, "Reinforced")
+		}
+		for _, id := range u.EnhancementIDs {
+			e := enhancements[id]
+			notes = append(notes, fmt.Sprintf("%s: %s (%d pts)", e.EnhancementType, markdownEscape(e.Name), e.Points))
+		}
+		if len(notes) > 0 {
+			fmt.Fprintf(&b, "*%s*\n\n", strings.Join(notes, " · "))
+		}
+
+		writeMarkdownUnit(&b, unit)
+	}
+
+	regimentNumber := 0
+	for _, reg := range list.Regiments {
+		if reg.IsGeneral {
+			b.WriteString("\n## General's Regiment\n")
+		} else {
+			regimentNumber++
+			fmt.Fprintf(&b, "\n## Regiment %d\n", regimentNumber)
+		}
+		for i, u := range reg.Units {
+			writeUnit(u, reg.IsGeneral && i == 0)
+		}
+	}
+
+	if len(list.Auxiliaries) > 0 {
+		b.WriteString("\n## Auxiliary Units\n")
+		for _, u := range list.Auxiliaries {
+			writeUnit(u, false)
+		}
+	}
+
+	if export.RegimentOfRenown != nil {
+		fmt.Fprintf(&b, "\n## Regiment of Renown: %s (%d pts)\n", markdownEscape(export.RegimentOfRenown.Name), export.RegimentOfRenown.Points)
+		for _, u := range list.RegimentOfRenownUnits {
+			writeUnit(u, false)
+		}
+	}
+
+	return b.String()
+}
+
+// writeMarkdownUnit writes the stat line, weapons, abilities and keywords of
+// unit. Stats the unit's game does not use are left out.
+func writeMarkdownUnit(b *strings.Builder, unit models.Unit) {
+	stats := []struct{ name, value string }{
+		{"Move", unit.Move},
+		{"Toughness", unit.Toughness},
+		{"Health", unit.HealthWounds},
+		{"Save", unit.Save},
+		{"Invuln", unit.InvulnSave},
+		{"Ward", unit.WardFNP},
+		{"Leadership", unit.Leadership},
+		{"Control", unit.ControlOC},
+	}
+
+	var header, values []string
+	for _, stat := range stats {
+		if stat.value == "" {
+			continue
+		}
+		header = append(header, stat.name)
+		values = append(values, markdownEscape(stat.value))
+	}
+	if len(header) > 0 {
+		writeMarkdownTable(b, header, [][]string{values})
+	}
+
+	if len(unit.Weapons) > 0 {
+		rows := make([][]string, len(unit.Weapons))
+		for i, w := range unit.Weapons {
+			rows[i] = []string{w.Name, w.WeaponType, w.Range, w.Attacks, w.HitStats, w.WoundStrength, w.RendAP, w.Damage, w.Abilities}
+			for j := range rows[i] {
+				rows[i][j] = markdownEscape(rows[i][j])
+			}
+		}
+		b.WriteString("\n")
+		writeMarkdownTable(b, []string{"Weapon", "Type", "Range", "Attacks", "Hit", "Wound", "Rend", "Damage", "Abilities"}, rows)
+	}
+
+	if len(unit.Abilities) > 0 {
+		b.WriteString("\n**Abilities**\n\n")
+		for _, a := range unit.Abilities {
+			fmt.Fprintf(b, "- **%s**", markdownEscape(a.Name))
+			if a.Phase != "" {
+				fmt.Fprintf(b, " (%s)", markdownEscape(a.Phase))
+			}
+			if a.Description != "" {
+				fmt.Fprintf(b, ": %s", markdownEscape(a.Description))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(unit.Keywords) > 0 {
+		names := make([]string, len(unit.Keywords))
+		for i, k := range unit.Keywords {
+			names[i] = k.KeywordName
+		}
+		fmt.Fprintf(b, "\n**Keywords:** %s\n", markdownEscape(strings.Join(names, ", ")))
+	}
+}
+
+func writeMarkdownTable(b *strings.Builder, header []string, rows [][]string) {
+	fmt.Fprintf(b, "| %s |\n", strings.Join(header, " | "))
+	fmt.Fprintf(b, "|%s\n", strings.Repeat(" --- |", len(header)))
+	for _, row := range rows {
+		fmt.Fprintf(b, "| %s |\n", strings.Join(row, " | "))
+	}
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "*", `\*`, "_", `\_`, "\n", " ")
+
+func markdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// armyListUnitPoints is what the entries of u cost, reinforcement included.
+func armyListUnitPoints(unit models.Unit, u models.ArmyListUnit) int {
+	points := unit.Points * max(u.Quantity, 1)
+	if u.Reinforced {
+		points *= 2
+	}
+	return points
+}
+
+// armyListUnits lists every unit of a saved list: regiments first, then
+// auxiliaries and the units of the Regiment of Renown.
+func armyListUnits(list models.ArmyList) []models.ArmyListUnit {
+	var all []models.ArmyListUnit
+	for _, reg := range list.Regiments {
+		all = append(all, reg.Units...)
+	}
+	all = append(all, list.Auxiliaries...)
+	return append(all, list.RegimentOfRenownUnits...)
+}
+
+func exportUnitsByID(export models.ArmyListExport) map[uuid.UUID]models.Unit {
+	units := make(map[uuid.UUID]models.Unit, len(export.Units))
+	for _, u := range export.Units {
+		units[u.ID] = u
+	}
+	return units
+}
+
+func exportEnhancementsByID(export models.ArmyListExport) map[uuid.UUID]models.Enhancement {
+	enhancements := make(map[uuid.UUID]models.Enhancement, len(export.Enhancements))
+	for _, e := range export.Enhancements {
+		enhancements[e.ID] = e
+	}
+	return enhancements
+}
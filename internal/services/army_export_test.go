@@ -0,0 +1,174 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+func testArmyListExport() models.ArmyListExport {
+	hero := models.Unit{
+		ID:           uuid.New(),
+		Name:         "Test Hero",
+		Move:         "5\"",
+		HealthWounds: "6",
+		Save:         "3+",
+		ControlOC:    "2",
+		Points:       150,
+		Weapons:      []models.Weapon{{Name: "Test Blade", WeaponType: "melee", Attacks: "4", HitStats: "3+", WoundStrength: "3+", RendAP: "1", Damage: "2"}},
+		Abilities:    []models.Ability{{Name: "Test Ability", Phase: "Combat", Description: "Does a thing."}},
+		Keywords:     []models.UnitKeyword{{KeywordName: "HERO"}},
+	}
+	unit := models.Unit{ID: uuid.New(), Name: "Test Unit", Points: 100}
+	enhancement := models.Enhancement{ID: uuid.New(), Name: "Test Trait", EnhancementType: "Heroic Trait", Points: 20}
+
+	return models.ArmyListExport{
+		Army: models.ArmyList{
+			Name:        "Test List",
+			PointsLimit: 2000,
+			TotalPoints: 570,
+			IsValid:     false,
+			Validation: models.ValidationResponse{
+				Drops:  2,
+				Issues: []models.ValidationIssue{{Severity: "error", Message: "Something is wrong"}},
+			},
+			Regiments: []models.ArmyRegiment{{
+				Name:      "Regiment 1",
+				IsGeneral: true,
+				Units: []models.ArmyListUnit{
+					{UnitID: hero.ID, Quantity: 1, EnhancementIDs: []uuid.UUID{enhancement.ID}},
+					{UnitID: unit.ID, Quantity: 1, Reinforced: true},
+				},
+			}},
+			Auxiliaries: []models.ArmyListUnit{{UnitID: unit.ID, Quantity: 2}},
+		},
+		Faction:         models.Faction{Name: "Test Faction"},
+		BattleFormation: &models.BattleFormation{Name: "Test Formation"},
+		Units:           []models.Unit{hero, unit},
+		Enhancements:    []models.Enhancement{enhancement},
+	}
+}
+
+func TestRenderArmyListExport_TextRoundTrip(t *testing.T) {
+	text, err := RenderArmyListExport(testArmyListExport(), ExportText)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list := parseArmyText(text)
+
+	if list.name != "Test List" || list.pointsLimit != 2000 {
+		t.Errorf("expected Test List at 2000 points, got %q at %d", list.name, list.pointsLimit)
+	}
+
+	if len(list.regiments) != 1 || !list.regiments[0].isGeneral || len(list.regiments[0].units) != 2 {
+		t.Fatalf("expected the general's regiment with 2 units, got %+v\n%s", list.regiments, text)
+	}
+
+	leader := list.regiments[0].units[0]
+	if leader.name != "Test Hero" || len(leader.enhancements) != 1 || leader.enhancements[0].name != "Test Trait" {
+		t.Errorf("expected the hero with its trait, got %+v", leader)
+	}
+
+	if reinforced := list.regiments[0].units[1]; !reinforced.reinforced || reinforced.points != 200 {
+		t.Errorf("expected a reinforced unit at 200 points, got %+v", reinforced)
+	}
+
+	if len(list.auxiliaries) != 1 || list.auxiliaries[0].quantity != 2 || list.auxiliaries[0].points != 200 {
+		t.Errorf("expected 2 auxiliary entries at 200 points, got %+v", list.auxiliaries)
+	}
+
+	if len(list.skipped) != 0 {
+		t.Errorf("expected nothing skipped, got %+v", list.skipped)
+	}
+}
+
+func TestRenderArmyListExport_Markdown(t *testing.T) {
+	markdown, err := RenderArmyListExport(testArmyListExport(), ExportMarkdown)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"# Test List",
+		"- **Battle Formation:** Test Formation",
+		"- **Points:** 570 / 2000",
+		"- **Drops:** 2",
+		"- **Validation:** Invalid",
+		"  - error: Something is wrong",
+		"## General's Regiment",
+		"### Test Hero (150 pts)",
+		"*General · Heroic Trait: Test Trait (20 pts)*",
+		"| Move | Health | Save | Control |",
+		"| Test Blade | melee |  | 4 | 3+ | 3+ | 1 | 2 |  |",
+		"- **Test Ability** (Combat): Does a thing.",
+		"**Keywords:** HERO",
+		"### 2x Test Unit (200 pts)",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestRenderArmyListExport_RegimentOfRenown(t *testing.T) {
+	export := testArmyListExport()
+	blade := models.Unit{ID: uuid.New(), Name: "Hired Blade", Points: 0}
+	export.Units = append(export.Units, blade)
+	export.RegimentOfRenown = &models.Faction{Name: "Hired Blades", Points: 150}
+	export.Army.RegimentOfRenownUnits = []models.ArmyListUnit{{UnitID: blade.ID, Quantity: 2}}
+
+	text, err := RenderArmyListExport(export, ExportText)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list := parseArmyText(text)
+	if len(list.renown) != 2 || list.renown[0].name != "Hired Blades" || list.renown[0].points != 150 {
+		t.Fatalf("expected the regiment followed by its unit, got %+v\n%s", list.renown, text)
+	}
+	if hired := list.renown[1]; hired.name != "Hired Blade" || hired.quantity != 2 || hired.points != 0 {
+		t.Errorf("expected 2 Hired Blade entries at 0 points, got %+v", hired)
+	}
+
+	markdown, err := RenderArmyListExport(export, ExportMarkdown)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"- **Regiment of Renown:** Hired Blades (150 pts)",
+		"## Regiment of Renown: Hired Blades (150 pts)",
+		"### 2x Hired Blade (0 pts)",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestArmyListUnits(t *testing.T) {
+	list := testArmyListExport().Army
+	hired := models.ArmyListUnit{UnitID: uuid.New(), Quantity: 1}
+	list.RegimentOfRenownUnits = []models.ArmyListUnit{hired}
+
+	units := armyListUnits(list)
+	if len(units) != 4 {
+		t.Fatalf("expected 4 units, got %d", len(units))
+	}
+	if units[3].UnitID != hired.UnitID {
+		t.Errorf("expected the Regiment of Renown unit last, got %+v", units[3])
+	}
+}
+
+func TestRenderArmyListExport_UnsupportedFormat(t *testing.T) {
+	_, err := RenderArmyListExport(testArmyListExport(), "pdf")
+	if !errors.Is(err, appErr.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
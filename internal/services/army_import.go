@@ -0,0 +1,484 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+const importDefaultName = "Imported List"
+
+var (
+	// "My List 1990/2000 pts"
+	importTitlePattern = regexp.MustCompile(`(?i)^(.*?)[\s\-–—:]*(\d+)\s*/\s*(\d+)\s*pts$`)
+	// "Liberators (120)", "2x Vindictors (260 pts)"
+	importUnitPattern = regexp.MustCompile(`(?i)^(?:(\d+)\s*x\s+)?(.+?)\s*\((\d+)(?:\s*pts)?\)$`)
+	// "• Reinforced", "- Heroic Trait: Mighty Warleader (20)"
+	importBulletPattern = regexp.MustCompile(`^[•·\-*]\s*(.+)$`)
+	// "Spell Lore - Lore of the Storm", "Drops: 3", "General's Handbook 2024-25"
+	importMetaPattern = regexp.MustCompile(`(?i)(:|\s[-–—]\s|^general's handbook|^battlescroll)`)
+)
+
+// importSection is the part of the exported text a line belongs to.
+type importSection int
+
+const (
+	importHeader importSection = iota
+	importRegiment
+	importAuxiliaries
+	importTerrain
+	importRenown
+	importFooter
+)
+
+type importLine struct {
+	number int
+	text   string
+}
+
+// importedUnit is a unit line and its bullets. points is -1 when the line
+// gives none.
+type importedUnit struct {
+	importLine
+	name         string
+	quantity     int
+	points       int
+	reinforced   bool
+	enhancements []importedEnhancement
+}
+
+type importedEnhancement struct {
+	importLine
+	name   string
+	points int
+}
+
+type importedRegiment struct {
+	importLine
+	isGeneral bool
+	units     []*importedUnit
+}
+
+// importedList is the exported text split into its parts, before any name is
+// looked up.
+type importedList struct {
+	name        string
+	pointsLimit int
+	header      []importLine
+	regiments   []*importedRegiment
+	auxiliaries []*importedUnit
+	renown      []importedUnit
+	skipped     []models.ImportReportLine
+}
+
+// parseArmyText splits a list exported by the official app into its parts.
+func parseArmyText(text string) importedList {
+	list := importedList{}
+	section := importHeader
+	var regiment *importedRegiment
+	var unit *importedUnit
+	titled := false
+
+	for i, raw := range strings.Split(text, "\n") {
+		line := importLine{number: i + 1, text: strings.TrimSpace(strings.ReplaceAll(raw, "’", "'"))}
+		if line.text == "" || section == importFooter {
+			continue
+		}
+
+		if !titled {
+			titled = true
+			if m := importTitlePattern.FindStringSubmatch(line.text); m != nil {
+				list.name = strings.TrimSpace(m[1])
+				list.pointsLimit, _ = strconv.Atoi(m[3])
+				continue
+			}
+		}
+
+		if next, isGeneral, ok := importSectionFor(line.text); ok {
+			section = next
+			unit = nil
+			if section == importRegiment {
+				regiment = &importedRegiment{importLine: line, isGeneral: isGeneral}
+				list.regiments = append(list.regiments, regiment)
+			}
+			continue
+		}
+
+		if m := importBulletPattern.FindStringSubmatch(line.text); m != nil {
+			switch {
+			case section == importRenown || section == importTerrain:
+			case unit == nil:
+				list.skipped = append(list.skipped, importReport(line, models.ImportSkipped, "option is not under a unit"))
+			default:
+				parseImportBullet(line, m[1], unit, regiment)
+			}
+			continue
+		}
+
+		m := importUnitPattern.FindStringSubmatch(line.text)
+		if m == nil && section == importHeader {
+			list.header = append(list.header, line)
+			continue
+		}
+
+		parsed := &importedUnit{importLine: line, name: line.text, quantity: 1, points: -1}
+		if m != nil {
+			parsed.name = m[2]
+			parsed.points, _ = strconv.Atoi(m[3])
+			if m[1] != "" {
+				parsed.quantity, _ = strconv.Atoi(m[1])
+			}
+		}
+
+		switch section {
+		case importRegiment:
+			regiment.units = append(regiment.units, parsed)
+		case importHeader, importAuxiliaries:
+			list.auxiliaries = append(list.auxiliaries, parsed)
+		case importRenown:
+			list.renown = append(list.renown, *parsed)
+		case importTerrain:
+			list.skipped = append(list.skipped, importReport(line, models.ImportSkipped, "faction terrain is not part of saved lists"))
+		}
+		unit = parsed
+	}
+
+	return list
+}
+
+// importSectionFor reports whether line starts a new part of the text.
+func importSectionFor(line string) (importSection, bool, bool) {
+	heading := strings.ToLower(strings.TrimSuffix(line, ":"))
+	switch {
+	case heading == "general's regiment":
+		return importRegiment, true, true
+	case strings.HasPrefix(heading, "regiment ") && isNumber(strings.TrimPrefix(heading, "regiment ")):
+		return importRegiment, false, true
+	case heading == "auxiliary units" || heading == "auxiliaries":
+		return importAuxiliaries, false, true
+	case heading == "faction terrain":
+		return importTerrain, false, true
+	case heading == "regiment of renown" || heading == "regiments of renown":
+		return importRenown, false, true
+	case strings.HasPrefix(heading, "created with"):
+		return importFooter, false, true
+	}
+	return 0, false, false
+}
+
+// parseImportBullet applies a bullet to the unit above it. "Label: Name"
+// bullets are enhancements; other bullets, such as wargear, are ignored.
+func parseImportBullet(line importLine, bullet string, unit *importedUnit, regiment *importedRegiment) {
+	switch strings.ToLower(bullet) {
+	case "general":
+		if regiment != nil {
+			regiment.isGeneral = true
+		}
+		return
+	case "reinforced":
+		unit.reinforced = true
+		return
+	}
+
+	_, name, ok := strings.Cut(bullet, ":")
+	if !ok {
+		return
+	}
+
+	enhancement := importedEnhancement{importLine: line, name: strings.TrimSpace(name), points: -1}
+	if m := importUnitPattern.FindStringSubmatch(enhancement.name); m != nil && m[1] == "" {
+		enhancement.name = m[2]
+		enhancement.points, _ = strconv.Atoi(m[3])
+	}
+	unit.enhancements = append(unit.enhancements, enhancement)
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func importReport(line importLine, status, msg string) models.ImportReportLine {
+	return models.ImportReportLine{Line: line.number, Text: line.text, Status: status, Message: msg}
+}
+
+// ImportArmyList turns a list exported by the official app into a list that
+// can be saved, matching its names against the faction's units, enhancements
+// and battle formations. Lines that match nothing, or more than one thing
+// equally well, are left out and reported.
+func ImportArmyList(s *state.State, ctx context.Context, req models.ArmyImportRequest) (models.ArmyImportResponse, error) {
+	if strings.TrimSpace(req.Text) == "" {
+		return models.ArmyImportResponse{}, appErr.ErrMissingText
+	}
+
+	list := parseArmyText(req.Text)
+	report := list.skipped
+
+	faction, header, err := importFaction(s, ctx, req, list.header)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+
+	army := models.ArmyListRequest{
+		GameID:      req.GameID,
+		FactionID:   faction.ID,
+		Name:        list.name,
+		PointsLimit: list.pointsLimit,
+		Regiments:   []models.ArmyRegimentRequest{},
+		Auxiliaries: []models.ArmyListUnitRequest{},
+	}
+	if army.GameID == uuid.Nil {
+		army.GameID = faction.GameID
+	}
+	if army.Name == "" {
+		army.Name = importDefaultName
+	}
+
+	roster, err := GetFactionRoster(s, ctx, faction.ID)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+	enhancements, err := GetEnhancementsByFaction(s, ctx, &faction.ID)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+	formations, err := GetBattleFormationsForFaction(s, ctx, faction.ID)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+
+	// The header names the faction and then the battle formation; the rest of
+	// it describes lores and the like, which lists do not store.
+	formationNames := make([]string, len(formations))
+	for i, f := range formations {
+		formationNames[i] = f.Name
+	}
+
+	for _, line := range header {
+		if army.BattleFormationID != nil || importMetaPattern.MatchString(line.text) {
+			continue
+		}
+
+		i, tied := matchName(line.text, formationNames)
+		switch {
+		case i >= 0:
+			army.BattleFormationID = &formations[i].ID
+		case len(tied) > 0:
+			report = append(report, ambiguousReport(line, "battle formation", tied))
+		default:
+			report = append(report, importReport(line, models.ImportUnmatched, "no unit, faction or battle formation has this name"))
+		}
+	}
+
+	unitNames := make([]string, len(roster))
+	for i, unit := range roster {
+		unitNames[i] = unit.Name
+	}
+	enhancementNames := make([]string, len(enhancements))
+	for i, e := range enhancements {
+		enhancementNames[i] = e.Name
+	}
+
+	matchUnit := func(u *importedUnit) (models.ArmyListUnitRequest, bool) {
+		i, tied := matchName(u.name, unitNames)
+		if i < 0 {
+			if len(tied) > 0 {
+				report = append(report, ambiguousReport(u.importLine, "unit", tied))
+			} else {
+				report = append(report, importReport(u.importLine, models.ImportUnmatched, fmt.Sprintf("no unit of %s is called %s", faction.Name, u.name)))
+			}
+			return models.ArmyListUnitRequest{}, false
+		}
+		unit := roster[i]
+
+		points := int(unit.Points) * u.quantity
+		if u.reinforced {
+			points *= 2
+		}
+		if u.points >= 0 && u.points != points {
+			report = append(report, importReport(u.importLine, models.ImportPointsMismatch, fmt.Sprintf("%s costs %d points, the text gives %d", unit.Name, points, u.points)))
+		}
+
+		picked := models.ArmyListUnitRequest{
+			UnitID:         unit.ID,
+			Quantity:       u.quantity,
+			Reinforced:     u.reinforced,
+			EnhancementIDs: []uuid.UUID{},
+		}
+
+		for _, e := range u.enhancements {
+			i, tied := matchName(e.name, enhancementNames)
+			switch {
+			case i >= 0:
+				picked.EnhancementIDs = append(picked.EnhancementIDs, enhancements[i].ID)
+				if e.points >= 0 && e.points != enhancements[i].Points {
+					report = append(report, importReport(e.importLine, models.ImportPointsMismatch, fmt.Sprintf("%s costs %d points, the text gives %d", enhancements[i].Name, enhancements[i].Points, e.points)))
+				}
+			case len(tied) > 0:
+				report = append(report, ambiguousReport(e.importLine, "enhancement", tied))
+			default:
+				report = append(report, importReport(e.importLine, models.ImportUnmatched, fmt.Sprintf("no enhancement of %s is called %s", faction.Name, e.name)))
+			}
+		}
+
+		return picked, true
+	}
+
+	for _, reg := range list.regiments {
+		regiment := models.ArmyRegimentRequest{
+			Name:      reg.text,
+			IsGeneral: reg.isGeneral,
+			Units:     []models.ArmyListUnitRequest{},
+		}
+		for _, u := range reg.units {
+			if picked, ok := matchUnit(u); ok {
+				regiment.Units = append(regiment.Units, picked)
+			}
+		}
+		army.Regiments = append(army.Regiments, regiment)
+	}
+
+	for _, u := range list.auxiliaries {
+		if picked, ok := matchUnit(u); ok {
+			army.Auxiliaries = append(army.Auxiliaries, picked)
+		}
+	}
+
+	// The Regiment of Renown section names the regiment, followed by the
+	// units taken from it.
+	if len(list.renown) > 0 {
+		isRegimentOfRenown := true
+		regiments, err := GetFactions(s, ctx, FactionFilter{GameID: &army.GameID, IsRegimentOfRenown: &isRegimentOfRenown})
+		if err != nil {
+			return models.ArmyImportResponse{}, err
+		}
+
+		names := make([]string, len(regiments))
+		for i, f := range regiments {
+			names[i] = f.Name
+		}
+
+		var hired models.Faction
+		var hiredUnits []models.Unit
+		var hiredNames []string
+		for _, line := range list.renown {
+			if army.RegimentOfRenownID != nil {
+				i, tied := matchName(line.name, hiredNames)
+				switch {
+				case i >= 0:
+					army.RegimentOfRenownUnits = append(army.RegimentOfRenownUnits, models.ArmyListUnitRequest{
+						UnitID:         hiredUnits[i].ID,
+						Quantity:       line.quantity,
+						Reinforced:     line.reinforced,
+						EnhancementIDs: []uuid.UUID{},
+					})
+					continue
+				case len(tied) > 0:
+					report = append(report, ambiguousReport(line.importLine, "unit", tied))
+					continue
+				}
+			}
+
+			i, tied := matchName(line.name, names)
+			switch {
+			case i >= 0 && army.RegimentOfRenownID != nil:
+				report = append(report, importReport(line.importLine, models.ImportSkipped, "a list can hire only one Regiment of Renown"))
+			case i >= 0:
+				hired = regiments[i]
+				army.RegimentOfRenownID = &hired.ID
+				hiredUnits, err = GetUnitsByFaction(s, ctx, hired.ID)
+				if err != nil {
+					return models.ArmyImportResponse{}, err
+				}
+				hiredNames = make([]string, len(hiredUnits))
+				for j, unit := range hiredUnits {
+					hiredNames[j] = unit.Name
+				}
+			case len(tied) > 0:
+				report = append(report, ambiguousReport(line.importLine, "Regiment of Renown", tied))
+			case army.RegimentOfRenownID != nil:
+				report = append(report, importReport(line.importLine, models.ImportUnmatched, fmt.Sprintf("no unit of %s is called %s", hired.Name, line.name)))
+			default:
+				report = append(report, importReport(line.importLine, models.ImportUnmatched, fmt.Sprintf("no Regiment of Renown is called %s", line.name)))
+			}
+		}
+	}
+
+	validation, err := ValidateArmy(s, ctx, armyListValidationRequest(army))
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+
+	if report == nil {
+		report = []models.ImportReportLine{}
+	}
+
+	return models.ArmyImportResponse{
+		Army:       army,
+		Validation: validation,
+		Report:     report,
+	}, nil
+}
+
+// importFaction loads the requested faction, or else finds the first header
+// line naming a faction of the requested game. It returns the header lines
+// left once the faction line is taken out.
+func importFaction(s *state.State, ctx context.Context, req models.ArmyImportRequest, header []importLine) (models.Faction, []importLine, error) {
+	if req.FactionID != uuid.Nil {
+		faction, err := GetFactionByID(s, ctx, req.FactionID)
+		if err != nil {
+			return models.Faction{}, nil, err
+		}
+
+		// Drop the faction line so it is not taken for a battle formation.
+		for i, line := range header {
+			if nameKey(line.text) == nameKey(faction.Name) {
+				return faction, append(header[:i:i], header[i+1:]...), nil
+			}
+		}
+		return faction, header, nil
+	}
+
+	filter := FactionFilter{}
+	if req.GameID != uuid.Nil {
+		filter.GameID = &req.GameID
+	}
+	factions, err := GetFactions(s, ctx, filter)
+	if err != nil {
+		return models.Faction{}, nil, err
+	}
+
+	names := make([]string, len(factions))
+	for i, f := range factions {
+		names[i] = f.Name
+	}
+
+	for i, line := range header {
+		if importMetaPattern.MatchString(line.text) {
+			continue
+		}
+		if j, _ := matchName(line.text, names); j >= 0 {
+			faction, err := GetFactionByID(s, ctx, factions[j].ID)
+			if err != nil {
+				return models.Faction{}, nil, err
+			}
+			return faction, append(header[:i:i], header[i+1:]...), nil
+		}
+	}
+
+	return models.Faction{}, nil, fmt.Errorf("no faction named in the text: %w", appErr.ErrMissingFactionID)
+}
+
+func ambiguousReport(line importLine, kind string, candidates []string) models.ImportReportLine {
+	r := importReport(line, models.ImportAmbiguous, fmt.Sprintf("more than one %s matches this name", kind))
+	r.Candidates = candidates
+	return r
+}
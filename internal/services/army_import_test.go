@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+const testArmyText = `Thunder List 1990/2000 pts
+
+Test Faction
+Test Battle Formation
+General's Handbook 2024-25
+Drops: 2
+Spell Lore - Lore of the Storm
+
+General's Regiment
+Leader (150)
+• General
+• Heroic Trait: Test Enhancment (20)
+Test Unit (180)
+• Reinforced
+
+Regiment 1
+Leader (150)
+Unknown Unit (100)
+
+Auxiliary Units
+2x Test Unit (200)
+
+Faction Terrain
+Test Terrain (0)
+
+Created with Warhammer Age of Sigmar: The App
+App: 1.19.0 | Data: 348`
+
+func TestParseArmyText(t *testing.T) {
+	list := parseArmyText(testArmyText)
+
+	if list.name != "Thunder List" || list.pointsLimit != 2000 {
+		t.Errorf("expected Thunder List at 2000 points, got %q at %d", list.name, list.pointsLimit)
+	}
+
+	if len(list.header) != 5 {
+		t.Errorf("expected 5 header lines, got %d", len(list.header))
+	}
+
+	if len(list.regiments) != 2 {
+		t.Fatalf("expected 2 regiments, got %d", len(list.regiments))
+	}
+
+	general := list.regiments[0]
+	if !general.isGeneral || len(general.units) != 2 {
+		t.Fatalf("expected the general's regiment with 2 units, got %+v", general)
+	}
+	if len(general.units[0].enhancements) != 1 || general.units[0].enhancements[0].points != 20 {
+		t.Errorf("expected the leader to have a 20 point enhancement, got %+v", general.units[0].enhancements)
+	}
+	if !general.units[1].reinforced || general.units[1].points != 180 {
+		t.Errorf("expected a reinforced unit at 180 points, got %+v", general.units[1])
+	}
+
+	if list.regiments[1].isGeneral {
+		t.Errorf("expected regiment 1 not to be the general's")
+	}
+
+	if len(list.auxiliaries) != 1 || list.auxiliaries[0].quantity != 2 {
+		t.Errorf("expected 2 entries of one auxiliary unit, got %+v", list.auxiliaries)
+	}
+
+	if len(list.skipped) != 1 || list.skipped[0].Status != models.ImportSkipped {
+		t.Errorf("expected the faction terrain to be skipped, got %+v", list.skipped)
+	}
+}
+
+func TestMatchName(t *testing.T) {
+	candidates := []string{"Liberators", "Lord-Vigilant on Gryph-stalker", "Lord-Relictor", "Lord-Celestant"}
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedIndex int
+		expectedTied  int
+	}{
+		{name: "Exact", query: "Liberators", expectedIndex: 0},
+		{name: "Case And Punctuation", query: "lord relictor", expectedIndex: 2},
+		{name: "Typo", query: "Liberaters", expectedIndex: 0},
+		{name: "Contained", query: "Lord-Vigilant", expectedIndex: 1},
+		{name: "Ambiguous", query: "Lord", expectedIndex: -1, expectedTied: 3},
+		{name: "Unmatched", query: "Vindictors", expectedIndex: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, tied := matchName(tt.query, candidates)
+			if i != tt.expectedIndex {
+				t.Errorf("expected index %d, got %d", tt.expectedIndex, i)
+			}
+			if len(tied) != tt.expectedTied {
+				t.Errorf("expected %d tied candidates, got %v", tt.expectedTied, tied)
+			}
+		})
+	}
+}
+
+func TestImportArmyList(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	heroID := createTestHero(t, s, gameID, factionID, "Leader")
+	unitID := createTestUnit(t, s, factionID)
+	enhancementID := createTestEnhancement(t, s, factionID, "Test Enhancement", "Heroic Trait", "[]")
+	formationID := createTestBattleFormation(t, s, gameID, factionID)
+
+	resp, err := ImportArmyList(s, ctx, models.ArmyImportRequest{GameID: gameID, Text: testArmyText})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	army := resp.Army
+	if army.FactionID != factionID || army.Name != "Thunder List" || army.PointsLimit != 2000 {
+		t.Errorf("expected Thunder List for the test faction at 2000 points, got %+v", army)
+	}
+
+	if army.BattleFormationID == nil || *army.BattleFormationID != formationID {
+		t.Errorf("expected battle formation %v, got %v", formationID, army.BattleFormationID)
+	}
+
+	if len(army.Regiments) != 2 || len(army.Regiments[0].Units) != 2 || len(army.Regiments[1].Units) != 1 {
+		t.Fatalf("expected regiments of 2 and 1 units, got %+v", army.Regiments)
+	}
+
+	leader := army.Regiments[0].Units[0]
+	if leader.UnitID != heroID || !slices.Equal(leader.EnhancementIDs, []uuid.UUID{enhancementID}) {
+		t.Errorf("expected the leader with its enhancement, got %+v", leader)
+	}
+
+	if !army.Regiments[0].Units[1].Reinforced || army.Regiments[0].Units[1].UnitID != unitID {
+		t.Errorf("expected the reinforced test unit, got %+v", army.Regiments[0].Units[1])
+	}
+
+	if len(army.Auxiliaries) != 1 || army.Auxiliaries[0].Quantity != 2 {
+		t.Errorf("expected 2 entries of one auxiliary unit, got %+v", army.Auxiliaries)
+	}
+
+	statuses := make(map[string]int)
+	for _, line := range resp.Report {
+		statuses[line.Status]++
+	}
+	// The terrain is skipped, the unknown unit unmatched and the reinforced
+	// unit costs more than the text says.
+	if statuses[models.ImportSkipped] != 1 || statuses[models.ImportUnmatched] != 1 || statuses[models.ImportPointsMismatch] != 1 {
+		t.Errorf("unexpected report: %+v", resp.Report)
+	}
+}
+
+func TestImportArmyList_RegimentOfRenown(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	createTestHero(t, s, gameID, factionID, "Leader")
+	rorID := createTestRegimentOfRenown(t, s, gameID, "Test Regiment", "Test Allegiance", 200)
+	bladeID := createTestUnitWithName(t, s, rorID, "Hired Blade")
+
+	text := `Renown List 350/2000 pts
+
+Test Faction
+
+General's Regiment
+Leader (150)
+• General
+
+Regiment of Renown
+Test Regiment (200)
+2x Hired Blade (0)
+Unknown Blade (0)`
+
+	resp, err := ImportArmyList(s, ctx, models.ArmyImportRequest{GameID: gameID, Text: text})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	army := resp.Army
+	if army.RegimentOfRenownID == nil || *army.RegimentOfRenownID != rorID {
+		t.Errorf("expected Regiment of Renown %v, got %v", rorID, army.RegimentOfRenownID)
+	}
+
+	if len(army.RegimentOfRenownUnits) != 1 || army.RegimentOfRenownUnits[0].UnitID != bladeID || army.RegimentOfRenownUnits[0].Quantity != 2 {
+		t.Errorf("expected 2 hired blades from the Regiment of Renown, got %+v", army.RegimentOfRenownUnits)
+	}
+
+	// The unit the regiment does not have is the only line left out.
+	if len(resp.Report) != 1 || resp.Report[0].Status != models.ImportUnmatched {
+		t.Errorf("unexpected report: %+v", resp.Report)
+	}
+}
+
+func TestImportArmyList_MissingText(t *testing.T) {
+	s := setupTestDB(t)
+
+	_, err := ImportArmyList(s, context.Background(), models.ArmyImportRequest{Text: "  "})
+	if !errors.Is(err, appErr.ErrMissingText) {
+		t.Errorf("expected ErrMissingText, got %v", err)
+	}
+}
@@ -0,0 +1,429 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/database"
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+// foreignKeyViolation is the postgres SQLSTATE for a missing referenced row.
+const foreignKeyViolation = "23503"
+
+func mapDBArmyListToModel(a database.ArmyList) models.ArmyList {
+	var validation models.ValidationResponse
+	if len(a.Validation) > 0 {
+		_ = json.Unmarshal(a.Validation, &validation)
+	}
+	// Lists saved before a field existed decode it as nil.
+	if validation.Issues == nil {
+		validation.Issues = []models.ValidationIssue{}
+	}
+	if validation.Errors == nil {
+		validation.Errors = []string{}
+	}
+	if validation.Warnings == nil {
+		validation.Warnings = []string{}
+	}
+	if validation.FiredRules == nil {
+		validation.FiredRules = []string{}
+	}
+
+	return models.ArmyList{
+		ID:                 a.ID,
+		GameID:             a.GameID,
+		FactionID:          a.FactionID,
+		Name:               a.Name,
+		PointsLimit:        int(a.PointsLimit),
+		BattleFormationID:  database.NullUUIDToPtr(a.BattleFormationID),
+		RegimentOfRenownID: database.NullUUIDToPtr(a.RegimentOfRenownID),
+		TotalPoints:        int(a.TotalPoints),
+		IsValid:            a.IsValid,
+		Validation:         validation,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
+	}
+}
+
+func GetArmyLists(s *state.State, ctx context.Context, factionID *uuid.UUID) ([]models.ArmyList, error) {
+	var dbLists []database.ArmyList
+	var err error
+
+	if factionID == nil {
+		dbLists, err = s.DB.GetArmyLists(ctx)
+	} else {
+		dbLists, err = s.DB.GetArmyListsForFaction(ctx, *factionID)
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []models.ArmyList{}, nil
+		}
+		return nil, err
+	}
+
+	lists := make([]models.ArmyList, len(dbLists))
+	for i, a := range dbLists {
+		lists[i] = mapDBArmyListToModel(a)
+	}
+
+	return lists, nil
+}
+
+// GetArmyListByID returns the list with its regiments, units and enhancements.
+func GetArmyListByID(s *state.State, ctx context.Context, id uuid.UUID) (models.ArmyList, error) {
+	if id == uuid.Nil {
+		return models.ArmyList{}, appErr.ErrMissingID
+	}
+
+	dbList, err := s.DB.GetArmyListByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ArmyList{}, appErr.ErrNotFound
+		}
+		return models.ArmyList{}, err
+	}
+
+	dbRegiments, err := s.DB.GetArmyListRegiments(ctx, id)
+	if err != nil {
+		return models.ArmyList{}, err
+	}
+
+	dbUnits, err := s.DB.GetArmyListUnits(ctx, id)
+	if err != nil {
+		return models.ArmyList{}, err
+	}
+
+	dbEnhancements, err := s.DB.GetArmyListUnitEnhancements(ctx, id)
+	if err != nil {
+		return models.ArmyList{}, err
+	}
+
+	enhancements := make(map[uuid.UUID][]uuid.UUID)
+	for _, e := range dbEnhancements {
+		enhancements[e.ArmyListUnitID] = append(enhancements[e.ArmyListUnitID], e.EnhancementID)
+	}
+
+	list := mapDBArmyListToModel(dbList)
+	list.Regiments = []models.ArmyRegiment{}
+	list.Auxiliaries = []models.ArmyListUnit{}
+	list.RegimentOfRenownUnits = []models.ArmyListUnit{}
+
+	// Auxiliary units and the units of the Regiment of Renown are each
+	// stored in a regiment row of their own.
+	var auxiliaryID, renownID uuid.UUID
+	regimentIndex := make(map[uuid.UUID]int, len(dbRegiments))
+	for _, r := range dbRegiments {
+		if r.IsAuxiliary {
+			auxiliaryID = r.ID
+			continue
+		}
+		if r.IsRegimentOfRenown {
+			renownID = r.ID
+			continue
+		}
+		regimentIndex[r.ID] = len(list.Regiments)
+		list.Regiments = append(list.Regiments, models.ArmyRegiment{
+			ID:        r.ID,
+			Name:      r.Name,
+			IsGeneral: r.IsGeneral,
+			Units:     []models.ArmyListUnit{},
+		})
+	}
+
+	for _, u := range dbUnits {
+		enhancementIDs := enhancements[u.ID]
+		if enhancementIDs == nil {
+			enhancementIDs = []uuid.UUID{}
+		}
+
+		unit := models.ArmyListUnit{
+			ID:             u.ID,
+			UnitID:         u.UnitID,
+			Quantity:       int(u.Quantity),
+			Reinforced:     u.IsReinforced,
+			EnhancementIDs: enhancementIDs,
+		}
+
+		switch u.RegimentID {
+		case auxiliaryID:
+			list.Auxiliaries = append(list.Auxiliaries, unit)
+			continue
+		case renownID:
+			list.RegimentOfRenownUnits = append(list.RegimentOfRenownUnits, unit)
+			continue
+		}
+
+		i := regimentIndex[u.RegimentID]
+		list.Regiments[i].Units = append(list.Regiments[i].Units, unit)
+	}
+
+	return list, nil
+}
+
+func CreateArmyList(s *state.State, ctx context.Context, req models.ArmyListRequest) (models.ArmyList, error) {
+	id, err := saveArmyList(s, ctx, nil, req)
+	if err != nil {
+		return models.ArmyList{}, err
+	}
+
+	return GetArmyListByID(s, ctx, id)
+}
+
+func UpdateArmyList(s *state.State, ctx context.Context, id uuid.UUID, req models.ArmyListRequest) (models.ArmyList, error) {
+	if id == uuid.Nil {
+		return models.ArmyList{}, appErr.ErrMissingID
+	}
+
+	_, err := saveArmyList(s, ctx, &id, req)
+	if err != nil {
+		return models.ArmyList{}, err
+	}
+
+	return GetArmyListByID(s, ctx, id)
+}
+
+func DeleteArmyList(s *state.State, ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return appErr.ErrMissingID
+	}
+
+	deleted, err := s.DB.DeleteArmyList(ctx, id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return appErr.ErrNotFound
+	}
+
+	return nil
+}
+
+// saveArmyList validates the list and writes it in one transaction. With a nil
+// id a new list is created, otherwise the regiments of the existing list are
+// replaced.
+func saveArmyList(s *state.State, ctx context.Context, id *uuid.UUID, req models.ArmyListRequest) (uuid.UUID, error) {
+	switch {
+	case req.GameID == uuid.Nil:
+		return uuid.Nil, appErr.ErrMissingGameID
+	case req.FactionID == uuid.Nil:
+		return uuid.Nil, appErr.ErrMissingFactionID
+	case req.Name == "":
+		return uuid.Nil, appErr.ErrMissingName
+	case len(req.RegimentOfRenownUnits) > 0 && req.RegimentOfRenownID == nil:
+		return uuid.Nil, fmt.Errorf("%w: regiment_of_renown_units need a regiment_of_renown_id", appErr.ErrMissingRegimentOfRenownID)
+	}
+
+	validation, err := ValidateArmy(s, ctx, armyListValidationRequest(req))
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	validationJSON, err := json.Marshal(validation)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	battleFormationID := uuid.NullUUID{}
+	if req.BattleFormationID != nil {
+		battleFormationID = database.UUIDToNullUUID(*req.BattleFormationID)
+	}
+
+	regimentOfRenownID := uuid.NullUUID{}
+	if req.RegimentOfRenownID != nil {
+		regimentOfRenownID = database.UUIDToNullUUID(*req.RegimentOfRenownID)
+	}
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	q := s.DB.WithTx(tx)
+
+	var listID uuid.UUID
+	if id == nil {
+		dbList, err := q.CreateArmyList(ctx, database.CreateArmyListParams{
+			GameID:             req.GameID,
+			FactionID:          req.FactionID,
+			Name:               req.Name,
+			PointsLimit:        int32(req.PointsLimit),
+			BattleFormationID:  battleFormationID,
+			RegimentOfRenownID: regimentOfRenownID,
+			TotalPoints:        int32(validation.TotalPoints),
+			IsValid:            validation.IsValid,
+			Validation:         validationJSON,
+		})
+		if err != nil {
+			return uuid.Nil, referenceError(err)
+		}
+		listID = dbList.ID
+	} else {
+		dbList, err := q.UpdateArmyList(ctx, database.UpdateArmyListParams{
+			ID:                 *id,
+			GameID:             req.GameID,
+			FactionID:          req.FactionID,
+			Name:               req.Name,
+			PointsLimit:        int32(req.PointsLimit),
+			BattleFormationID:  battleFormationID,
+			RegimentOfRenownID: regimentOfRenownID,
+			TotalPoints:        int32(validation.TotalPoints),
+			IsValid:            validation.IsValid,
+			Validation:         validationJSON,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return uuid.Nil, appErr.ErrNotFound
+			}
+			return uuid.Nil, referenceError(err)
+		}
+		listID = dbList.ID
+
+		err = q.DeleteArmyListRegiments(ctx, listID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	for i, reg := range req.Regiments {
+		err = saveArmyListRegiment(ctx, q, database.CreateArmyListRegimentParams{
+			ArmyListID: listID,
+			Position:   int32(i),
+			Name:       reg.Name,
+			IsGeneral:  reg.IsGeneral,
+		}, reg.Units)
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	if len(req.Auxiliaries) > 0 {
+		err = saveArmyListRegiment(ctx, q, database.CreateArmyListRegimentParams{
+			ArmyListID:  listID,
+			Position:    int32(len(req.Regiments)),
+			IsAuxiliary: true,
+		}, req.Auxiliaries)
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	if len(req.RegimentOfRenownUnits) > 0 {
+		err = saveArmyListRegiment(ctx, q, database.CreateArmyListRegimentParams{
+			ArmyListID:         listID,
+			Position:           int32(len(req.Regiments) + 1),
+			IsRegimentOfRenown: true,
+		}, req.RegimentOfRenownUnits)
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return listID, nil
+}
+
+func saveArmyListRegiment(ctx context.Context, q *database.Queries, params database.CreateArmyListRegimentParams, units []models.ArmyListUnitRequest) error {
+	dbRegiment, err := q.CreateArmyListRegiment(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	for j, u := range units {
+		dbUnit, err := q.CreateArmyListUnit(ctx, database.CreateArmyListUnitParams{
+			RegimentID:   dbRegiment.ID,
+			UnitID:       u.UnitID,
+			Position:     int32(j),
+			Quantity:     int32(u.Quantity),
+			IsReinforced: u.Reinforced,
+		})
+		if err != nil {
+			return referenceError(err)
+		}
+
+		for _, enhancementID := range u.EnhancementIDs {
+			err = q.AddEnhancementToArmyListUnit(ctx, database.AddEnhancementToArmyListUnitParams{
+				ArmyListUnitID: dbUnit.ID,
+				EnhancementID:  enhancementID,
+			})
+			if err != nil {
+				return referenceError(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// armyListValidationRequest maps the stored layout onto the one ValidateArmy
+// works on: the first unit of a regiment is its leader. Regiments without
+// units have nothing to check and are left out.
+func armyListValidationRequest(req models.ArmyListRequest) models.ArmyValidationRequest {
+	validationReq := models.ArmyValidationRequest{
+		GameID:            req.GameID,
+		FactionID:         req.FactionID,
+		PointsLimit:       req.PointsLimit,
+		BattleFormationID: req.BattleFormationID,
+		Regiments:         []models.RegimentSelection{},
+		Auxiliaries:       armyUnitsFromRequest(req.Auxiliaries),
+	}
+
+	if req.RegimentOfRenownID != nil {
+		validationReq.RegimentsOfRenown = []models.RegimentOfRenownSelection{{
+			FactionID: *req.RegimentOfRenownID,
+			Units:     armyUnitsFromRequest(req.RegimentOfRenownUnits),
+		}}
+	}
+
+	for _, reg := range req.Regiments {
+		if len(reg.Units) == 0 {
+			continue
+		}
+
+		units := armyUnitsFromRequest(reg.Units)
+		validationReq.Regiments = append(validationReq.Regiments, models.RegimentSelection{
+			Leader:    units[0],
+			Units:     units[1:],
+			IsGeneral: reg.IsGeneral,
+		})
+	}
+
+	return validationReq
+}
+
+func armyUnitsFromRequest(units []models.ArmyListUnitRequest) []models.ArmyUnit {
+	armyUnits := make([]models.ArmyUnit, len(units))
+	for i, u := range units {
+		armyUnits[i] = models.ArmyUnit{
+			UnitID:         u.UnitID,
+			Quantity:       u.Quantity,
+			Reinforced:     u.Reinforced,
+			EnhancementIDs: u.EnhancementIDs,
+		}
+	}
+	return armyUnits
+}
+
+func referenceError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return appErr.ErrInvalidReference
+	}
+	return err
+}
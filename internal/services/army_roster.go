@@ -0,0 +1,591 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/battlescribe"
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+// ExportRoster is the BattleScribe roster format of GET /armies/{id}/export.
+const ExportRoster = "ros"
+
+// Names of the upgrades BattleScribe adds to a unit.
+const (
+	rosterGeneral    = "General"
+	rosterReinforced = "Reinforced"
+)
+
+// rosterImport holds what ImportArmyRoster matches roster selections against.
+type rosterImport struct {
+	s       *state.State
+	ctx     context.Context
+	game    models.Game
+	faction models.Faction
+
+	units        []models.Unit
+	unitNames    []string
+	enhancements []models.Enhancement
+	formations   []models.BattleFormation
+	renown       []models.Faction
+	renownNames  []string
+
+	army   models.ArmyListRequest
+	report []models.ImportReportLine
+}
+
+// ImportArmyRoster turns a BattleScribe roster, a .ros file or a zipped
+// .rosz, into a list that can be saved. Selections are matched to units and
+// enhancements on the BattleScribe entry IDs stored with them, falling back
+// to their names; what matches nothing is left out and reported.
+func ImportArmyRoster(s *state.State, ctx context.Context, gameID, factionID uuid.UUID, data []byte) (models.ArmyImportResponse, error) {
+	if len(data) == 0 {
+		return models.ArmyImportResponse{}, fmt.Errorf("empty file: %w", appErr.ErrInvalidRoster)
+	}
+
+	roster, err := battlescribe.Read(data)
+	if err != nil {
+		return models.ArmyImportResponse{}, fmt.Errorf("%w: %v", appErr.ErrInvalidRoster, err)
+	}
+	if len(roster.Forces) == 0 {
+		return models.ArmyImportResponse{}, fmt.Errorf("roster has no forces: %w", appErr.ErrInvalidRoster)
+	}
+	force := roster.Forces[0]
+
+	imp := &rosterImport{s: s, ctx: ctx}
+
+	imp.faction, err = rosterFaction(s, ctx, gameID, factionID, roster)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+	if gameID == uuid.Nil {
+		gameID = imp.faction.GameID
+	}
+	imp.game, err = GetGame(s, ctx, gameID)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+
+	imp.units, err = GetFactionRoster(s, ctx, imp.faction.ID)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+	imp.unitNames = make([]string, len(imp.units))
+	for i, unit := range imp.units {
+		imp.unitNames[i] = unit.Name
+	}
+
+	imp.enhancements, err = GetEnhancementsByFaction(s, ctx, &imp.faction.ID)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+	imp.formations, err = GetBattleFormationsForFaction(s, ctx, imp.faction.ID)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+
+	isRegimentOfRenown := true
+	imp.renown, err = GetFactions(s, ctx, FactionFilter{GameID: &gameID, IsRegimentOfRenown: &isRegimentOfRenown})
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+	imp.renownNames = make([]string, len(imp.renown))
+	for i, f := range imp.renown {
+		imp.renownNames[i] = f.Name
+	}
+
+	imp.army = models.ArmyListRequest{
+		GameID:      gameID,
+		FactionID:   imp.faction.ID,
+		Name:        strings.TrimSpace(roster.Name),
+		PointsLimit: battlescribe.Points(roster.CostLimits),
+		Regiments:   []models.ArmyRegimentRequest{},
+		Auxiliaries: []models.ArmyListUnitRequest{},
+	}
+	if imp.army.Name == "" {
+		imp.army.Name = importDefaultName
+	}
+
+	err = imp.armyForce(force)
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+	for _, extra := range roster.Forces[1:] {
+		imp.report = append(imp.report, rosterReport(extra.Name, models.ImportSkipped, "a list holds a single army, only the first force is imported"))
+	}
+
+	validation, err := ValidateArmy(s, ctx, armyListValidationRequest(imp.army))
+	if err != nil {
+		return models.ArmyImportResponse{}, err
+	}
+
+	if imp.report == nil {
+		imp.report = []models.ImportReportLine{}
+	}
+
+	return models.ArmyImportResponse{
+		Army:       imp.army,
+		Validation: validation,
+		Report:     imp.report,
+	}, nil
+}
+
+// rosterFaction loads the requested faction, or else the faction built from
+// the catalogue of the roster's first force.
+func rosterFaction(s *state.State, ctx context.Context, gameID, factionID uuid.UUID, roster battlescribe.Roster) (models.Faction, error) {
+	if factionID != uuid.Nil {
+		return GetFactionByID(s, ctx, factionID)
+	}
+
+	filter := FactionFilter{}
+	if gameID != uuid.Nil {
+		filter.GameID = &gameID
+	}
+	factions, err := GetFactions(s, ctx, filter)
+	if err != nil {
+		return models.Faction{}, err
+	}
+
+	force := roster.Forces[0]
+	names := make([]string, 0, len(factions))
+	candidates := make([]models.Faction, 0, len(factions))
+	for _, f := range factions {
+		if f.IsRegimentOfRenown {
+			continue
+		}
+		if f.BattlescribeID != "" && f.BattlescribeID == force.CatalogueID {
+			return GetFactionByID(s, ctx, f.ID)
+		}
+		names = append(names, f.Name)
+		candidates = append(candidates, f)
+	}
+
+	if i, _ := matchName(force.CatalogueName, names); i >= 0 {
+		return GetFactionByID(s, ctx, candidates[i].ID)
+	}
+
+	return models.Faction{}, fmt.Errorf("no faction built from catalogue %q: %w", force.CatalogueName, appErr.ErrMissingFactionID)
+}
+
+// armyForce imports the army force: its battle formation, the units taken
+// outside any regiment and the regiment, auxiliary and Regiment of Renown
+// forces inside it.
+func (imp *rosterImport) armyForce(force battlescribe.Force) error {
+	// Where armies are built from regiments, the units left in the army
+	// force are faction terrain and the like, which lists do not store.
+	hasRegiments := imp.game.BattlescribeForces[battlescribe.ForceRegiment] != ""
+
+	for _, sel := range force.Selections {
+		switch {
+		case !imp.isUnitSelection(sel):
+			imp.battleFormation(sel)
+		case hasRegiments:
+			imp.report = append(imp.report, rosterReport(sel.Name, models.ImportSkipped, "taken outside the regiments and auxiliary units"))
+		default:
+			if picked, _, ok := imp.unit(sel); ok {
+				imp.army.Auxiliaries = append(imp.army.Auxiliaries, picked)
+			}
+		}
+	}
+
+	for _, child := range force.Forces {
+		err := imp.childForce(child)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// childForce imports a force added inside the army force. Forces are known
+// by the force entries stored with the game, or else by their names; the
+// data spells auxiliary units "Auxillary Units".
+func (imp *rosterImport) childForce(force battlescribe.Force) error {
+	forces := imp.game.BattlescribeForces
+	name := nameKey(force.Name)
+
+	switch {
+	case battlescribe.HasEntryID(force.EntryID, forces[battlescribe.ForceRegiment]),
+		strings.HasPrefix(name, "regiment") && !strings.Contains(name, "renown"):
+		return imp.regiment(force)
+	case battlescribe.HasEntryID(force.EntryID, forces[battlescribe.ForceAuxiliary]),
+		strings.HasPrefix(name, "auxil"):
+		for _, sel := range force.Selections {
+			if picked, _, ok := imp.unit(sel); ok {
+				imp.army.Auxiliaries = append(imp.army.Auxiliaries, picked)
+			}
+		}
+		return nil
+	}
+
+	ror, tied := imp.regimentOfRenown(force)
+	switch {
+	case ror >= 0 && imp.army.RegimentOfRenownID != nil:
+		imp.report = append(imp.report, rosterReport(force.Name, models.ImportSkipped, "a list can hire only one Regiment of Renown"))
+	case ror >= 0:
+		imp.army.RegimentOfRenownID = &imp.renown[ror].ID
+		return imp.regimentOfRenownUnits(force, imp.renown[ror])
+	case len(tied) > 0:
+		r := rosterReport(force.Name, models.ImportAmbiguous, "more than one Regiment of Renown matches this name")
+		r.Candidates = tied
+		imp.report = append(imp.report, r)
+	default:
+		imp.report = append(imp.report, rosterReport(force.Name, models.ImportSkipped, "not a regiment, auxiliary units or a Regiment of Renown"))
+	}
+	return nil
+}
+
+func (imp *rosterImport) regimentOfRenown(force battlescribe.Force) (int, []string) {
+	for i, f := range imp.renown {
+		if battlescribe.HasEntryID(force.EntryID, f.BattlescribeForceID) {
+			return i, nil
+		}
+	}
+	return matchName(force.Name, imp.renownNames)
+}
+
+// regimentOfRenownUnits matches the selections of a hired Regiment of Renown
+// force against the regiment's own units, on entry ID or else name.
+func (imp *rosterImport) regimentOfRenownUnits(force battlescribe.Force, ror models.Faction) error {
+	units, err := GetUnitsByFaction(imp.s, imp.ctx, ror.ID)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(units))
+	for i, unit := range units {
+		names[i] = unit.Name
+	}
+
+	for _, sel := range force.Selections {
+		i := slices.IndexFunc(units, func(unit models.Unit) bool {
+			return battlescribe.HasEntryID(sel.EntryID, unit.BattlescribeID)
+		})
+		if i < 0 {
+			var tied []string
+			i, tied = matchName(sel.Name, names)
+			if i < 0 {
+				if len(tied) > 0 {
+					r := rosterReport(sel.Name, models.ImportAmbiguous, "more than one unit matches this name")
+					r.Candidates = tied
+					imp.report = append(imp.report, r)
+				} else {
+					imp.report = append(imp.report, rosterReport(sel.Name, models.ImportUnmatched, fmt.Sprintf("no unit of %s is called %s", ror.Name, sel.Name)))
+				}
+				continue
+			}
+		}
+
+		imp.army.RegimentOfRenownUnits = append(imp.army.RegimentOfRenownUnits, models.ArmyListUnitRequest{
+			UnitID:         units[i].ID,
+			Quantity:       max(sel.Number, 1),
+			EnhancementIDs: []uuid.UUID{},
+		})
+	}
+	return nil
+}
+
+// regiment imports a regiment force. The leader is the unit picked as
+// general, or else the first hero.
+func (imp *rosterImport) regiment(force battlescribe.Force) error {
+	var units []models.ArmyListUnitRequest
+	leader := -1
+	isGeneral := false
+
+	for _, sel := range force.Selections {
+		picked, general, ok := imp.unit(sel)
+		if !ok {
+			continue
+		}
+		if general {
+			isGeneral = true
+			leader = len(units)
+		}
+		units = append(units, picked)
+	}
+
+	for i := 0; leader < 0 && i < len(units); i++ {
+		keywords, err := GetKeywordsForUnit(imp.s, imp.ctx, units[i].UnitID)
+		if err != nil {
+			return err
+		}
+		for _, k := range keywords {
+			if k.KeywordName == "HERO" {
+				leader = i
+				break
+			}
+		}
+	}
+	if leader > 0 {
+		units = append([]models.ArmyListUnitRequest{units[leader]}, append(units[:leader:leader], units[leader+1:]...)...)
+	}
+	if units == nil {
+		units = []models.ArmyListUnitRequest{}
+	}
+
+	name := "General's Regiment"
+	if !isGeneral {
+		number := 1
+		for _, reg := range imp.army.Regiments {
+			if !reg.IsGeneral {
+				number++
+			}
+		}
+		name = fmt.Sprintf("Regiment %d", number)
+	}
+
+	imp.army.Regiments = append(imp.army.Regiments, models.ArmyRegimentRequest{
+		Name:      name,
+		IsGeneral: isGeneral,
+		Units:     units,
+	})
+	return nil
+}
+
+// isUnitSelection reports whether a selection of the army force is a unit
+// rather than one of the upgrades configuring the army.
+func (imp *rosterImport) isUnitSelection(sel battlescribe.Selection) bool {
+	if sel.Type == "unit" || sel.Type == "model" {
+		return true
+	}
+	for _, unit := range imp.units {
+		if battlescribe.HasEntryID(sel.EntryID, unit.BattlescribeID) {
+			return true
+		}
+	}
+	return false
+}
+
+// unit matches a unit selection and its upgrades. It also reports whether
+// the unit was picked as the general.
+func (imp *rosterImport) unit(sel battlescribe.Selection) (models.ArmyListUnitRequest, bool, bool) {
+	i := -1
+	for j, unit := range imp.units {
+		if battlescribe.HasEntryID(sel.EntryID, unit.BattlescribeID) {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		var tied []string
+		i, tied = matchName(sel.Name, imp.unitNames)
+		if i < 0 {
+			if len(tied) > 0 {
+				r := rosterReport(sel.Name, models.ImportAmbiguous, "more than one unit matches this name")
+				r.Candidates = tied
+				imp.report = append(imp.report, r)
+			} else {
+				imp.report = append(imp.report, rosterReport(sel.Name, models.ImportUnmatched, fmt.Sprintf("no unit of %s is called %s", imp.faction.Name, sel.Name)))
+			}
+			return models.ArmyListUnitRequest{}, false, false
+		}
+	}
+	unit := imp.units[i]
+
+	picked := models.ArmyListUnitRequest{
+		UnitID:         unit.ID,
+		Quantity:       max(sel.Number, 1),
+		EnhancementIDs: []uuid.UUID{},
+	}
+	isGeneral := false
+
+	var upgrades func(selections []battlescribe.Selection)
+	upgrades = func(selections []battlescribe.Selection) {
+		for _, child := range selections {
+			switch nameKey(child.Name) {
+			case nameKey(rosterGeneral):
+				isGeneral = true
+				continue
+			case nameKey(rosterReinforced):
+				picked.Reinforced = true
+				continue
+			}
+
+			if e, ok := imp.enhancement(child); ok {
+				picked.EnhancementIDs = append(picked.EnhancementIDs, e.ID)
+				if points := battlescribe.Points(child.Costs); points > 0 && points != e.Points {
+					imp.report = append(imp.report, rosterReport(child.Name, models.ImportPointsMismatch, fmt.Sprintf("%s costs %d points, the roster gives %d", e.Name, e.Points, points)))
+				}
+				continue
+			}
+			upgrades(child.Selections)
+		}
+	}
+	upgrades(sel.Selections)
+
+	points := unit.Points * picked.Quantity
+	if picked.Reinforced {
+		points *= 2
+	}
+	if rosterPoints := battlescribe.Points(sel.Costs); rosterPoints > 0 && rosterPoints != points {
+		imp.report = append(imp.report, rosterReport(sel.Name, models.ImportPointsMismatch, fmt.Sprintf("%s costs %d points, the roster gives %d", unit.Name, points, rosterPoints)))
+	}
+
+	return picked, isGeneral, true
+}
+
+// enhancement matches an upgrade on its entry ID, or else on its exact name:
+// units carry many upgrades, such as weapon options, that are not
+// enhancements and must not be matched loosely.
+func (imp *rosterImport) enhancement(sel battlescribe.Selection) (models.Enhancement, bool) {
+	for _, e := range imp.enhancements {
+		if battlescribe.HasEntryID(sel.EntryID, e.BattlescribeID) {
+			return e, true
+		}
+	}
+	for _, e := range imp.enhancements {
+		if nameKey(e.Name) == nameKey(sel.Name) {
+			return e, true
+		}
+	}
+	return models.Enhancement{}, false
+}
+
+// battleFormation looks for the battle formation among an upgrade of the
+// army force and its children. Battle formations are matched on their exact
+// name, as the army force also holds lores, traits and the like.
+func (imp *rosterImport) battleFormation(sel battlescribe.Selection) {
+	if imp.army.BattleFormationID != nil {
+		return
+	}
+	for _, f := range imp.formations {
+		if nameKey(f.Name) == nameKey(sel.Name) {
+			imp.army.BattleFormationID = &f.ID
+			return
+		}
+	}
+	for _, child := range sel.Selections {
+		imp.battleFormation(child)
+	}
+}
+
+func rosterReport(text, status, msg string) models.ImportReportLine {
+	return models.ImportReportLine{Text: text, Status: status, Message: msg}
+}
+
+// RenderArmyListRoster writes a saved list as a BattleScribe .ros file.
+// Forces and selections carry the BattleScribe IDs stored with the game,
+// faction, units and enhancements so BattleScribe can link them back to its
+// data.
+func RenderArmyListRoster(export models.ArmyListExport, game models.Game) ([]byte, error) {
+	list := export.Army
+	units := exportUnitsByID(export)
+	enhancements := exportEnhancementsByID(export)
+	forces := game.BattlescribeForces
+
+	roster := battlescribe.Roster{
+		ID:             battlescribe.NewID(),
+		Name:           list.Name,
+		GameSystemID:   game.BattlescribeID,
+		GameSystemName: game.Name,
+		Costs:          battlescribe.PointsCost(list.TotalPoints),
+		CostLimits:     battlescribe.PointsCost(list.PointsLimit),
+	}
+
+	army := battlescribe.Force{
+		ID:            battlescribe.NewID(),
+		Name:          export.Faction.Name,
+		EntryID:       forces[battlescribe.ForceArmy],
+		CatalogueID:   export.Faction.BattlescribeID,
+		CatalogueName: export.Faction.Name,
+	}
+	childForce := func(name, role string) battlescribe.Force {
+		return battlescribe.Force{
+			ID:            battlescribe.NewID(),
+			Name:          name,
+			EntryID:       forces[role],
+			CatalogueID:   export.Faction.BattlescribeID,
+			CatalogueName: export.Faction.Name,
+		}
+	}
+
+	if export.BattleFormation != nil {
+		army.Selections = append(army.Selections, battlescribe.Selection{
+			ID:     battlescribe.NewID(),
+			Name:   export.BattleFormation.Name,
+			Number: 1,
+			Type:   "upgrade",
+		})
+	}
+
+	unitSelection := func(u models.ArmyListUnit, isGeneral bool) battlescribe.Selection {
+		unit := units[u.UnitID]
+		sel := battlescribe.Selection{
+			ID:      battlescribe.NewID(),
+			Name:    unit.Name,
+			EntryID: unit.BattlescribeID,
+			Number:  max(u.Quantity, 1),
+			Type:    "unit",
+			Costs:   battlescribe.PointsCost(armyListUnitPoints(unit, u)),
+		}
+		if isGeneral {
+			sel.Selections = append(sel.Selections, battlescribe.Selection{ID: battlescribe.NewID(), Name: rosterGeneral, Number: 1, Type: "upgrade"})
+		}
+		if u.Reinforced {
+			sel.Selections = append(sel.Selections, battlescribe.Selection{ID: battlescribe.NewID(), Name: rosterReinforced, Number: 1, Type: "upgrade"})
+		}
+		for _, id := range u.EnhancementIDs {
+			e := enhancements[id]
+			sel.Selections = append(sel.Selections, battlescribe.Selection{
+				ID:      battlescribe.NewID(),
+				Name:    e.Name,
+				EntryID: e.BattlescribeID,
+				Number:  1,
+				Type:    "upgrade",
+				Costs:   battlescribe.PointsCost(e.Points),
+			})
+		}
+		return sel
+	}
+
+	// Games without regiment forces take every unit in the army force.
+	hasRegiments := forces[battlescribe.ForceRegiment] != ""
+
+	for _, reg := range list.Regiments {
+		force := childForce("Regiment", battlescribe.ForceRegiment)
+		for i, u := range reg.Units {
+			force.Selections = append(force.Selections, unitSelection(u, reg.IsGeneral && i == 0))
+		}
+		if hasRegiments {
+			army.Forces = append(army.Forces, force)
+		} else {
+			army.Selections = append(army.Selections, force.Selections...)
+		}
+	}
+
+	if len(list.Auxiliaries) > 0 {
+		force := childForce("Auxiliary Units", battlescribe.ForceAuxiliary)
+		for _, u := range list.Auxiliaries {
+			force.Selections = append(force.Selections, unitSelection(u, false))
+		}
+		if hasRegiments {
+			army.Forces = append(army.Forces, force)
+		} else {
+			army.Selections = append(army.Selections, force.Selections...)
+		}
+	}
+
+	if export.RegimentOfRenown != nil {
+		ror := export.RegimentOfRenown
+		force := battlescribe.Force{
+			ID:            battlescribe.NewID(),
+			Name:          ror.Name,
+			EntryID:       ror.BattlescribeForceID,
+			CatalogueID:   ror.BattlescribeID,
+			CatalogueName: ror.Name,
+		}
+		for _, u := range list.RegimentOfRenownUnits {
+			force.Selections = append(force.Selections, unitSelection(u, false))
+		}
+		army.Forces = append(army.Forces, force)
+	}
+
+	roster.Forces = []battlescribe.Force{army}
+	return battlescribe.Write(roster)
+}
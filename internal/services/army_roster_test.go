@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/battlescribe"
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+func testRosterGame() models.Game {
+	return models.Game{
+		Name:           "Test Game",
+		BattlescribeID: "gs1",
+		BattlescribeForces: map[string]string{
+			battlescribe.ForceArmy:      "army1",
+			battlescribe.ForceRegiment:  "reg1",
+			battlescribe.ForceAuxiliary: "aux1",
+		},
+	}
+}
+
+func TestRenderArmyListRoster(t *testing.T) {
+	export := testArmyListExport()
+	export.Faction.BattlescribeID = "cat1"
+	export.Units[0].BattlescribeID = "hero1"
+	export.Enhancements[0].BattlescribeID = "trait1"
+
+	data, err := RenderArmyListRoster(export, testRosterGame())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	roster, err := battlescribe.Read(data)
+	if err != nil {
+		t.Fatalf("rendered roster does not parse: %v\n%s", err, data)
+	}
+
+	if roster.Name != "Test List" || roster.GameSystemID != "gs1" || battlescribe.Points(roster.CostLimits) != 2000 {
+		t.Errorf("unexpected roster header: %+v", roster)
+	}
+
+	if len(roster.Forces) != 1 {
+		t.Fatalf("expected one army force, got %d", len(roster.Forces))
+	}
+	army := roster.Forces[0]
+	if army.EntryID != "army1" || army.CatalogueID != "cat1" {
+		t.Errorf("expected the army force of catalogue cat1, got %+v", army)
+	}
+	if len(army.Selections) != 1 || army.Selections[0].Name != "Test Formation" {
+		t.Errorf("expected the battle formation, got %+v", army.Selections)
+	}
+
+	if len(army.Forces) != 2 || army.Forces[0].EntryID != "reg1" || army.Forces[1].EntryID != "aux1" {
+		t.Fatalf("expected a regiment and an auxiliary force, got %+v", army.Forces)
+	}
+
+	leader := army.Forces[0].Selections[0]
+	if leader.EntryID != "hero1" || battlescribe.Points(leader.Costs) != 150 {
+		t.Errorf("expected the hero at 150 points, got %+v", leader)
+	}
+	var upgrades []string
+	for _, sel := range leader.Selections {
+		upgrades = append(upgrades, sel.Name+"/"+sel.EntryID)
+	}
+	if !slices.Equal(upgrades, []string{"General/", "Test Trait/trait1"}) {
+		t.Errorf("expected the general and its trait, got %v", upgrades)
+	}
+
+	reinforced := army.Forces[0].Selections[1]
+	if battlescribe.Points(reinforced.Costs) != 200 || len(reinforced.Selections) != 1 || reinforced.Selections[0].Name != "Reinforced" {
+		t.Errorf("expected a reinforced unit at 200 points, got %+v", reinforced)
+	}
+
+	if aux := army.Forces[1].Selections; len(aux) != 1 || aux[0].Number != 2 {
+		t.Errorf("expected 2 entries of one auxiliary unit, got %+v", aux)
+	}
+}
+
+func TestRenderArmyListRoster_RegimentOfRenown(t *testing.T) {
+	export := testArmyListExport()
+	blade := models.Unit{ID: uuid.New(), Name: "Hired Blade", BattlescribeID: "blade1"}
+	export.Units = append(export.Units, blade)
+	export.RegimentOfRenown = &models.Faction{Name: "Hired Blades", BattlescribeID: "ror1", BattlescribeForceID: "rorforce1", Points: 150}
+	export.Army.RegimentOfRenownUnits = []models.ArmyListUnit{{UnitID: blade.ID, Quantity: 1}}
+
+	data, err := RenderArmyListRoster(export, testRosterGame())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	roster, err := battlescribe.Read(data)
+	if err != nil {
+		t.Fatalf("rendered roster does not parse: %v\n%s", err, data)
+	}
+
+	forces := roster.Forces[0].Forces
+	if len(forces) != 3 || forces[2].EntryID != "rorforce1" || forces[2].CatalogueID != "ror1" {
+		t.Fatalf("expected a Regiment of Renown force after the regiment and auxiliaries, got %+v", forces)
+	}
+	if hired := forces[2].Selections; len(hired) != 1 || hired[0].EntryID != "blade1" || hired[0].Name != "Hired Blade" {
+		t.Errorf("expected the hired unit in the Regiment of Renown force, got %+v", hired)
+	}
+}
+
+func TestRenderArmyListRoster_NoRegimentForces(t *testing.T) {
+	game := testRosterGame()
+	delete(game.BattlescribeForces, battlescribe.ForceRegiment)
+	delete(game.BattlescribeForces, battlescribe.ForceAuxiliary)
+
+	data, err := RenderArmyListRoster(testArmyListExport(), game)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	roster, err := battlescribe.Read(data)
+	if err != nil {
+		t.Fatalf("rendered roster does not parse: %v", err)
+	}
+
+	army := roster.Forces[0]
+	// The battle formation and the three units.
+	if len(army.Forces) != 0 || len(army.Selections) != 4 {
+		t.Errorf("expected every unit in the army force, got %d forces and %d selections", len(army.Forces), len(army.Selections))
+	}
+}
+
+func TestImportArmyRoster(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	heroID := createTestHero(t, s, gameID, factionID, "Leader")
+	unitID := createTestUnit(t, s, factionID)
+	enhancementID := createTestEnhancement(t, s, factionID, "Test Enhancement", "Heroic Trait", "[]")
+	formationID := createTestBattleFormation(t, s, gameID, factionID)
+	rorID := createTestRegimentOfRenown(t, s, gameID, "Test Regiment", "Test Allegiance", 200)
+	bladeID := createTestUnitWithName(t, s, rorID, "Hired Blade")
+
+	hero := models.Unit{ID: heroID, Name: "Leader", Points: 150}
+	unit := models.Unit{ID: unitID, Name: "Test Unit", Points: 100}
+	unknown := models.Unit{ID: uuid.New(), Name: "Nobody Knows", Points: 50}
+	blade := models.Unit{ID: bladeID, Name: "Hired Blade"}
+	enhancement := models.Enhancement{ID: enhancementID, Name: "Test Enhancement", Points: 20}
+
+	export := models.ArmyListExport{
+		Army: models.ArmyList{
+			Name:        "Roster List",
+			PointsLimit: 2000,
+			Regiments: []models.ArmyRegiment{{
+				Units: []models.ArmyListUnit{
+					// No general is picked, so the hero must be found to lead.
+					{UnitID: unitID, Quantity: 1, Reinforced: true},
+					{UnitID: heroID, Quantity: 1, EnhancementIDs: []uuid.UUID{enhancementID}},
+				},
+			}},
+			Auxiliaries:           []models.ArmyListUnit{{UnitID: unknown.ID, Quantity: 1}},
+			RegimentOfRenownUnits: []models.ArmyListUnit{{UnitID: bladeID, Quantity: 1}},
+		},
+		Faction:          models.Faction{Name: "Test Faction"},
+		BattleFormation:  &models.BattleFormation{Name: "Test BattleFormation"},
+		RegimentOfRenown: &models.Faction{Name: "Test Regiment"},
+		Units:            []models.Unit{hero, unit, unknown, blade},
+		Enhancements:     []models.Enhancement{enhancement},
+	}
+
+	data, err := RenderArmyListRoster(export, testRosterGame())
+	if err != nil {
+		t.Fatalf("failed to render roster: %v", err)
+	}
+
+	resp, err := ImportArmyRoster(s, ctx, gameID, uuid.Nil, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	army := resp.Army
+	if army.FactionID != factionID || army.Name != "Roster List" || army.PointsLimit != 2000 {
+		t.Errorf("expected Roster List for the test faction at 2000 points, got %+v", army)
+	}
+
+	if army.BattleFormationID == nil || *army.BattleFormationID != formationID {
+		t.Errorf("expected battle formation %v, got %v", formationID, army.BattleFormationID)
+	}
+
+	if army.RegimentOfRenownID == nil || *army.RegimentOfRenownID != rorID {
+		t.Errorf("expected Regiment of Renown %v, got %v", rorID, army.RegimentOfRenownID)
+	}
+
+	if len(army.RegimentOfRenownUnits) != 1 || army.RegimentOfRenownUnits[0].UnitID != bladeID {
+		t.Errorf("expected the hired blade from the Regiment of Renown, got %+v", army.RegimentOfRenownUnits)
+	}
+
+	if len(army.Regiments) != 1 || army.Regiments[0].IsGeneral || len(army.Regiments[0].Units) != 2 {
+		t.Fatalf("expected a regiment of 2 units, got %+v", army.Regiments)
+	}
+
+	leader := army.Regiments[0].Units[0]
+	if leader.UnitID != heroID || !slices.Equal(leader.EnhancementIDs, []uuid.UUID{enhancementID}) {
+		t.Errorf("expected the hero to lead with its enhancement, got %+v", leader)
+	}
+
+	if second := army.Regiments[0].Units[1]; second.UnitID != unitID || !second.Reinforced {
+		t.Errorf("expected the reinforced test unit, got %+v", second)
+	}
+
+	// The unknown auxiliary unit is the only selection left out.
+	if len(army.Auxiliaries) != 0 || len(resp.Report) != 1 || resp.Report[0].Status != models.ImportUnmatched {
+		t.Errorf("unexpected report: %+v", resp.Report)
+	}
+}
+
+func TestImportArmyRoster_Invalid(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	for _, data := range []string{"", "Test Faction 2000 pts", `<roster id="r1" name="Empty"/>`} {
+		_, err := ImportArmyRoster(s, ctx, uuid.Nil, uuid.Nil, []byte(data))
+		if !errors.Is(err, appErr.ErrInvalidRoster) {
+			t.Errorf("expected ErrInvalidRoster for %q, got %v", data, err)
+		}
+	}
+}
@@ -116,6 +116,7 @@ func GetBattleFormationByID(s *state.State, ctx context.Context, id uuid.UUID) (
 	}
 
 	battleFormation := mapDBBattleFormationToModel(dbBattleFormation)
+	battleFormation.Effects, _ = GetAbilityEffectsForBattleFormation(s, ctx, dbBattleFormation.ID)
 
 	return battleFormation, nil
 }
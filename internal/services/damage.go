@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/combat"
+	"github.com/JohnG-Dev/army_builder_api/internal/dice"
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
+)
+
+// maxModelCount caps the models of a unit in combat requests, keeping
+// distributions and simulations small.
+const maxModelCount = 60
+
+// CalculateDamage works out the expected damage of the attacker's weapons
+// against the target, and the chance of every total.
+func CalculateDamage(s *state.State, ctx context.Context, req models.DamageRequest) (models.DamageResponse, error) {
+	attacker, err := GetUnitByID(s, ctx, req.AttackerID)
+	if err != nil {
+		return models.DamageResponse{}, err
+	}
+
+	var trace []models.StatChange
+	if req.Effects != nil {
+		resolved, err := resolveEffects(s, ctx, attacker, *req.Effects)
+		if err != nil {
+			return models.DamageResponse{}, err
+		}
+		attacker, trace = resolved.Unit, resolved.Trace
+	}
+
+	modelCount, err := unitModels(max(attacker.MinUnitSize, 1), req.ModelCount)
+	if err != nil {
+		return models.DamageResponse{}, err
+	}
+
+	weapons, err := chosenWeapons(attacker, req.WeaponIDs)
+	if err != nil {
+		return models.DamageResponse{}, err
+	}
+
+	if req.Crit != "" && !combat.IsCrit(req.Crit) {
+		return models.DamageResponse{}, fmt.Errorf("%w: crit %q", appErr.ErrInvalidStat, req.Crit)
+	}
+
+	target, damageTarget, err := resolveDamageTarget(s, ctx, req)
+	if err != nil {
+		return models.DamageResponse{}, err
+	}
+
+	resp := models.DamageResponse{
+		AttackerID:   attacker.ID,
+		Attacker:     attacker.Name,
+		ModelCount:   modelCount,
+		Target:       damageTarget,
+		Weapons:      make([]models.WeaponDamage, 0, len(weapons)),
+		Distribution: dice.Fixed(0).Distribution(),
+		Effects:      trace,
+	}
+
+	for _, w := range weapons {
+		crit := req.Crit
+		if crit == "" {
+			crit = combat.CritFromAbilities(w.Abilities)
+		}
+
+		wd := models.WeaponDamage{WeaponID: w.ID, Name: w.Name, Crit: crit}
+		e, err := combat.ExpectedDamage(combat.Profile{Models: modelCount, Stats: w.Stats, Crit: crit}, target)
+		if err != nil {
+			if !errors.Is(err, combat.ErrUnsupported) {
+				return models.DamageResponse{}, err
+			}
+			wd.Skipped = err.Error()
+			resp.Weapons = append(resp.Weapons, wd)
+			continue
+		}
+
+		wd.Attacks = e.Attacks
+		wd.Hits = e.Hits
+		wd.Crits = e.Crits
+		wd.Wounds = e.Wounds
+		wd.Unsaved = e.Unsaved
+		wd.ExpectedDamage = e.Damage
+		wd.ExpectedMortalDamage = e.MortalDamage
+		resp.Weapons = append(resp.Weapons, wd)
+
+		resp.ExpectedDamage += e.Damage
+		resp.ExpectedMortalDamage += e.MortalDamage
+		resp.Distribution = resp.Distribution.Add(e.Distribution)
+	}
+
+	return resp, nil
+}
+
+// unitModels checks a requested model count, falling back to defaultCount.
+func unitModels(defaultCount, requested int) (int, error) {
+	if requested == 0 {
+		return defaultCount, nil
+	}
+	if requested < 0 || requested > maxModelCount {
+		return 0, fmt.Errorf("%w: %d is not between 1 and %d", appErr.ErrInvalidModelCount, requested, maxModelCount)
+	}
+	return requested, nil
+}
+
+// chosenWeapons picks the requested weapons of unit, or all of them.
+func chosenWeapons(unit models.Unit, ids []uuid.UUID) ([]models.Weapon, error) {
+	if len(ids) == 0 {
+		return unit.Weapons, nil
+	}
+
+	byID := make(map[uuid.UUID]models.Weapon, len(unit.Weapons))
+	for _, w := range unit.Weapons {
+		byID[w.ID] = w
+	}
+
+	chosen := make([]models.Weapon, 0, len(ids))
+	for _, id := range ids {
+		w, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: weapon %s is not carried by %s", appErr.ErrInvalidReference, id, unit.Name)
+		}
+		chosen = append(chosen, w)
+	}
+	return chosen, nil
+}
+
+// resolveDamageTarget reads the target's stats from its unit, then applies
+// the save and ward given in the request.
+func resolveDamageTarget(s *state.State, ctx context.Context, req models.DamageRequest) (combat.Target, models.DamageTarget, error) {
+	var target combat.Target
+	var dt models.DamageTarget
+
+	if req.TargetID != uuid.Nil {
+		unit, err := GetUnitByID(s, ctx, req.TargetID)
+		if err != nil {
+			if errors.Is(err, appErr.ErrNotFound) {
+				return combat.Target{}, models.DamageTarget{}, fmt.Errorf("%w: target unit %s", appErr.ErrInvalidReference, req.TargetID)
+			}
+			return combat.Target{}, models.DamageTarget{}, err
+		}
+
+		target = combat.Target{
+			Save:      unit.Stats.Save,
+			Ward:      unit.Stats.WardFNP,
+			Invuln:    unit.Stats.InvulnSave,
+			Toughness: unit.Stats.Toughness,
+		}
+		dt = models.DamageTarget{
+			UnitID:    &unit.ID,
+			Name:      unit.Name,
+			Save:      unit.Save,
+			Ward:      unit.WardFNP,
+			Invuln:    unit.InvulnSave,
+			Toughness: unit.Toughness,
+		}
+	}
+
+	if req.Save != "" {
+		save, err := stats.ParseThreshold(req.Save)
+		if err != nil {
+			return combat.Target{}, models.DamageTarget{}, fmt.Errorf("%w: save %q", appErr.ErrInvalidStat, req.Save)
+		}
+		target.Save = save
+		dt.Save = req.Save
+	}
+	if req.Ward != "" {
+		ward, err := stats.ParseThreshold(req.Ward)
+		if err != nil {
+			return combat.Target{}, models.DamageTarget{}, fmt.Errorf("%w: ward %q", appErr.ErrInvalidStat, req.Ward)
+		}
+		target.Ward = ward
+		dt.Ward = req.Ward
+	}
+
+	return target, dt, nil
+}
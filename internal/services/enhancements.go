@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	"github.com/google/uuid"
@@ -14,19 +15,29 @@ import (
 )
 
 func mapDBEnhancementToModel(e database.Enhancement) models.Enhancement {
+	var requiredKeywords [][]string
+	if len(e.RequiredKeywords) > 0 {
+		_ = json.Unmarshal(e.RequiredKeywords, &requiredKeywords)
+	}
+	if requiredKeywords == nil {
+		requiredKeywords = [][]string{}
+	}
+
 	return models.Enhancement{
-		ID:              e.ID,
-		FactionID:       e.FactionID,
-		Name:            e.Name,
-		EnhancementType: e.EnhancementType,
-		Description:     e.Description,
-		Points:          int(e.Points),
-		IsUnique:        e.IsUnique,
-		Restrictions:    e.Restrictions,
-		Version:         e.Version,
-		Source:          e.Source,
-		CreatedAt:       e.CreatedAt,
-		UpdatedAt:       e.UpdatedAt,
+		ID:               e.ID,
+		FactionID:        e.FactionID,
+		Name:             e.Name,
+		EnhancementType:  e.EnhancementType,
+		Description:      e.Description,
+		Points:           int(e.Points),
+		IsUnique:         e.IsUnique,
+		Restrictions:     e.Restrictions,
+		RequiredKeywords: requiredKeywords,
+		Version:          e.Version,
+		Source:           e.Source,
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+		BattlescribeID:   e.BattlescribeID,
 	}
 }
 
@@ -86,6 +97,7 @@ func GetEnhancementByID(s *state.State, ctx context.Context, id uuid.UUID) (mode
 	}
 
 	enhancement := mapDBEnhancementToModel(dbEnhancement)
+	enhancement.Effects, _ = GetAbilityEffectsForEnhancement(s, ctx, dbEnhancement.ID)
 
 	return enhancement, nil
 }
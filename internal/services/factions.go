@@ -23,10 +23,14 @@ func mapDBFactionToModel(f database.Faction) models.Faction {
 		Name:               f.Name,
 		Description:        f.Description,
 		Allegiance:         f.Allegiance,
+		Points:             int(f.Points),
 		Version:            f.Version,
 		Source:             f.Source,
 		CreatedAt:          f.CreatedAt,
 		UpdatedAt:          f.UpdatedAt,
+
+		BattlescribeID:      f.BattlescribeID,
+		BattlescribeForceID: f.BattlescribeForceID,
 	}
 }
 
@@ -118,5 +122,21 @@ func GetFactionByID(s *state.State, ctx context.Context, id uuid.UUID) (models.F
 
 	faction := mapDBFactionToModel(dbFaction)
 
+	if faction.IsArmyOfRenown {
+		allowed, err := GetAllowedUnitsForFaction(s, ctx, faction.ID)
+		if err != nil {
+			return models.Faction{}, err
+		}
+		faction.AllowedUnits = allowed
+	}
+
+	if faction.IsRegimentOfRenown {
+		hosts, err := GetHostsForRegimentOfRenown(s, ctx, faction.ID)
+		if err != nil {
+			return models.Faction{}, err
+		}
+		faction.HireableBy = hosts
+	}
+
 	return faction, nil
 }
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 
 	"github.com/google/uuid"
@@ -14,6 +15,11 @@ import (
 )
 
 func mapDBGameToModel(g database.Game) models.Game {
+	var forces map[string]string
+	if len(g.BattlescribeForces) > 0 {
+		_ = json.Unmarshal(g.BattlescribeForces, &forces)
+	}
+
 	return models.Game{
 		ID:        g.ID,
 		Name:      g.Name,
@@ -22,6 +28,9 @@ func mapDBGameToModel(g database.Game) models.Game {
 		Source:    g.Source,
 		CreatedAt: g.CreatedAt,
 		UpdatedAt: g.UpdatedAt,
+
+		BattlescribeID:     g.BattlescribeID,
+		BattlescribeForces: forces,
 	}
 }
 
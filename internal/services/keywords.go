@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/JohnG-Dev/army_builder_api/internal/database"
 	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/keywordexpr"
 	"github.com/JohnG-Dev/army_builder_api/internal/models"
 	"github.com/JohnG-Dev/army_builder_api/internal/state"
 )
@@ -177,3 +180,68 @@ func GetKeywordByID(s *state.State, ctx context.Context, id uuid.UUID) (models.K
 
 	return keyword, nil
 }
+
+// ListKeywords returns a page of keywords. Filtered by unit, each keyword
+// carries the unit's value for it.
+func ListKeywords(s *state.State, ctx context.Context, p models.ListParams) (models.Page[models.Keyword], error) {
+	lp, err := newListPage(p, "name", "created_at")
+	if err != nil {
+		return models.Page[models.Keyword]{}, err
+	}
+
+	f := p.Filters
+	dbKeywords, err := s.DB.ListKeywords(ctx, database.ListKeywordsParams{
+		UnitID:         nullUUID(f.UnitID),
+		GameID:         nullUUID(f.GameID),
+		AfterID:        lp.afterID(),
+		Sort:           lp.sort,
+		AfterName:      lp.afterName(),
+		AfterCreatedAt: lp.afterCreatedAt(),
+		PageSize:       lp.pageSize(),
+	})
+	if err != nil {
+		return models.Page[models.Keyword]{}, err
+	}
+
+	return paginate(lp, dbKeywords, func(k database.ListKeywordsRow) listCursor {
+		return listCursor{ID: k.ID, Name: k.Name, CreatedAt: k.CreatedAt}
+	}, func(k database.ListKeywordsRow) models.Keyword {
+		keyword := mapDBKeywordToModel(database.Keyword{
+			ID:          k.ID,
+			GameID:      k.GameID,
+			Name:        k.Name,
+			Description: k.Description,
+			Version:     k.Version,
+			Source:      k.Source,
+			CreatedAt:   k.CreatedAt,
+			UpdatedAt:   k.UpdatedAt,
+		})
+		keyword.Value = k.Value
+		return keyword
+	}), nil
+}
+
+// keywordQuery compiles a keyword expression into the tsquery ListUnits
+// matches against the upper-cased names of each unit's keywords. Every name
+// is quoted as a single lexeme, so names with spaces match whole.
+func keywordQuery(e keywordexpr.Expr) pgtype.Text {
+	if e == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: tsquery(e), Valid: true}
+}
+
+func tsquery(e keywordexpr.Expr) string {
+	switch e := e.(type) {
+	case keywordexpr.Keyword:
+		name := strings.NewReplacer(`\`, `\\`, "'", "''").Replace(strings.ToUpper(e.Name))
+		return "'" + name + "'"
+	case keywordexpr.Not:
+		return "!" + tsquery(e.X)
+	case keywordexpr.And:
+		return "(" + tsquery(e.X) + " & " + tsquery(e.Y) + ")"
+	case keywordexpr.Or:
+		return "(" + tsquery(e.X) + " | " + tsquery(e.Y) + ")"
+	}
+	return ""
+}
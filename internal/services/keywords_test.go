@@ -0,0 +1,36 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/keywordexpr"
+)
+
+func TestKeywordQuery(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"hero", "'HERO'"},
+		{"HERO AND (WIZARD OR PRIEST) AND NOT UNIQUE", "(('HERO' & ('WIZARD' | 'PRIEST')) & !'UNIQUE')"},
+		{"Stormcast Eternals AND NOT (CAVALRY OR MONSTER)", "('STORMCAST ETERNALS' & !('CAVALRY' | 'MONSTER'))"},
+		{`"Lord's Guard"`, `'LORD''S GUARD'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := keywordexpr.Parse(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := keywordQuery(e)
+			if !got.Valid || got.String != tt.expected {
+				t.Errorf("expected %s, got %+v", tt.expected, got)
+			}
+		})
+	}
+
+	if keywordQuery(nil).Valid {
+		t.Error("expected no query without an expression")
+	}
+}
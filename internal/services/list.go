@@ -0,0 +1,170 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 500
+	defaultSort     = "name"
+)
+
+// listCursor marks the last row of a page: the next page starts after its
+// sort value and, on ties, its id.
+type listCursor struct {
+	Sort      string    `json:"s"`
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"n,omitempty"`
+	Points    int32     `json:"p,omitempty"`
+	CreatedAt time.Time `json:"c,omitempty"`
+}
+
+// listPage is a checked ListParams, ready to fill in the paging arguments
+// of a list query.
+type listPage struct {
+	sort  string
+	limit int
+	after *listCursor
+}
+
+// newListPage checks the limit, sort and cursor of p. sorts are the fields
+// the list can be sorted by.
+func newListPage(p models.ListParams, sorts ...string) (listPage, error) {
+	lp := listPage{sort: p.Sort, limit: p.Limit}
+	if lp.sort == "" {
+		lp.sort = defaultSort
+	}
+	if !slices.Contains(sorts, strings.TrimPrefix(lp.sort, "-")) {
+		return listPage{}, fmt.Errorf("%w: %q is not one of %s", appErr.ErrInvalidSort, lp.sort, strings.Join(sorts, ", "))
+	}
+
+	if lp.limit == 0 {
+		lp.limit = defaultPageSize
+	}
+	if lp.limit < 0 || lp.limit > maxPageSize {
+		return listPage{}, fmt.Errorf("%w: %d is not between 1 and %d", appErr.ErrInvalidLimit, lp.limit, maxPageSize)
+	}
+
+	if p.Cursor != "" {
+		after, err := decodeCursor(p.Cursor)
+		if err != nil {
+			return listPage{}, err
+		}
+		if after.Sort != lp.sort {
+			return listPage{}, fmt.Errorf("%w: cursor is for sort %q", appErr.ErrInvalidCursor, after.Sort)
+		}
+		lp.after = &after
+	}
+
+	return lp, nil
+}
+
+func decodeCursor(s string) (listCursor, error) {
+	var c listCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return listCursor{}, fmt.Errorf("%w: %v", appErr.ErrInvalidCursor, err)
+	}
+	err = json.Unmarshal(data, &c)
+	if err != nil || c.ID == uuid.Nil {
+		return listCursor{}, fmt.Errorf("%w: %q", appErr.ErrInvalidCursor, s)
+	}
+	return c, nil
+}
+
+func encodeCursor(c listCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// pageSize asks for one row more than the page holds, to tell whether
+// there is a next page.
+func (lp listPage) pageSize() int32 {
+	return int32(lp.limit + 1)
+}
+
+func (lp listPage) afterID() uuid.NullUUID {
+	if lp.after == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: lp.after.ID, Valid: true}
+}
+
+func (lp listPage) afterName() pgtype.Text {
+	if lp.after == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: lp.after.Name, Valid: true}
+}
+
+func (lp listPage) afterPoints() pgtype.Int4 {
+	if lp.after == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: lp.after.Points, Valid: true}
+}
+
+func (lp listPage) afterCreatedAt() pgtype.Timestamptz {
+	if lp.after == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: lp.after.CreatedAt, Valid: true}
+}
+
+// paginate cuts rows, fetched with pageSize, down to the page and works out
+// the cursor of the next one from the last row kept.
+func paginate[R, T any](lp listPage, rows []R, cursor func(R) listCursor, mapRow func(R) T) models.Page[T] {
+	page := models.Page[T]{Items: make([]T, 0, min(len(rows), lp.limit))}
+
+	if len(rows) > lp.limit {
+		rows = rows[:lp.limit]
+		c := cursor(rows[len(rows)-1])
+		c.Sort = lp.sort
+		page.NextCursor = encodeCursor(c)
+	}
+
+	for _, r := range rows {
+		page.Items = append(page.Items, mapRow(r))
+	}
+	return page
+}
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func nullInt4(n *int) pgtype.Int4 {
+	if n == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(*n), Valid: true}
+}
+
+func nullBool(b *bool) pgtype.Bool {
+	if b == nil {
+		return pgtype.Bool{}
+	}
+	return pgtype.Bool{Bool: *b, Valid: true}
+}
+
+func nullText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *s, Valid: true}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+func TestNewListPage(t *testing.T) {
+	lp, err := newListPage(models.ListParams{}, "name", "created_at")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lp.sort != "name" || lp.limit != defaultPageSize || lp.after != nil || lp.pageSize() != defaultPageSize+1 {
+		t.Errorf("expected the defaults, got %+v", lp)
+	}
+
+	cursor := encodeCursor(listCursor{Sort: "-created_at", ID: uuid.New(), CreatedAt: time.Now()})
+
+	tests := []struct {
+		name   string
+		params models.ListParams
+		err    error
+	}{
+		{name: "descending", params: models.ListParams{Sort: "-created_at", Limit: 10}},
+		{name: "with cursor", params: models.ListParams{Sort: "-created_at", Cursor: cursor}},
+		{name: "unknown sort", params: models.ListParams{Sort: "points"}, err: appErr.ErrInvalidSort},
+		{name: "limit too large", params: models.ListParams{Limit: maxPageSize + 1}, err: appErr.ErrInvalidLimit},
+		{name: "negative limit", params: models.ListParams{Limit: -1}, err: appErr.ErrInvalidLimit},
+		{name: "garbled cursor", params: models.ListParams{Cursor: "not a cursor"}, err: appErr.ErrInvalidCursor},
+		{name: "cursor of another sort", params: models.ListParams{Sort: "name", Cursor: cursor}, err: appErr.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newListPage(tt.params, "name", "created_at")
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	names := []string{"Gryph-hounds", "Liberators", "Vindictors"}
+	toCursor := func(name string) listCursor { return listCursor{ID: uuid.New(), Name: name} }
+	upper := func(name string) string { return name + "!" }
+
+	lp := listPage{sort: "name", limit: 2}
+	page := paginate(lp, names, toCursor, upper)
+	if len(page.Items) != 2 || page.Items[1] != "Liberators!" || page.NextCursor == "" {
+		t.Fatalf("expected 2 items and a next page, got %+v", page)
+	}
+
+	next, err := newListPage(models.ListParams{Cursor: page.NextCursor}, "name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.after.Name != "Liberators" || !next.afterName().Valid || next.afterName().String != "Liberators" {
+		t.Errorf("expected the cursor to resume after Liberators, got %+v", next.after)
+	}
+
+	last := paginate(lp, names[2:], toCursor, upper)
+	if len(last.Items) != 1 || last.NextCursor != "" {
+		t.Errorf("expected a last page without cursor, got %+v", last)
+	}
+
+	empty := paginate(lp, []string(nil), toCursor, upper)
+	if empty.Items == nil {
+		t.Errorf("expected an empty page to have an empty, not nil, list")
+	}
+}
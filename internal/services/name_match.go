@@ -0,0 +1,106 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+)
+
+// matchName finds name among candidates. Names are compared on their letters
+// and digits only, ignoring case. Failing an exact match, the candidates
+// within a few edits of name are tried, then the ones containing name or
+// contained in it. It returns the index of the match, or -1 and the tied
+// candidates when the best match is ambiguous.
+func matchName(name string, candidates []string) (int, []string) {
+	key := nameKey(name)
+	if key == "" {
+		return -1, nil
+	}
+
+	keys := make([]string, len(candidates))
+	for i, c := range candidates {
+		keys[i] = nameKey(c)
+	}
+
+	pick := func(indexes []int) (int, []string) {
+		switch len(indexes) {
+		case 0:
+			return -1, nil
+		case 1:
+			return indexes[0], nil
+		}
+		tied := make([]string, len(indexes))
+		for i, idx := range indexes {
+			tied[i] = candidates[idx]
+		}
+		return -1, tied
+	}
+
+	var exact []int
+	for i, k := range keys {
+		if k == key {
+			exact = append(exact, i)
+		}
+	}
+	if len(exact) > 0 {
+		return pick(exact)
+	}
+
+	maxEdits := max(2, len([]rune(key))/5)
+	best := maxEdits + 1
+	var close []int
+	for i, k := range keys {
+		d := editDistance(key, k)
+		switch {
+		case d < best:
+			best = d
+			close = []int{i}
+		case d == best:
+			close = append(close, i)
+		}
+	}
+	if len(close) > 0 {
+		return pick(close)
+	}
+
+	var containing []int
+	for i, k := range keys {
+		if k != "" && (strings.Contains(k, key) || strings.Contains(key, k)) {
+			containing = append(containing, i)
+		}
+	}
+	return pick(containing)
+}
+
+func nameKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
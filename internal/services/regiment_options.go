@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/database"
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+func mapDBRegimentOptionToModel(o database.RegimentOption) models.RegimentOption {
+	return models.RegimentOption{
+		ID:       o.ID,
+		UnitID:   o.UnitID,
+		Keyword:  o.Keyword,
+		UnitName: o.UnitName,
+		IsHero:   o.IsHero,
+	}
+}
+
+func GetRegimentOptionsForUnit(s *state.State, ctx context.Context, unitID uuid.UUID) ([]models.RegimentOption, error) {
+	if unitID == uuid.Nil {
+		return nil, appErr.ErrMissingUnitID
+	}
+
+	dbOptions, err := s.DB.GetRegimentOptionsForUnit(ctx, unitID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []models.RegimentOption{}, nil
+		}
+		return nil, err
+	}
+
+	options := make([]models.RegimentOption, len(dbOptions))
+	for i, o := range dbOptions {
+		options[i] = mapDBRegimentOptionToModel(o)
+	}
+
+	return options, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+// GetHostsForRegimentOfRenown returns the names of the factions allowed to
+// hire the Regiment of Renown.
+func GetHostsForRegimentOfRenown(s *state.State, ctx context.Context, factionID uuid.UUID) ([]string, error) {
+	if factionID == uuid.Nil {
+		return nil, appErr.ErrMissingFactionID
+	}
+
+	dbHosts, err := s.DB.GetHostsForRegimentOfRenown(ctx, factionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	hosts := make([]string, len(dbHosts))
+	for i, h := range dbHosts {
+		hosts[i] = h.HostFactionName
+	}
+
+	return hosts, nil
+}
+
+// canHire reports whether host can hire the Regiment of Renown ror. An Army
+// of Renown is also checked under its parent's name, passed in hostNames.
+// Regiments without captured hosts can be hired within their grand alliance.
+func canHire(hostNames []string, host, ror models.Faction) bool {
+	if len(ror.HireableBy) == 0 {
+		return ror.Allegiance != "" && ror.Allegiance == host.Allegiance
+	}
+
+	for _, name := range hostNames {
+		if slices.Contains(ror.HireableBy, name) {
+			return true
+		}
+	}
+	return false
+}
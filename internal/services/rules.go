@@ -15,15 +15,16 @@ import (
 
 func mapDBRuleToModel(r database.Rule) models.Rule {
 	return models.Rule{
-		ID:        r.ID,
-		GameID:    r.GameID,
-		Name:      r.Name,
-		RuleType:  r.RuleType,
-		Text:      r.Text,
-		Version:   r.Version,
-		Source:    r.Source,
-		CreatedAt: r.CreatedAt,
-		UpdatedAt: r.UpdatedAt,
+		ID:          r.ID,
+		GameID:      r.GameID,
+		Name:        r.Name,
+		RuleType:    r.RuleType,
+		Description: r.Description,
+		Text:        r.Text,
+		Version:     r.Version,
+		Source:      r.Source,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
 	}
 }
 
@@ -126,3 +127,29 @@ func GetRuleByID(s *state.State, ctx context.Context, id uuid.UUID) (models.Rule
 
 	return rule, nil
 }
+
+// ListRules returns a page of rules.
+func ListRules(s *state.State, ctx context.Context, p models.ListParams) (models.Page[models.Rule], error) {
+	lp, err := newListPage(p, "name", "created_at")
+	if err != nil {
+		return models.Page[models.Rule]{}, err
+	}
+
+	f := p.Filters
+	dbRules, err := s.DB.ListRules(ctx, database.ListRulesParams{
+		GameID:         nullUUID(f.GameID),
+		RuleType:       nullText(f.Type),
+		AfterID:        lp.afterID(),
+		Sort:           lp.sort,
+		AfterName:      lp.afterName(),
+		AfterCreatedAt: lp.afterCreatedAt(),
+		PageSize:       lp.pageSize(),
+	})
+	if err != nil {
+		return models.Page[models.Rule]{}, err
+	}
+
+	return paginate(lp, dbRules, func(r database.Rule) listCursor {
+		return listCursor{ID: r.ID, Name: r.Name, CreatedAt: r.CreatedAt}
+	}, mapDBRuleToModel), nil
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/database"
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// searchTypes are the types of hit, in the order they are searched.
+var searchTypes = []string{
+	models.SearchTypeUnit,
+	models.SearchTypeAbility,
+	models.SearchTypeRule,
+	models.SearchTypeEnhancement,
+	models.SearchTypeBattleFormation,
+}
+
+// searcher runs the full-text query for one type of hit.
+type searcher func(s *state.State, ctx context.Context, p models.SearchParams) ([]models.SearchHit, error)
+
+var searchers = map[string]searcher{
+	models.SearchTypeUnit:            searchUnits,
+	models.SearchTypeAbility:         searchAbilities,
+	models.SearchTypeRule:            searchRules,
+	models.SearchTypeEnhancement:     searchEnhancements,
+	models.SearchTypeBattleFormation: searchBattleFormations,
+}
+
+// Search runs a full-text query over units, abilities, rules, enhancements
+// and battle formations and returns the best hits across all of them. The
+// query takes web search syntax: quoted phrases, OR and -word.
+func Search(s *state.State, ctx context.Context, p models.SearchParams) (models.SearchResults, error) {
+	p.Query = strings.TrimSpace(p.Query)
+	if p.Query == "" {
+		return models.SearchResults{}, appErr.ErrMissingQuery
+	}
+
+	if p.Limit == 0 {
+		p.Limit = defaultSearchLimit
+	}
+	if p.Limit < 0 || p.Limit > maxSearchLimit {
+		return models.SearchResults{}, fmt.Errorf("%w: %d is not between 1 and %d", appErr.ErrInvalidLimit, p.Limit, maxSearchLimit)
+	}
+
+	types := p.Types
+	if len(types) == 0 {
+		types = searchTypes
+	}
+	for _, t := range types {
+		if _, ok := searchers[t]; !ok {
+			return models.SearchResults{}, fmt.Errorf("%w: %q is not one of %s", appErr.ErrInvalidSearchType, t, strings.Join(searchTypes, ", "))
+		}
+	}
+
+	hits := []models.SearchHit{}
+	for _, t := range searchTypes {
+		if !slices.Contains(types, t) {
+			continue
+		}
+		// Rules belong to no faction.
+		if t == models.SearchTypeRule && p.FactionID != nil {
+			continue
+		}
+
+		found, err := searchers[t](s, ctx, p)
+		if err != nil {
+			return models.SearchResults{}, err
+		}
+		hits = append(hits, found...)
+	}
+
+	return models.SearchResults{Query: p.Query, Hits: rankHits(hits, p.Limit)}, nil
+}
+
+// rankHits orders hits best first, by name on ties, and keeps the first
+// limit of them.
+func rankHits(hits []models.SearchHit, limit int) []models.SearchHit {
+	slices.SortStableFunc(hits, func(a, b models.SearchHit) int {
+		if c := cmp.Compare(b.Rank, a.Rank); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return hits[:min(len(hits), limit)]
+}
+
+func searchUnits(s *state.State, ctx context.Context, p models.SearchParams) ([]models.SearchHit, error) {
+	rows, err := s.DB.SearchUnits(ctx, database.SearchUnitsParams{
+		Query:       p.Query,
+		GameID:      nullUUID(p.GameID),
+		FactionID:   nullUUID(p.FactionID),
+		ResultLimit: int32(p.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]models.SearchHit, len(rows))
+	for i, r := range rows {
+		hits[i] = models.SearchHit{
+			Type:      models.SearchTypeUnit,
+			ID:        r.ID,
+			Name:      r.Name,
+			GameID:    r.GameID,
+			FactionID: &r.FactionID,
+			Rank:      r.Rank,
+			Snippet:   r.Snippet,
+		}
+	}
+	return hits, nil
+}
+
+func searchAbilities(s *state.State, ctx context.Context, p models.SearchParams) ([]models.SearchHit, error) {
+	rows, err := s.DB.SearchAbilities(ctx, database.SearchAbilitiesParams{
+		Query:       p.Query,
+		GameID:      nullUUID(p.GameID),
+		FactionID:   nullUUID(p.FactionID),
+		ResultLimit: int32(p.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]models.SearchHit, len(rows))
+	for i, r := range rows {
+		hits[i] = models.SearchHit{
+			Type:      models.SearchTypeAbility,
+			ID:        r.ID,
+			Name:      r.Name,
+			GameID:    r.GameID,
+			FactionID: database.NullUUIDToPtr(r.FactionID),
+			UnitID:    database.NullUUIDToPtr(r.UnitID),
+			Rank:      r.Rank,
+			Snippet:   r.Snippet,
+		}
+	}
+	return hits, nil
+}
+
+func searchRules(s *state.State, ctx context.Context, p models.SearchParams) ([]models.SearchHit, error) {
+	rows, err := s.DB.SearchRules(ctx, database.SearchRulesParams{
+		Query:       p.Query,
+		GameID:      nullUUID(p.GameID),
+		ResultLimit: int32(p.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]models.SearchHit, len(rows))
+	for i, r := range rows {
+		hits[i] = models.SearchHit{
+			Type:    models.SearchTypeRule,
+			ID:      r.ID,
+			Name:    r.Name,
+			GameID:  r.GameID,
+			Rank:    r.Rank,
+			Snippet: r.Snippet,
+		}
+	}
+	return hits, nil
+}
+
+func searchEnhancements(s *state.State, ctx context.Context, p models.SearchParams) ([]models.SearchHit, error) {
+	rows, err := s.DB.SearchEnhancements(ctx, database.SearchEnhancementsParams{
+		Query:       p.Query,
+		GameID:      nullUUID(p.GameID),
+		FactionID:   nullUUID(p.FactionID),
+		ResultLimit: int32(p.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]models.SearchHit, len(rows))
+	for i, r := range rows {
+		hits[i] = models.SearchHit{
+			Type:      models.SearchTypeEnhancement,
+			ID:        r.ID,
+			Name:      r.Name,
+			GameID:    r.GameID,
+			FactionID: &r.FactionID,
+			Rank:      r.Rank,
+			Snippet:   r.Snippet,
+		}
+	}
+	return hits, nil
+}
+
+func searchBattleFormations(s *state.State, ctx context.Context, p models.SearchParams) ([]models.SearchHit, error) {
+	rows, err := s.DB.SearchBattleFormations(ctx, database.SearchBattleFormationsParams{
+		Query:       p.Query,
+		GameID:      nullUUID(p.GameID),
+		FactionID:   nullUUID(p.FactionID),
+		ResultLimit: int32(p.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]models.SearchHit, len(rows))
+	for i, r := range rows {
+		hits[i] = models.SearchHit{
+			Type:      models.SearchTypeBattleFormation,
+			ID:        r.ID,
+			Name:      r.Name,
+			GameID:    r.GameID,
+			FactionID: &r.FactionID,
+			Rank:      r.Rank,
+			Snippet:   r.Snippet,
+		}
+	}
+	return hits, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+)
+
+func TestSearch_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params models.SearchParams
+		want   error
+	}{
+		{"no query", models.SearchParams{Query: "  "}, appErr.ErrMissingQuery},
+		{"limit too high", models.SearchParams{Query: "fly", Limit: maxSearchLimit + 1}, appErr.ErrInvalidLimit},
+		{"negative limit", models.SearchParams{Query: "fly", Limit: -1}, appErr.ErrInvalidLimit},
+		{"unknown type", models.SearchParams{Query: "fly", Types: []string{"unit", "weapon"}}, appErr.ErrInvalidSearchType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Search(nil, context.Background(), tt.params)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestRankHits(t *testing.T) {
+	hits := []models.SearchHit{
+		{Type: models.SearchTypeUnit, Name: "Prosecutors", Rank: 0.2},
+		{Type: models.SearchTypeRule, Name: "Fly", Rank: 0.6},
+		{Type: models.SearchTypeAbility, Name: "Arcane Bolt", Rank: 0.2},
+		{Type: models.SearchTypeEnhancement, Name: "Mirror Shield", Rank: 0.1},
+	}
+
+	got := rankHits(hits, 3)
+
+	want := []string{"Fly", "Arcane Bolt", "Prosecutors"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d hits, got %d", len(want), len(got))
+	}
+	for i, name := range want {
+		if got[i].Name != name {
+			t.Errorf("hit %d: expected %s, got %s", i, name, got[i].Name)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/combat"
+	"github.com/JohnG-Dev/army_builder_api/internal/dice"
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+)
+
+const (
+	defaultIterations = 10000
+	maxIterations     = 100000
+)
+
+// SimulateCombat rolls every weapon of the attacker against the defender,
+// iterations times, and sums up how the defender fared.
+func SimulateCombat(s *state.State, ctx context.Context, req models.CombatSimulationRequest) (models.CombatSimulationResponse, error) {
+	iterations := req.Iterations
+	if iterations == 0 {
+		iterations = defaultIterations
+	}
+	if iterations < 0 || iterations > maxIterations {
+		return models.CombatSimulationResponse{}, fmt.Errorf("%w: %d is not between 1 and %d", appErr.ErrInvalidIterations, iterations, maxIterations)
+	}
+
+	if req.Crit != "" && !combat.IsCrit(req.Crit) {
+		return models.CombatSimulationResponse{}, fmt.Errorf("%w: crit %q", appErr.ErrInvalidStat, req.Crit)
+	}
+
+	attacker, attackerModels, err := resolveSimulationUnit(s, ctx, req.Attacker)
+	if err != nil {
+		return models.CombatSimulationResponse{}, err
+	}
+
+	defender, defenderModels, err := resolveSimulationUnit(s, ctx, req.Defender)
+	if err != nil {
+		return models.CombatSimulationResponse{}, err
+	}
+
+	seed := rand.Int64()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	target := combat.Target{
+		Save:      defender.Stats.Save,
+		Ward:      defender.Stats.WardFNP,
+		Invuln:    defender.Stats.InvulnSave,
+		Toughness: defender.Stats.Toughness,
+	}
+
+	resp := models.CombatSimulationResponse{
+		Seed:       seed,
+		Iterations: iterations,
+		Attacker:   models.SimulatedUnit{UnitID: attacker.ID, Name: attacker.Name, ModelCount: attackerModels},
+		Defender:   models.SimulatedUnit{UnitID: defender.ID, Name: defender.Name, ModelCount: defenderModels, Health: defender.HealthWounds},
+		Weapons:    make([]models.SimulatedWeapon, 0, len(attacker.Weapons)),
+	}
+
+	profiles := make([]combat.Profile, 0, len(attacker.Weapons))
+	for _, w := range attacker.Weapons {
+		crit := req.Crit
+		if crit == "" {
+			crit = combat.CritFromAbilities(w.Abilities)
+		}
+
+		sw := models.SimulatedWeapon{WeaponID: w.ID, Name: w.Name, Crit: crit}
+		profile := combat.Profile{Models: attackerModels, Stats: w.Stats, Crit: crit}
+		err := combat.Check(profile, target)
+		if err != nil {
+			sw.Skipped = err.Error()
+		} else {
+			profiles = append(profiles, profile)
+		}
+		resp.Weapons = append(resp.Weapons, sw)
+	}
+
+	sim, err := combat.Simulate(profiles, combat.Defender{
+		Target: target,
+		Models: defenderModels,
+		Health: defender.Stats.HealthWounds,
+	}, iterations, seed)
+	if err != nil {
+		return models.CombatSimulationResponse{}, err
+	}
+
+	resp.KillProbability = sim.Destroyed
+	resp.MeanModelsSlain = sim.ModelsSlain.Mean()
+	resp.ModelsSlain = sim.ModelsSlain
+	resp.ModelsSlainPercentiles = percentiles(sim.ModelsSlain)
+	resp.MeanDamage = sim.Damage.Mean()
+	resp.Damage = sim.Damage
+	resp.DamagePercentiles = percentiles(sim.Damage)
+	return resp, nil
+}
+
+// resolveSimulationUnit loads the unit named by su and works out how many
+// models it has.
+func resolveSimulationUnit(s *state.State, ctx context.Context, su models.SimulationUnit) (models.Unit, int, error) {
+	unitID := su.UnitID
+	reinforced := false
+
+	if su.ArmyUnitID != uuid.Nil {
+		entry, err := findArmyListUnit(s, ctx, su.ArmyID, su.ArmyUnitID)
+		if err != nil {
+			return models.Unit{}, 0, err
+		}
+		unitID = entry.UnitID
+		reinforced = entry.Reinforced
+	}
+
+	unit, err := GetUnitByID(s, ctx, unitID)
+	if err != nil {
+		return models.Unit{}, 0, err
+	}
+
+	defaultCount := max(unit.MinUnitSize, 1)
+	if reinforced {
+		defaultCount *= 2
+	}
+
+	modelCount, err := unitModels(defaultCount, su.ModelCount)
+	if err != nil {
+		return models.Unit{}, 0, err
+	}
+	return unit, modelCount, nil
+}
+
+// findArmyListUnit finds an entry in a regiment, the auxiliaries or the
+// Regiment of Renown of a saved army.
+func findArmyListUnit(s *state.State, ctx context.Context, armyID, entryID uuid.UUID) (models.ArmyListUnit, error) {
+	list, err := GetArmyListByID(s, ctx, armyID)
+	if err != nil {
+		if errors.Is(err, appErr.ErrNotFound) {
+			return models.ArmyListUnit{}, fmt.Errorf("%w: army %s", appErr.ErrInvalidReference, armyID)
+		}
+		return models.ArmyListUnit{}, err
+	}
+
+	entries := append(list.Auxiliaries, list.RegimentOfRenownUnits...)
+	for _, r := range list.Regiments {
+		entries = append(entries, r.Units...)
+	}
+	for _, u := range entries {
+		if u.ID == entryID {
+			return u, nil
+		}
+	}
+	return models.ArmyListUnit{}, fmt.Errorf("%w: army unit %s is not in army %s", appErr.ErrInvalidReference, entryID, armyID)
+}
+
+func percentiles(d dice.Distribution) models.Percentiles {
+	return models.Percentiles{
+		P10: d.Percentile(0.1),
+		P25: d.Percentile(0.25),
+		P50: d.Percentile(0.5),
+		P75: d.Percentile(0.75),
+		P90: d.Percentile(0.9),
+	}
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/state"
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
+)
+
+// effectSource is an ability, enhancement or battle formation and the
+// effects it grants.
+type effectSource struct {
+	kind    string
+	id      uuid.UUID
+	name    string
+	effects []models.AbilityEffect
+}
+
+// statField points at a raw stat and its parsed value, so an effect can
+// rewrite both.
+type statField struct {
+	raw   *string
+	value *stats.Value
+}
+
+// ResolveUnitStats applies the effects of the selected abilities,
+// enhancements and battle formation to a unit's stat line and weapons.
+func ResolveUnitStats(s *state.State, ctx context.Context, unitID uuid.UUID, sel models.EffectSelection) (models.ResolvedUnit, error) {
+	unit, err := GetUnitByID(s, ctx, unitID)
+	if err != nil {
+		return models.ResolvedUnit{}, err
+	}
+	return resolveEffects(s, ctx, unit, sel)
+}
+
+func resolveEffects(s *state.State, ctx context.Context, unit models.Unit, sel models.EffectSelection) (models.ResolvedUnit, error) {
+	sources, err := selectedEffects(s, ctx, sel)
+	if err != nil {
+		return models.ResolvedUnit{}, err
+	}
+	return applyEffects(unit, sources, sel.Conditions), nil
+}
+
+// selectedEffects loads the effects of everything in sel, in the order
+// abilities, enhancements, battle formation.
+func selectedEffects(s *state.State, ctx context.Context, sel models.EffectSelection) ([]effectSource, error) {
+	var sources []effectSource
+
+	for _, id := range sel.AbilityIDs {
+		a, err := GetAbilityByID(s, ctx, id)
+		if err != nil {
+			return nil, effectSourceError(err, models.EffectSourceAbility, id)
+		}
+		sources = append(sources, effectSource{kind: models.EffectSourceAbility, id: a.ID, name: a.Name, effects: a.Effects})
+	}
+
+	for _, id := range sel.EnhancementIDs {
+		e, err := GetEnhancementByID(s, ctx, id)
+		if err != nil {
+			return nil, effectSourceError(err, models.EffectSourceEnhancement, id)
+		}
+		sources = append(sources, effectSource{kind: models.EffectSourceEnhancement, id: e.ID, name: e.Name, effects: e.Effects})
+	}
+
+	if sel.BattleFormationID != uuid.Nil {
+		f, err := GetBattleFormationByID(s, ctx, sel.BattleFormationID)
+		if err != nil {
+			return nil, effectSourceError(err, models.EffectSourceBattleFormation, sel.BattleFormationID)
+		}
+		sources = append(sources, effectSource{kind: models.EffectSourceBattleFormation, id: f.ID, name: f.Name, effects: f.Effects})
+	}
+
+	return sources, nil
+}
+
+func effectSourceError(err error, kind string, id uuid.UUID) error {
+	if errors.Is(err, appErr.ErrNotFound) || errors.Is(err, appErr.ErrMissingID) {
+		return fmt.Errorf("%w: %s %s", appErr.ErrInvalidReference, kind, id)
+	}
+	return err
+}
+
+// applyEffects applies every effect of sources in turn to a copy of unit.
+func applyEffects(unit models.Unit, sources []effectSource, conditions []string) models.ResolvedUnit {
+	unit.Weapons = slices.Clone(unit.Weapons)
+	trace := []models.StatChange{}
+
+	active := make(map[string]bool, len(conditions))
+	for _, c := range conditions {
+		active[conditionKey(c)] = true
+	}
+
+	for _, src := range sources {
+		for _, e := range src.effects {
+			change := models.StatChange{
+				EffectID:   e.ID,
+				SourceType: src.kind,
+				SourceID:   src.id,
+				SourceName: src.name,
+				Stat:       e.Stat,
+				Modifier:   e.Modifier,
+				Condition:  e.Condition,
+			}
+			if field, ok := unitStatField(&unit, e.Stat); ok {
+				trace = append(trace, applyUnitEffect(change, field, active))
+				continue
+			}
+			if isWeaponStat(e.Stat) {
+				trace = append(trace, applyWeaponEffect(change, unit.Weapons, active)...)
+				continue
+			}
+			change.Reason = "unknown stat"
+			trace = append(trace, change)
+		}
+	}
+
+	return models.ResolvedUnit{Unit: unit, Trace: trace}
+}
+
+func applyUnitEffect(change models.StatChange, field statField, active map[string]bool) models.StatChange {
+	if change.Condition != "" && !active[conditionKey(change.Condition)] {
+		change.Reason = "condition not active"
+		return change
+	}
+	return modifyStat(change, field)
+}
+
+// applyWeaponEffect applies an effect to every weapon it covers. A condition
+// naming a weapon type or weapon picks those weapons; any other condition
+// must be active and covers all of them.
+func applyWeaponEffect(change models.StatChange, weapons []models.Weapon, active map[string]bool) []models.StatChange {
+	cond := conditionKey(change.Condition)
+	all := cond == "" || active[cond]
+
+	var changes []models.StatChange
+	for i := range weapons {
+		w := &weapons[i]
+		if !all && cond != conditionKey(w.WeaponType) && cond != conditionKey(w.Name) {
+			continue
+		}
+
+		c := change
+		c.WeaponID = &w.ID
+		c.Weapon = w.Name
+		field, _ := weaponStatField(w, change.Stat)
+		changes = append(changes, modifyStat(c, field))
+	}
+
+	if len(changes) == 0 {
+		change.Reason = "condition not active"
+		return []models.StatChange{change}
+	}
+	return changes
+}
+
+func modifyStat(change models.StatChange, field statField) models.StatChange {
+	change.From = *field.raw
+
+	modified, err := field.value.Modify(change.Modifier)
+	if err != nil {
+		change.Reason = "stat cannot be modified"
+		return change
+	}
+
+	*field.raw = modified.Raw
+	*field.value = modified
+	change.To = modified.Raw
+	change.Applied = true
+	return change
+}
+
+// statKey normalises a stat or condition name: "To Hit" and "to-hit" are
+// both "to_hit".
+func statKey(name string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+func conditionKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// unitStatField finds the unit stat an effect names. Both the Age of Sigmar
+// and the Warhammer 40,000 names are accepted.
+func unitStatField(u *models.Unit, stat string) (statField, bool) {
+	switch statKey(stat) {
+	case "move":
+		return statField{&u.Move, &u.Stats.Move}, true
+	case "health", "wounds", "health_wounds":
+		return statField{&u.HealthWounds, &u.Stats.HealthWounds}, true
+	case "save", "save_stats":
+		return statField{&u.Save, &u.Stats.Save}, true
+	case "ward", "fnp", "feel_no_pain", "ward_fnp":
+		return statField{&u.WardFNP, &u.Stats.WardFNP}, true
+	case "invuln", "invulnerable_save", "invuln_save":
+		return statField{&u.InvulnSave, &u.Stats.InvulnSave}, true
+	case "control", "oc", "objective_control", "control_oc":
+		return statField{&u.ControlOC, &u.Stats.ControlOC}, true
+	case "toughness":
+		return statField{&u.Toughness, &u.Stats.Toughness}, true
+	case "leadership", "bravery", "leadership_bravery":
+		return statField{&u.Leadership, &u.Stats.Leadership}, true
+	}
+	return statField{}, false
+}
+
+// weaponStatField finds the weapon stat an effect names.
+func weaponStatField(w *models.Weapon, stat string) (statField, bool) {
+	switch statKey(stat) {
+	case "range":
+		return statField{&w.Range, &w.Stats.Range}, true
+	case "attacks":
+		return statField{&w.Attacks, &w.Stats.Attacks}, true
+	case "hit", "to_hit", "hit_stats":
+		return statField{&w.HitStats, &w.Stats.HitStats}, true
+	case "wound", "to_wound", "strength", "wound_strength":
+		return statField{&w.WoundStrength, &w.Stats.WoundStrength}, true
+	case "rend", "ap", "rend_ap":
+		return statField{&w.RendAP, &w.Stats.RendAP}, true
+	case "damage":
+		return statField{&w.Damage, &w.Stats.Damage}, true
+	}
+	return statField{}, false
+}
+
+func isWeaponStat(stat string) bool {
+	_, ok := weaponStatField(&models.Weapon{}, stat)
+	return ok
+}
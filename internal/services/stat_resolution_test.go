@@ -0,0 +1,87 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
+)
+
+func resolutionUnit() models.Unit {
+	unit := models.Unit{Name: "Liberators", Save: "4+", HealthWounds: "2", Move: `5"`}
+	unit.Stats, _ = stats.ParseUnit(stats.UnitLine{Save: unit.Save, HealthWounds: unit.HealthWounds, Move: unit.Move})
+
+	for _, w := range []models.Weapon{
+		{ID: uuid.New(), Name: "Warhammer", WeaponType: "melee", Attacks: "2", HitStats: "3+", WoundStrength: "3+", RendAP: "1", Damage: "1"},
+		{ID: uuid.New(), Name: "Grandhammer", WeaponType: "melee", Attacks: "2", HitStats: "4+", WoundStrength: "3+", RendAP: "1", Damage: "D3"},
+		{ID: uuid.New(), Name: "Boltstorm Pistol", WeaponType: "ranged", Range: `10"`, Attacks: "2", HitStats: "3+", WoundStrength: "4+", RendAP: "-", Damage: "1"},
+	} {
+		w.Stats, _ = stats.ParseWeapon(stats.WeaponLine{Range: w.Range, Attacks: w.Attacks, HitStats: w.HitStats, WoundStrength: w.WoundStrength, RendAP: w.RendAP, Damage: w.Damage})
+		unit.Weapons = append(unit.Weapons, w)
+	}
+	return unit
+}
+
+func TestApplyEffects(t *testing.T) {
+	unit := resolutionUnit()
+	sources := []effectSource{
+		{kind: models.EffectSourceAbility, name: "Mystic Shield", effects: []models.AbilityEffect{
+			{Stat: "Save", Modifier: 1},
+			{Stat: "Rend", Modifier: 1, Condition: "Melee"},
+			{Stat: "to-hit", Modifier: 1, Condition: "charged"},
+		}},
+		{kind: models.EffectSourceEnhancement, name: "Hammer of Judgement", effects: []models.AbilityEffect{
+			{Stat: "damage", Modifier: 1, Condition: "Grandhammer"},
+			{Stat: "move", Modifier: 2, Condition: "first turn"},
+		}},
+		{kind: models.EffectSourceBattleFormation, name: "Strike Force", effects: []models.AbilityEffect{
+			{Stat: "Bloodlust", Modifier: 1},
+			{Stat: "range", Modifier: 2},
+		}},
+	}
+
+	resolved := applyEffects(unit, sources, []string{"Charged"})
+	u := resolved.Unit
+
+	if u.Save != "3+" || u.Stats.Save.Min != 3 || u.Move != `5"` {
+		t.Errorf("expected a 3+ save and an unchanged move, got %q and %q", u.Save, u.Move)
+	}
+
+	hammer, grandhammer, pistol := u.Weapons[0], u.Weapons[1], u.Weapons[2]
+	if hammer.RendAP != "2" || grandhammer.RendAP != "2" || pistol.RendAP != "-" {
+		t.Errorf("expected rend 2 on melee weapons only, got %q, %q and %q", hammer.RendAP, grandhammer.RendAP, pistol.RendAP)
+	}
+	if hammer.HitStats != "2+" || pistol.HitStats != "2+" || hammer.Stats.HitStats.Min != 2 {
+		t.Errorf("expected the active condition to improve every hit roll, got %+v", hammer.Stats.HitStats)
+	}
+	if grandhammer.Damage != "D3+1" || hammer.Damage != "1" || grandhammer.Stats.Damage.Mean != 3 {
+		t.Errorf("expected D3+1 damage on the grandhammer only, got %q and %q", grandhammer.Damage, hammer.Damage)
+	}
+	if pistol.Range != `12"` || hammer.Range != "" {
+		t.Errorf("expected 12\" on the pistol and no range on melee, got %q and %q", pistol.Range, hammer.Range)
+	}
+
+	if unit.Save != "4+" || unit.Weapons[1].Damage != "D3" {
+		t.Errorf("expected the unit passed in to be left alone, got %q and %q", unit.Save, unit.Weapons[1].Damage)
+	}
+
+	reasons := map[string]int{}
+	applied := 0
+	for _, c := range resolved.Trace {
+		if c.Applied {
+			applied++
+			continue
+		}
+		reasons[c.Reason]++
+	}
+
+	// save, 2 rends, 3 hits, damage, 1 range; melee weapons have no range.
+	if applied != 8 {
+		t.Errorf("expected 8 changes, got %d: %+v", applied, resolved.Trace)
+	}
+	if reasons["condition not active"] != 1 || reasons["unknown stat"] != 1 || reasons["stat cannot be modified"] != 2 {
+		t.Errorf("unexpected skipped effects %+v", reasons)
+	}
+}
@@ -26,7 +26,7 @@ func setupTestDB(t *testing.T) *state.State {
 	queries := database.New(dbpool)
 
 	tables := []string{
-		"ability_effects", "abilities", "unit_keywords", "weapons", "units", "factions",
+		"army_lists", "ability_effects", "abilities", "unit_keywords", "weapons", "units", "factions",
 		"keywords", "battle_formations", "enhancements", "rules", "games",
 	}
 
@@ -124,6 +124,7 @@ func createTestUnit(t *testing.T, s *state.State, factionID uuid.UUID) uuid.UUID
 		MatchedPlay:     true,
 		IsManifestation: false,
 		IsUnique:        false,
+		CanBeReinforced: true,
 		AdditionalStats: json.RawMessage("{}"),
 		Version:         "1.0",
 		Source:          "Test Source",
@@ -154,6 +155,7 @@ func createTestUnitWithName(t *testing.T, s *state.State, factionID uuid.UUID, n
 		MatchedPlay:     true,
 		IsManifestation: false,
 		IsUnique:        false,
+		CanBeReinforced: true,
 		AdditionalStats: json.RawMessage("{}"),
 		Version:         "1.0",
 		Source:          "Test Source",
@@ -272,3 +274,185 @@ func createTestAbilityUnitWithName(t *testing.T, s *state.State, unitID uuid.UUI
 
 	return ability.ID
 }
+
+func createTestHero(t *testing.T, s *state.State, gameID, factionID uuid.UUID, name string) uuid.UUID {
+	ctx := context.Background()
+
+	unit, err := s.DB.CreateUnit(ctx, database.CreateUnitParams{
+		FactionID:       factionID,
+		Name:            name,
+		Move:            "5\"",
+		HealthWounds:    "6",
+		SaveStats:       "3+",
+		ControlOc:       "2",
+		Points:          150,
+		MinUnitSize:     1,
+		MaxUnitSize:     1,
+		MatchedPlay:     true,
+		AdditionalStats: json.RawMessage("{}"),
+		Version:         "1.0",
+		Source:          "Test Source",
+	})
+	if err != nil {
+		t.Fatalf("failed to create hero: %v", err)
+	}
+
+	addTestKeyword(t, s, gameID, unit.ID, "HERO")
+
+	return unit.ID
+}
+
+func addTestKeyword(t *testing.T, s *state.State, gameID, unitID uuid.UUID, name string) {
+	ctx := context.Background()
+
+	keyword, err := s.DB.CreateKeyword(ctx, database.CreateKeywordParams{
+		GameID:  gameID,
+		Name:    name,
+		Version: "1.0",
+		Source:  "Test Source",
+	})
+	if err != nil {
+		t.Fatalf("failed to create keyword: %v", err)
+	}
+
+	err = s.DB.AddKeywordToUnit(ctx, database.AddKeywordToUnitParams{
+		UnitID:    unitID,
+		KeywordID: keyword.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to add keyword to unit: %v", err)
+	}
+}
+
+func createTestRegimentOption(t *testing.T, s *state.State, unitID uuid.UUID, keyword string, isHero bool) {
+	ctx := context.Background()
+
+	_, err := s.DB.CreateRegimentOption(ctx, database.CreateRegimentOptionParams{
+		UnitID:  unitID,
+		Keyword: keyword,
+		IsHero:  isHero,
+	})
+	if err != nil {
+		t.Fatalf("failed to create regiment option: %v", err)
+	}
+}
+
+func createTestEnhancement(t *testing.T, s *state.State, factionID uuid.UUID, name, enhancementType, requiredKeywords string) uuid.UUID {
+	ctx := context.Background()
+
+	enhancement, err := s.DB.CreateEnhancement(ctx, database.CreateEnhancementParams{
+		FactionID:        factionID,
+		Name:             name,
+		EnhancementType:  enhancementType,
+		Description:      "Enhancement Description",
+		RequiredKeywords: json.RawMessage(requiredKeywords),
+		Points:           20,
+		IsUnique:         true,
+		Version:          "1.0",
+		Source:           "Test Source",
+	})
+	if err != nil {
+		t.Fatalf("failed to create enhancement: %v", err)
+	}
+
+	return enhancement.ID
+}
+
+func createTestBattleFormation(t *testing.T, s *state.State, gameID, factionID uuid.UUID) uuid.UUID {
+	ctx := context.Background()
+
+	battleFormation, err := s.DB.CreateBattleFormation(ctx, database.CreateBattleFormationParams{
+		GameID:      gameID,
+		FactionID:   factionID,
+		Name:        "Test BattleFormation",
+		Description: "Formation Description",
+		Version:     "1.0",
+		Source:      "Test Source",
+	})
+	if err != nil {
+		t.Fatalf("failed to create battle formation: %v", err)
+	}
+
+	return battleFormation.ID
+}
+
+func createTestArmyOfRenown(t *testing.T, s *state.State, gameID, parentID uuid.UUID, name string) uuid.UUID {
+	ctx := context.Background()
+
+	faction, err := s.DB.CreateFaction(ctx, database.CreateFactionParams{
+		GameID:             gameID,
+		Name:               name,
+		Allegiance:         "Test Allegiance",
+		Version:            "1.0",
+		Source:             "Test Source",
+		IsArmyOfRenown:     true,
+		IsRegimentOfRenown: false,
+		ParentFactionID:    database.UUIDToNullUUID(parentID),
+	})
+	if err != nil {
+		t.Fatalf("failed to create army of renown: %v", err)
+	}
+
+	return faction.ID
+}
+
+func createTestAllowedUnit(t *testing.T, s *state.State, factionID uuid.UUID, unitName string) {
+	ctx := context.Background()
+
+	_, err := s.DB.CreateAllowedUnit(ctx, database.CreateAllowedUnitParams{
+		FactionID: factionID,
+		UnitName:  unitName,
+	})
+	if err != nil {
+		t.Fatalf("failed to create allowed unit: %v", err)
+	}
+}
+
+func createTestRegimentOfRenown(t *testing.T, s *state.State, gameID uuid.UUID, name, allegiance string, points int32) uuid.UUID {
+	ctx := context.Background()
+
+	faction, err := s.DB.CreateFaction(ctx, database.CreateFactionParams{
+		GameID:             gameID,
+		Name:               name,
+		Allegiance:         allegiance,
+		Version:            "1.0",
+		Source:             "Test Source",
+		IsArmyOfRenown:     false,
+		IsRegimentOfRenown: true,
+		ParentFactionID:    uuid.NullUUID{},
+		Points:             points,
+	})
+	if err != nil {
+		t.Fatalf("failed to create regiment of renown: %v", err)
+	}
+
+	return faction.ID
+}
+
+func createTestRegimentOfRenownHost(t *testing.T, s *state.State, factionID uuid.UUID, hostName string) {
+	ctx := context.Background()
+
+	_, err := s.DB.CreateRegimentOfRenownHost(ctx, database.CreateRegimentOfRenownHostParams{
+		FactionID:       factionID,
+		HostFactionName: hostName,
+	})
+	if err != nil {
+		t.Fatalf("failed to create regiment of renown host: %v", err)
+	}
+}
+
+func createTestGameWithName(t *testing.T, s *state.State, name string) uuid.UUID {
+	ctx := context.Background()
+
+	game, err := s.DB.CreateGame(ctx, database.CreateGameParams{
+		Name:    name,
+		Edition: "Test Edition",
+		Version: "1.0",
+		Source:  "Test Source",
+	})
+	if err != nil {
+		t.Fatalf("failed to create game with name: %v", err)
+	}
+
+	return game.ID
+}
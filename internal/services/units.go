@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 
@@ -12,6 +13,7 @@ import (
 	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
 	"github.com/JohnG-Dev/army_builder_api/internal/models"
 	"github.com/JohnG-Dev/army_builder_api/internal/state"
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
 )
 
 func mapDBUnitToModel(u database.Unit) models.Unit {
@@ -44,24 +46,40 @@ func mapDBUnitToModel(u database.Unit) models.Unit {
 		Banishment:      u.Banishment,
 		MinUnitSize:     int(u.MinUnitSize),
 		MaxUnitSize:     int(u.MaxUnitSize),
+		CanBeReinforced: u.CanBeReinforced,
 		MatchedPlay:     u.MatchedPlay,
 		Version:         u.Version,
 		Source:          u.Source,
+		BattlescribeID:  u.BattlescribeID,
 		CreatedAt:       u.CreatedAt,
 		UpdatedAt:       u.UpdatedAt,
+
+		Stats: parseUnitStats(u),
 	}
 }
 
-func GetUnits(s *state.State, ctx context.Context, factionID *uuid.UUID) ([]models.Unit, error) {
-	var dbUnits []database.Unit
-	var err error
+// parseUnitStats parses a unit's stat line. Values that do not parse are
+// kept as unknown; the seeder reports them when the data is loaded.
+func parseUnitStats(u database.Unit) stats.UnitStats {
+	parsed, _ := stats.ParseUnit(stats.UnitLine{
+		Move:         u.Move,
+		HealthWounds: u.HealthWounds,
+		Save:         u.SaveStats,
+		WardFNP:      u.WardFnp,
+		InvulnSave:   u.InvulnSave,
+		ControlOC:    u.ControlOc,
+		Toughness:    u.Toughness,
+		Leadership:   u.LeadershipBravery,
+	})
+	return parsed
+}
 
-	if factionID == nil {
-		dbUnits, err = s.DB.GetAllUnits(ctx)
-	} else {
-		dbUnits, err = s.DB.GetUnitsByFaction(ctx, *factionID)
+func GetUnitsByFaction(s *state.State, ctx context.Context, factionID uuid.UUID) ([]models.Unit, error) {
+	if factionID == uuid.Nil {
+		return nil, appErr.ErrMissingID
 	}
 
+	dbUnits, err := s.DB.GetUnitsByFaction(ctx, factionID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return []models.Unit{}, nil
@@ -77,13 +95,6 @@ func GetUnits(s *state.State, ctx context.Context, factionID *uuid.UUID) ([]mode
 	return units, nil
 }
 
-func GetUnitsByFaction(s *state.State, ctx context.Context, factionID uuid.UUID) ([]models.Unit, error) {
-	if factionID == uuid.Nil {
-		return nil, appErr.ErrMissingID
-	}
-	return GetUnits(s, ctx, &factionID)
-}
-
 func GetUnitByID(s *state.State, ctx context.Context, id uuid.UUID) (models.Unit, error) {
 	if id == uuid.Nil {
 		return models.Unit{}, appErr.ErrMissingID
@@ -108,6 +119,9 @@ func GetUnitByID(s *state.State, ctx context.Context, id uuid.UUID) (models.Unit
 	keywords, _ := GetKeywordsForUnit(s, ctx, id)
 	unit.Keywords = keywords
 
+	regimentOptions, _ := GetRegimentOptionsForUnit(s, ctx, id)
+	unit.RegimentOptions = regimentOptions
+
 	return unit, nil
 }
 
@@ -172,24 +186,41 @@ func GetManifestationByID(s *state.State, ctx context.Context, id uuid.UUID) (mo
 	return unit, nil
 }
 
-func GetUnitsByMatchedPlay(s *state.State, ctx context.Context, factionID uuid.UUID) ([]models.Unit, error) {
-	if factionID == uuid.Nil {
-		return nil, appErr.ErrMissingFactionID
-	}
-
-	dbUnits, err := s.DB.GetUnitsByMatchedPlay(ctx, factionID)
+// ListUnits returns a page of units. Manifestations are left out unless
+// the IsManifestation filter asks for them.
+func ListUnits(s *state.State, ctx context.Context, p models.ListParams) (models.Page[models.Unit], error) {
+	lp, err := newListPage(p, "name", "points", "created_at")
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return []models.Unit{}, nil
-		}
-		return nil, err
-	}
-
-	units := make([]models.Unit, len(dbUnits))
-	for i, u := range dbUnits {
-		units[i] = mapDBUnitToModel(u)
+		return models.Page[models.Unit]{}, err
+	}
+
+	f := p.Filters
+	if f.Keywords != nil && f.GameID == nil && f.FactionID == nil {
+		return models.Page[models.Unit]{}, fmt.Errorf("%w: keywords need a game_id or faction_id", appErr.ErrMissingGameID)
+	}
+
+	dbUnits, err := s.DB.ListUnits(ctx, database.ListUnitsParams{
+		IsManifestation: f.IsManifestation != nil && *f.IsManifestation,
+		FactionID:       nullUUID(f.FactionID),
+		GameID:          nullUUID(f.GameID),
+		PointsMin:       nullInt4(f.PointsMin),
+		PointsMax:       nullInt4(f.PointsMax),
+		IsUnique:        nullBool(f.IsUnique),
+		MatchedPlay:     nullBool(f.MatchedPlay),
+		Allegiance:      nullText(f.Allegiance),
+		Keywords:        keywordQuery(f.Keywords),
+		AfterID:         lp.afterID(),
+		Sort:            lp.sort,
+		AfterName:       lp.afterName(),
+		AfterPoints:     lp.afterPoints(),
+		AfterCreatedAt:  lp.afterCreatedAt(),
+		PageSize:        lp.pageSize(),
+	})
+	if err != nil {
+		return models.Page[models.Unit]{}, err
 	}
 
-	return units, nil
+	return paginate(lp, dbUnits, func(u database.Unit) listCursor {
+		return listCursor{ID: u.ID, Name: u.Name, Points: u.Points, CreatedAt: u.CreatedAt}
+	}, mapDBUnitToModel), nil
 }
-
@@ -9,6 +9,7 @@ import (
 
 	"github.com/JohnG-Dev/army_builder_api/internal/database"
 	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
 )
 
 func TestGetUnitByID_DeepMapping(t *testing.T) {
@@ -70,6 +71,14 @@ func TestGetUnitByID_DeepMapping(t *testing.T) {
 		t.Errorf("expected name: Judicator, got %s", unit1.Name)
 	}
 
+	if unit1.Stats.Move.Max != 10 || unit1.Stats.Save.Kind != stats.KindThreshold || unit1.Stats.Save.Min != 3 {
+		t.Errorf("expected parsed stats alongside the raw ones, got %+v", unit1.Stats)
+	}
+
+	if unit1.Weapons[0].Stats.RendAP.Min != 1 || unit1.Weapons[0].Stats.Damage.Mean != 2 {
+		t.Errorf("expected a parsed weapon profile, got %+v", unit1.Weapons[0].Stats)
+	}
+
 	if len(unit2.Weapons) != 0 {
 		t.Errorf("servce linked weapon to incorrect unit: expected 0, got %d", len(unit2.Weapons))
 	}
@@ -4,72 +4,321 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
+
 	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/ruleset"
 	"github.com/JohnG-Dev/army_builder_api/internal/state"
 )
 
+// referencesRule is reported for IDs in the list that could not be loaded.
+// It is not part of any ruleset and always applies.
+const referencesRule = "references"
+
+// armyCheck is a list with everything it refers to loaded, as the rules see
+// it. IDs that failed to load are missing from the maps.
+type armyCheck struct {
+	req          models.ArmyValidationRequest
+	faction      models.Faction
+	hostNames    []string
+	units        map[uuid.UUID]models.Unit
+	enhancements map[uuid.UUID]models.Enhancement
+	formation    *models.BattleFormation
+	renown       []renownHire
+	points       int
+	reinforced   int
+}
+
+// renownHire is a loaded Regiment of Renown selection. paths holds the path
+// of each of units.
+type renownHire struct {
+	faction models.Faction
+	path    string
+	units   []models.Unit
+	paths   []string
+}
+
+// ValidateArmy checks a list against the ruleset of its game. Every rule that
+// reports an issue is listed in FiredRules; only error issues make the list
+// invalid.
 func ValidateArmy(s *state.State, ctx context.Context, req models.ArmyValidationRequest) (models.ValidationResponse, error) {
 	resp := models.ValidationResponse{
-		IsValid:     true,
-		Errors:      []string{},
-		TotalPoints: 0,
+		IsValid:    true,
+		Issues:     []models.ValidationIssue{},
+		Errors:     []string{},
+		Warnings:   []string{},
+		FiredRules: []string{},
+	}
+
+	a, missing := loadArmy(s, ctx, req)
+	addIssues(&resp, ruleset.Rule{ID: referencesRule, Severity: ruleset.SeverityError}, missing)
+
+	rs, err := armyRuleset(s, ctx, a)
+	if err != nil {
+		return models.ValidationResponse{}, err
+	}
+	resp.Ruleset = rs.Name
+
+	for _, rule := range rs.Rules {
+		check, ok := ruleChecks[rule.ID]
+		if !ok {
+			return models.ValidationResponse{}, fmt.Errorf("ruleset %s: unknown rule %s", rs.Name, rule.ID)
+		}
+		addIssues(&resp, rule, check(a, rule.Params))
+	}
+
+	resp.TotalPoints = a.points
+	resp.ReinforcedUnits = a.reinforced
+	resp.AuxiliaryUnits = countEntries(req.Auxiliaries)
+	resp.Drops = len(req.Regiments) + resp.AuxiliaryUnits
+
+	if len(resp.Errors) > 0 {
+		resp.IsValid = false
+	}
+	return resp, nil
+}
+
+// addIssues records the issues rule found with the rule's severity.
+func addIssues(resp *models.ValidationResponse, rule ruleset.Rule, issues []models.ValidationIssue) {
+	if len(issues) == 0 {
+		return
 	}
+	resp.FiredRules = append(resp.FiredRules, rule.ID)
+
+	for _, issue := range issues {
+		issue.Rule = rule.ID
+		issue.Severity = string(rule.Severity)
+		resp.Issues = append(resp.Issues, issue)
 
-	manifestationCount := 0
-	hasCaster := false
+		switch rule.Severity {
+		case ruleset.SeverityError:
+			resp.Errors = append(resp.Errors, issue.Message)
+		case ruleset.SeverityWarning:
+			resp.Warnings = append(resp.Warnings, issue.Message)
+		}
+	}
+}
 
-	for _, u := range req.Units {
-		currentID := u.UnitID
-		currentQTY := u.Quantity
+// CheckRulesets reports the first rule in reg that ValidateArmy does not know.
+func CheckRulesets(reg *ruleset.Registry) error {
+	for _, rs := range reg.Rulesets() {
+		for _, rule := range rs.Rules {
+			if _, ok := ruleChecks[rule.ID]; !ok {
+				return fmt.Errorf("ruleset %s: unknown rule %s", rs.Name, rule.ID)
+			}
+		}
+	}
+	return nil
+}
 
-		unit, err := GetUnitByID(s, ctx, currentID)
+// armyRuleset picks the ruleset for the list's game, or for its faction's game
+// when the request does not name one.
+func armyRuleset(s *state.State, ctx context.Context, a *armyCheck) (ruleset.Ruleset, error) {
+	reg := s.Rulesets
+	if reg == nil {
+		var err error
+		reg, err = ruleset.Builtin()
 		if err != nil {
-			resp.Errors = append(resp.Errors, fmt.Sprintf("Unit ID %v, not found", u.UnitID))
-			resp.IsValid = false
-			continue
+			return ruleset.Ruleset{}, err
 		}
+	}
+
+	gameID := a.req.GameID
+	if gameID == uuid.Nil {
+		gameID = a.faction.GameID
+	}
+
+	gameName := ""
+	if game, err := GetGame(s, ctx, gameID); err == nil {
+		gameName = game.Name
+	}
+
+	rs, ok := reg.ForGame(gameName)
+	if !ok {
+		return ruleset.Ruleset{}, fmt.Errorf("no ruleset for game %q", gameName)
+	}
+	return rs, nil
+}
+
+// loadArmy loads the faction, units, enhancements, battle formation and
+// Regiments of Renown of the list and tallies its points. It returns an issue
+// for every ID that could not be loaded.
+func loadArmy(s *state.State, ctx context.Context, req models.ArmyValidationRequest) (*armyCheck, []models.ValidationIssue) {
+	a := &armyCheck{
+		req:          req,
+		units:        make(map[uuid.UUID]models.Unit),
+		enhancements: make(map[uuid.UUID]models.Enhancement),
+	}
+	var missing []models.ValidationIssue
 
-		if unit.IsManifestation {
-			if u.Quantity > 1 {
-				msg := fmt.Sprintf("Can not have more than one %s manifestation, have %d", unit.Name, u.Quantity)
-				resp.Errors = append(resp.Errors, msg)
+	// A faction that fails to load still rejects units from other factions.
+	faction, err := GetFactionByID(s, ctx, req.FactionID)
+	if err != nil {
+		faction = models.Faction{ID: req.FactionID}
+	}
+	a.faction = faction
+
+	for _, e := range armyEntries(req) {
+		unit, ok := a.units[e.UnitID]
+		if !ok {
+			unit, err = GetUnitByID(s, ctx, e.UnitID)
+			if err != nil {
+				missing = append(missing, unitNotFound(e.UnitID, e.path))
+				continue
 			}
-			manifestationCount += u.Quantity
+			a.units[unit.ID] = unit
 		}
 
-		for _, k := range unit.Keywords {
-			if k.KeywordName == "WIZARD" || k.KeywordName == "PRIEST" {
-				hasCaster = true
+		// A reinforced unit fields twice its minimum size for twice the points.
+		points := int(unit.Points)
+		if e.Reinforced {
+			a.reinforced += unitEntries(e.ArmyUnit)
+			points *= 2
+		}
+		a.points += points * unitEntries(e.ArmyUnit)
+	}
+
+	for _, e := range armyEntries(req) {
+		for _, id := range e.EnhancementIDs {
+			enhancement, ok := a.enhancements[id]
+			if !ok {
+				enhancement, err = GetEnhancementByID(s, ctx, id)
+				if err != nil {
+					missing = append(missing, models.ValidationIssue{
+						Code:           "enhancement_not_found",
+						Message:        fmt.Sprintf("Enhancement ID %v, not found", id),
+						Paths:          []string{e.path},
+						EnhancementIDs: []uuid.UUID{id},
+					})
+					continue
+				}
+				a.enhancements[id] = enhancement
 			}
+			a.points += enhancement.Points
 		}
+	}
+
+	if req.BattleFormationID != nil {
+		formation, err := GetBattleFormationByID(s, ctx, *req.BattleFormationID)
+		if err != nil {
+			missing = append(missing, models.ValidationIssue{
+				Code:    "battle_formation_not_found",
+				Message: fmt.Sprintf("Battle formation ID %v, not found", *req.BattleFormationID),
+				Paths:   []string{"battle_formation_id"},
+				Params:  map[string]any{"battle_formation_id": *req.BattleFormationID},
+			})
+		} else {
+			a.formation = &formation
+		}
+	}
 
-		resp.TotalPoints += int(unit.Points) * currentQTY
+	for i, sel := range req.RegimentsOfRenown {
+		path := fmt.Sprintf("regiments_of_renown[%d]", i)
 
-		if unit.FactionID != req.FactionID {
-			resp.Errors = append(resp.Errors, fmt.Sprintf("unit %s does not belong to the selected faction", unit.Name))
+		ror, err := GetFactionByID(s, ctx, sel.FactionID)
+		if err != nil {
+			missing = append(missing, models.ValidationIssue{
+				Code:    "regiment_of_renown_not_found",
+				Message: fmt.Sprintf("Regiment of Renown ID %v, not found", sel.FactionID),
+				Paths:   []string{path},
+				Params:  map[string]any{"faction_id": sel.FactionID},
+			})
+			continue
 		}
 
-		if unit.IsUnique && currentQTY > 1 {
-			resp.Errors = append(resp.Errors, fmt.Sprintf("Unit %s is unique and unable to have more than 1 in army", unit.Name))
+		hire := renownHire{faction: ror, path: path}
+		for j, u := range sel.Units {
+			unitPath := fmt.Sprintf("%s.units[%d]", path, j)
+			unit, err := GetUnitByID(s, ctx, u.UnitID)
+			if err != nil {
+				missing = append(missing, unitNotFound(u.UnitID, unitPath))
+				continue
+			}
+			hire.units = append(hire.units, unit)
+			hire.paths = append(hire.paths, unitPath)
 		}
+		a.renown = append(a.renown, hire)
 
-		if u.Quantity < unit.MinUnitSize || u.Quantity > unit.MaxUnitSize {
-			msg := fmt.Sprintf("Unit %s has invalid size: %d, (Min: %d, Max: %d)", unit.Name, u.Quantity, unit.MinUnitSize, unit.MaxUnitSize)
-			resp.Errors = append(resp.Errors, msg)
+		if ror.IsRegimentOfRenown {
+			a.points += ror.Points
 		}
 	}
 
-	if manifestationCount > 0 && !hasCaster {
-		resp.Errors = append(resp.Errors, "Army contains manifestations but has no Wizards or priests to summon them")
+	// An Army of Renown hires as its parent faction would.
+	a.hostNames = []string{faction.Name}
+	if len(req.RegimentsOfRenown) > 0 && faction.IsArmyOfRenown && faction.ParentFactionID != nil {
+		if parent, err := GetFactionByID(s, ctx, *faction.ParentFactionID); err == nil {
+			a.hostNames = append(a.hostNames, parent.Name)
+		}
 	}
 
-	if resp.TotalPoints > req.PointsLimit {
-		msg := fmt.Sprintf("Total Points %d exceeds point limit %d", resp.TotalPoints, req.PointsLimit)
-		resp.Errors = append(resp.Errors, msg)
+	return a, missing
+}
+
+func unitNotFound(id uuid.UUID, path string) models.ValidationIssue {
+	return models.ValidationIssue{
+		Code:    "unit_not_found",
+		Message: fmt.Sprintf("Unit ID %v, not found", id),
+		Paths:   []string{path},
+		UnitIDs: []uuid.UUID{id},
 	}
+}
 
-	if len(resp.Errors) > 0 {
-		resp.IsValid = false
+// armyEntry is a unit of the request and the path it was picked at.
+type armyEntry struct {
+	models.ArmyUnit
+	path string
+}
+
+// armyEntries lists every unit in the request, whichever part of it the unit
+// was picked in.
+func armyEntries(req models.ArmyValidationRequest) []armyEntry {
+	var all []armyEntry
+	for i, reg := range req.Regiments {
+		all = append(all, armyEntry{reg.Leader, fmt.Sprintf("regiments[%d].leader", i)})
+		for j, u := range reg.Units {
+			all = append(all, armyEntry{u, fmt.Sprintf("regiments[%d].units[%d]", i, j)})
+		}
 	}
-	return resp, nil
+	for i, u := range req.Auxiliaries {
+		all = append(all, armyEntry{u, fmt.Sprintf("auxiliaries[%d]", i)})
+	}
+	for i, u := range req.Units {
+		all = append(all, armyEntry{u, fmt.Sprintf("units[%d]", i)})
+	}
+	return all
+}
+
+// unitEntries is how many entries of the unit u stands for.
+func unitEntries(u models.ArmyUnit) int {
+	if u.Quantity < 1 {
+		return 1
+	}
+	return u.Quantity
+}
+
+func countEntries(units []models.ArmyUnit) int {
+	total := 0
+	for _, u := range units {
+		total += unitEntries(u)
+	}
+	return total
+}
+
+func hasKeyword(unit models.Unit, name string) bool {
+	for _, k := range unit.Keywords {
+		if k.KeywordName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyKeyword(unit models.Unit, names []string) bool {
+	for _, name := range names {
+		if hasKeyword(unit, name) {
+			return true
+		}
+	}
+	return false
 }
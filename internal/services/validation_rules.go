@@ -0,0 +1,569 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/ruleset"
+)
+
+// A regiment is its leader plus up to regimentMaxUnits other units. The
+// general's regiment can take generalRegimentMaxUnits. Rulesets can change
+// both.
+const (
+	regimentMaxUnits        = 3
+	generalRegimentMaxUnits = 4
+)
+
+// ruleCheck returns an issue for every way the list breaks the rule. The
+// rule and severity of the issues are filled in by ValidateArmy.
+type ruleCheck func(a *armyCheck, p ruleset.Params) []models.ValidationIssue
+
+// ruleChecks maps the rule IDs a ruleset can list to their checks.
+var ruleChecks = map[string]ruleCheck{
+	"faction_units":       checkFactionUnits,
+	"unique_units":        checkUniqueUnits,
+	"reinforcements":      checkReinforcements,
+	"manifestations":      checkManifestations,
+	"regiments":           checkRegiments,
+	"battle_formation":    checkBattleFormation,
+	"enhancements":        checkEnhancements,
+	"regiments_of_renown": checkRegimentsOfRenown,
+	"rule_of_three":       checkRuleOfThree,
+	"points_limit":        checkPointsLimit,
+}
+
+// checkFactionUnits rejects units the faction can not field.
+func checkFactionUnits(a *armyCheck, _ ruleset.Params) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+	for _, e := range armyEntries(a.req) {
+		unit, ok := a.units[e.UnitID]
+		if !ok || factionPermitsUnit(a.faction, unit) {
+			continue
+		}
+
+		issue := models.ValidationIssue{
+			Code:    "unit_not_in_faction",
+			Message: fmt.Sprintf("unit %s does not belong to the selected faction", unit.Name),
+			Paths:   []string{e.path},
+			UnitIDs: []uuid.UUID{unit.ID},
+		}
+		if isParentUnit(a.faction, unit) {
+			issue.Code = "unit_not_available"
+			issue.Message = fmt.Sprintf("unit %s is not available to %s", unit.Name, a.faction.Name)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// unitTally counts the entries of each unit in the list and where they were
+// picked, in the order the units first appear.
+type unitTally struct {
+	ids   []uuid.UUID
+	count map[uuid.UUID]int
+	paths map[uuid.UUID][]string
+}
+
+func tallyUnits(a *armyCheck, include func(models.Unit) bool) unitTally {
+	t := unitTally{count: make(map[uuid.UUID]int), paths: make(map[uuid.UUID][]string)}
+	for _, e := range armyEntries(a.req) {
+		unit, ok := a.units[e.UnitID]
+		if !ok || !include(unit) {
+			continue
+		}
+		if t.count[unit.ID] == 0 {
+			t.ids = append(t.ids, unit.ID)
+		}
+		t.count[unit.ID] += unitEntries(e.ArmyUnit)
+		t.paths[unit.ID] = append(t.paths[unit.ID], e.path)
+	}
+	return t
+}
+
+// checkUniqueUnits allows Max entries (default 1) of each unique unit.
+func checkUniqueUnits(a *armyCheck, p ruleset.Params) []models.ValidationIssue {
+	limit := p.Max
+	if limit == 0 {
+		limit = 1
+	}
+
+	t := tallyUnits(a, func(u models.Unit) bool { return u.IsUnique })
+
+	var issues []models.ValidationIssue
+	for _, id := range t.ids {
+		if t.count[id] <= limit {
+			continue
+		}
+		issues = append(issues, models.ValidationIssue{
+			Code:    "unique_unit_limit",
+			Message: fmt.Sprintf("Unit %s is unique and unable to have more than %d in army", a.units[id].Name, limit),
+			Paths:   t.paths[id],
+			UnitIDs: []uuid.UUID{id},
+			Params:  map[string]any{"limit": limit, "actual": t.count[id]},
+		})
+	}
+	return issues
+}
+
+// checkReinforcements rejects reinforcing units that can not be reinforced
+// and caps the number of reinforced units. The cap is the Max of the first of
+// Limits the points limit fits in, or Max when it fits in none; zero is no
+// cap.
+func checkReinforcements(a *armyCheck, p ruleset.Params) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+	var reinforced []string
+	for _, e := range armyEntries(a.req) {
+		if !e.Reinforced {
+			continue
+		}
+		reinforced = append(reinforced, e.path)
+
+		unit, ok := a.units[e.UnitID]
+		if ok && !unit.CanBeReinforced {
+			issues = append(issues, models.ValidationIssue{
+				Code:    "unit_not_reinforceable",
+				Message: fmt.Sprintf("Unit %s can not be reinforced", unit.Name),
+				Paths:   []string{e.path},
+				UnitIDs: []uuid.UUID{unit.ID},
+			})
+		}
+	}
+
+	maxReinforced := p.Max
+	for _, l := range p.Limits {
+		if a.req.PointsLimit <= l.Points {
+			maxReinforced = l.Max
+			break
+		}
+	}
+
+	if maxReinforced > 0 && a.reinforced > maxReinforced {
+		issues = append(issues, models.ValidationIssue{
+			Code:    "reinforcement_limit",
+			Message: fmt.Sprintf("Army has %d reinforced units, max %d for a %d point army", a.reinforced, maxReinforced, a.req.PointsLimit),
+			Paths:   reinforced,
+			Params:  map[string]any{"limit": maxReinforced, "actual": a.reinforced, "points_limit": a.req.PointsLimit},
+		})
+	}
+	return issues
+}
+
+// checkManifestations allows one entry of each manifestation, and only in an
+// army with a unit carrying one of Keywords to summon them.
+func checkManifestations(a *armyCheck, p ruleset.Params) []models.ValidationIssue {
+	var issues []models.ValidationIssue
+	var manifestations []string
+	var manifestationIDs []uuid.UUID
+	hasCaster := false
+
+	for _, e := range armyEntries(a.req) {
+		unit, ok := a.units[e.UnitID]
+		if !ok {
+			continue
+		}
+
+		if unit.IsManifestation {
+			if entries := unitEntries(e.ArmyUnit); entries > 1 {
+				issues = append(issues, models.ValidationIssue{
+					Code:    "manifestation_limit",
+					Message: fmt.Sprintf("Can not have more than one %s manifestation, have %d", unit.Name, entries),
+					Paths:   []string{e.path},
+					UnitIDs: []uuid.UUID{unit.ID},
+					Params:  map[string]any{"limit": 1, "actual": entries},
+				})
+			}
+			manifestations = append(manifestations, e.path)
+			manifestationIDs = append(manifestationIDs, unit.ID)
+		}
+
+		if hasAnyKeyword(unit, p.Keywords) {
+			hasCaster = true
+		}
+	}
+
+	if len(manifestations) > 0 && !hasCaster {
+		issues = append(issues, models.ValidationIssue{
+			Code:    "manifestation_without_caster",
+			Message: "Army contains manifestations but has no Wizards or priests to summon them",
+			Paths:   manifestations,
+			UnitIDs: manifestationIDs,
+			Params:  map[string]any{"keywords": p.Keywords},
+		})
+	}
+	return issues
+}
+
+// checkRegiments checks the regiment structure of the list. A regiment is led
+// by a unit with one of Keywords (default HERO) and takes up to Max other units,
+// or GeneralMax for the general's.
+func checkRegiments(a *armyCheck, p ruleset.Params) []models.ValidationIssue {
+	req := a.req
+	if len(req.Regiments) == 0 && len(req.Auxiliaries) == 0 {
+		return nil
+	}
+
+	leaderKeywords := p.Keywords
+	if len(leaderKeywords) == 0 {
+		leaderKeywords = []string{"HERO"}
+	}
+	maxUnits := p.Max
+	if maxUnits == 0 {
+		maxUnits = regimentMaxUnits
+	}
+	generalMaxUnits := p.GeneralMax
+	if generalMaxUnits == 0 {
+		generalMaxUnits = generalRegimentMaxUnits
+	}
+
+	var issues []models.ValidationIssue
+
+	var generals []string
+	for i, reg := range req.Regiments {
+		if reg.IsGeneral {
+			generals = append(generals, fmt.Sprintf("regiments[%d]", i))
+		}
+	}
+	switch {
+	case len(req.Regiments) == 0:
+		issues = append(issues, models.ValidationIssue{
+			Code:    "no_regiments",
+			Message: "Army has auxiliary units but no regiments",
+			Paths:   []string{"regiments"},
+		})
+	case len(generals) == 0:
+		issues = append(issues, models.ValidationIssue{
+			Code:    "no_general",
+			Message: "No regiment is marked as the general's regiment",
+			Paths:   []string{"regiments"},
+		})
+	case len(generals) > 1:
+		issues = append(issues, models.ValidationIssue{
+			Code:    "too_many_generals",
+			Message: fmt.Sprintf("Army has %d generals, only one regiment can be the general's", len(generals)),
+			Paths:   generals,
+			Params:  map[string]any{"limit": 1, "actual": len(generals)},
+		})
+	}
+
+	for i, reg := range req.Regiments {
+		path := fmt.Sprintf("regiments[%d]", i)
+
+		limit := maxUnits
+		if reg.IsGeneral {
+			limit = generalMaxUnits
+		}
+		if units := countEntries(reg.Units); units > limit {
+			issues = append(issues, models.ValidationIssue{
+				Code:    "regiment_size",
+				Message: fmt.Sprintf("Regiment %d has %d units besides its leader, max %d", i+1, units, limit),
+				Paths:   []string{path},
+				Params:  map[string]any{"limit": limit, "actual": units},
+			})
+		}
+
+		leader, ok := a.units[reg.Leader.UnitID]
+		if !ok {
+			continue
+		}
+		if !hasAnyKeyword(leader, leaderKeywords) {
+			issues = append(issues, models.ValidationIssue{
+				Code:    "invalid_regiment_leader",
+				Message: fmt.Sprintf("Regiment %d is led by %s, which is not a %s", i+1, leader.Name, leaderKeywords[0]),
+				Paths:   []string{path + ".leader"},
+				UnitIDs: []uuid.UUID{leader.ID},
+				Params:  map[string]any{"keywords": leaderKeywords},
+			})
+			continue
+		}
+
+		issues = append(issues, regimentEligibility(leader, path, reg.Units, a.units)...)
+	}
+
+	return issues
+}
+
+// regimentEligibility checks the units of a regiment against its leader's
+// regiment options. Each hero option admits a single HERO. Leaders without
+// captured options are not checked.
+func regimentEligibility(leader models.Unit, path string, units []models.ArmyUnit, found map[uuid.UUID]models.Unit) []models.ValidationIssue {
+	if len(leader.RegimentOptions) == 0 {
+		return nil
+	}
+
+	var issues []models.ValidationIssue
+	used := make([]bool, len(leader.RegimentOptions))
+
+	for j, u := range units {
+		unit, ok := found[u.UnitID]
+		if !ok {
+			continue
+		}
+
+		isHero := hasKeyword(unit, "HERO")
+		for n := 0; n < unitEntries(u); n++ {
+			eligible := false
+			for i, opt := range leader.RegimentOptions {
+				if opt.IsHero != isHero || used[i] || !matchesRegimentOption(unit, opt) {
+					continue
+				}
+				eligible = true
+				if opt.IsHero {
+					used[i] = true
+				}
+				break
+			}
+
+			if !eligible {
+				issues = append(issues, models.ValidationIssue{
+					Code:    "regiment_unit_not_eligible",
+					Message: fmt.Sprintf("Unit %s can not join the regiment of %s", unit.Name, leader.Name),
+					Paths:   []string{fmt.Sprintf("%s.units[%d]", path, j)},
+					UnitIDs: []uuid.UUID{unit.ID, leader.ID},
+				})
+				break
+			}
+		}
+	}
+
+	return issues
+}
+
+func matchesRegimentOption(unit models.Unit, opt models.RegimentOption) bool {
+	if opt.UnitName != "" {
+		return unit.Name == opt.UnitName
+	}
+	return hasKeyword(unit, opt.Keyword)
+}
+
+func checkBattleFormation(a *armyCheck, _ ruleset.Params) []models.ValidationIssue {
+	if a.formation == nil || a.formation.FactionID == a.req.FactionID {
+		return nil
+	}
+	return []models.ValidationIssue{{
+		Code:    "battle_formation_not_in_faction",
+		Message: fmt.Sprintf("battle formation %s does not belong to the selected faction", a.formation.Name),
+		Paths:   []string{"battle_formation_id"},
+		Params:  map[string]any{"battle_formation_id": a.formation.ID},
+	}}
+}
+
+// checkEnhancements checks the enhancements given to each unit. Enhancements
+// without required keywords go to units with one of Keywords (default HERO).
+// A non-zero Max caps the enhancements in the army.
+func checkEnhancements(a *armyCheck, p ruleset.Params) []models.ValidationIssue {
+	bearerKeywords := p.Keywords
+	if len(bearerKeywords) == 0 {
+		bearerKeywords = []string{"HERO"}
+	}
+
+	var issues []models.ValidationIssue
+	var picked []uuid.UUID
+	pickedAt := make(map[uuid.UUID][]string)
+	var allPaths []string
+	var allIDs []uuid.UUID
+
+	for _, e := range armyEntries(a.req) {
+		unit, unitFound := a.units[e.UnitID]
+		types := make(map[string]bool)
+
+		for _, id := range e.EnhancementIDs {
+			enhancement, ok := a.enhancements[id]
+			if !ok {
+				continue
+			}
+			if len(pickedAt[id]) == 0 {
+				picked = append(picked, id)
+			}
+			pickedAt[id] = append(pickedAt[id], e.path)
+			allPaths = append(allPaths, e.path)
+			allIDs = append(allIDs, id)
+
+			if enhancement.FactionID != a.req.FactionID {
+				issues = append(issues, models.ValidationIssue{
+					Code:           "enhancement_not_in_faction",
+					Message:        fmt.Sprintf("enhancement %s does not belong to the selected faction", enhancement.Name),
+					Paths:          []string{e.path},
+					EnhancementIDs: []uuid.UUID{id},
+				})
+			}
+
+			if !unitFound {
+				continue
+			}
+
+			if types[enhancement.EnhancementType] {
+				issues = append(issues, models.ValidationIssue{
+					Code:           "duplicate_enhancement_type",
+					Message:        fmt.Sprintf("Unit %s has more than one %s enhancement", unit.Name, enhancement.EnhancementType),
+					Paths:          []string{e.path},
+					UnitIDs:        []uuid.UUID{unit.ID},
+					EnhancementIDs: []uuid.UUID{id},
+					Params:         map[string]any{"enhancement_type": enhancement.EnhancementType},
+				})
+			}
+			types[enhancement.EnhancementType] = true
+
+			if !enhancementAllowed(unit, enhancement, bearerKeywords) {
+				issues = append(issues, models.ValidationIssue{
+					Code:           "enhancement_not_allowed",
+					Message:        fmt.Sprintf("Enhancement %s can not be given to %s", enhancement.Name, unit.Name),
+					Paths:          []string{e.path},
+					UnitIDs:        []uuid.UUID{unit.ID},
+					EnhancementIDs: []uuid.UUID{id},
+				})
+			}
+		}
+	}
+
+	for _, id := range picked {
+		enhancement := a.enhancements[id]
+		if times := len(pickedAt[id]); enhancement.IsUnique && times > 1 {
+			issues = append(issues, models.ValidationIssue{
+				Code:           "unique_enhancement_repeated",
+				Message:        fmt.Sprintf("Enhancement %s is unique but was given %d times", enhancement.Name, times),
+				Paths:          pickedAt[id],
+				EnhancementIDs: []uuid.UUID{id},
+				Params:         map[string]any{"limit": 1, "actual": times},
+			})
+		}
+	}
+
+	if p.Max > 0 && len(allIDs) > p.Max {
+		issues = append(issues, models.ValidationIssue{
+			Code:           "enhancement_limit",
+			Message:        fmt.Sprintf("Army has %d enhancements, max %d", len(allIDs), p.Max),
+			Paths:          allPaths,
+			EnhancementIDs: allIDs,
+			Params:         map[string]any{"limit": p.Max, "actual": len(allIDs)},
+		})
+	}
+
+	return issues
+}
+
+// enhancementAllowed reports whether unit meets the restrictions of e. An
+// enhancement without required keywords can only be given to a unit with one
+// of bearerKeywords.
+func enhancementAllowed(unit models.Unit, e models.Enhancement, bearerKeywords []string) bool {
+	if len(e.RequiredKeywords) == 0 {
+		return hasAnyKeyword(unit, bearerKeywords)
+	}
+
+	for _, group := range e.RequiredKeywords {
+		allowed := true
+		for _, keyword := range group {
+			if !hasKeyword(unit, keyword) {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkRegimentsOfRenown allows Max hires (default 1) of Regiments of Renown
+// the army is eligible for, fielding only their own units.
+func checkRegimentsOfRenown(a *armyCheck, p ruleset.Params) []models.ValidationIssue {
+	limit := p.Max
+	if limit == 0 {
+		limit = 1
+	}
+
+	var issues []models.ValidationIssue
+	if hires := len(a.req.RegimentsOfRenown); hires > limit {
+		issues = append(issues, models.ValidationIssue{
+			Code:    "regiment_of_renown_limit",
+			Message: fmt.Sprintf("Army can hire only %d Regiment of Renown, has %d", limit, hires),
+			Paths:   []string{"regiments_of_renown"},
+			Params:  map[string]any{"limit": limit, "actual": hires},
+		})
+	}
+
+	for _, hire := range a.renown {
+		ror := hire.faction
+		if !ror.IsRegimentOfRenown {
+			issues = append(issues, models.ValidationIssue{
+				Code:    "not_regiment_of_renown",
+				Message: fmt.Sprintf("%s is not a Regiment of Renown", ror.Name),
+				Paths:   []string{hire.path},
+				Params:  map[string]any{"faction_id": ror.ID},
+			})
+			continue
+		}
+
+		if !canHire(a.hostNames, a.faction, ror) {
+			issues = append(issues, models.ValidationIssue{
+				Code:    "regiment_of_renown_not_hireable",
+				Message: fmt.Sprintf("%s can not be hired by %s", ror.Name, a.faction.Name),
+				Paths:   []string{hire.path},
+				Params:  map[string]any{"faction_id": ror.ID, "hireable_by": ror.HireableBy},
+			})
+		}
+
+		for i, unit := range hire.units {
+			if unit.FactionID != ror.ID {
+				issues = append(issues, models.ValidationIssue{
+					Code:    "unit_not_in_regiment_of_renown",
+					Message: fmt.Sprintf("unit %s is not part of %s", unit.Name, ror.Name),
+					Paths:   []string{hire.paths[i]},
+					UnitIDs: []uuid.UUID{unit.ID},
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// checkRuleOfThree allows Max entries (default 3) of each unit, or ExemptMax
+// (default 6) of units with one of ExemptKeywords.
+func checkRuleOfThree(a *armyCheck, p ruleset.Params) []models.ValidationIssue {
+	limit := p.Max
+	if limit == 0 {
+		limit = 3
+	}
+	exemptLimit := p.ExemptMax
+	if exemptLimit == 0 {
+		exemptLimit = 6
+	}
+
+	t := tallyUnits(a, func(models.Unit) bool { return true })
+
+	var issues []models.ValidationIssue
+	for _, id := range t.ids {
+		unit := a.units[id]
+		unitLimit := limit
+		if hasAnyKeyword(unit, p.ExemptKeywords) {
+			unitLimit = exemptLimit
+		}
+		if t.count[id] <= unitLimit {
+			continue
+		}
+		issues = append(issues, models.ValidationIssue{
+			Code:    "rule_of_three",
+			Message: fmt.Sprintf("Unit %s is taken %d times, max %d", unit.Name, t.count[id], unitLimit),
+			Paths:   t.paths[id],
+			UnitIDs: []uuid.UUID{id},
+			Params:  map[string]any{"limit": unitLimit, "actual": t.count[id]},
+		})
+	}
+	return issues
+}
+
+func checkPointsLimit(a *armyCheck, _ ruleset.Params) []models.ValidationIssue {
+	if a.points <= a.req.PointsLimit {
+		return nil
+	}
+	return []models.ValidationIssue{{
+		Code:    "points_limit",
+		Message: fmt.Sprintf("Total Points %d exceeds point limit %d", a.points, a.req.PointsLimit),
+		Params:  map[string]any{"limit": a.req.PointsLimit, "actual": a.points},
+	}}
+}
@@ -2,9 +2,13 @@ package services
 
 import (
 	"context"
+	"slices"
 	"testing"
 
+	"github.com/google/uuid"
+
 	"github.com/JohnG-Dev/army_builder_api/internal/models"
+	"github.com/JohnG-Dev/army_builder_api/internal/ruleset"
 )
 
 func TestValidateArmy_Logic(t *testing.T) {
@@ -20,10 +24,11 @@ func TestValidateArmy_Logic(t *testing.T) {
 	enemyUnit := createTestUnit(t, s, enemyFaction)
 
 	tests := []struct {
-		name          string
-		req           models.ArmyValidationRequest
-		expectedValid bool
-		expectedCount int
+		name           string
+		req            models.ArmyValidationRequest
+		expectedValid  bool
+		expectedCount  int
+		expectedPoints int
 	}{
 		{
 			name: "Valid Army",
@@ -32,18 +37,20 @@ func TestValidateArmy_Logic(t *testing.T) {
 				PointsLimit: 2000,
 				Units:       []models.ArmyUnit{{UnitID: unit, Quantity: 5}},
 			},
-			expectedValid: true,
-			expectedCount: 0,
+			expectedValid:  true,
+			expectedCount:  0,
+			expectedPoints: 500,
 		},
 		{
 			name: "Unique Violation",
 			req: models.ArmyValidationRequest{
 				FactionID:   myFaction,
 				PointsLimit: 2000,
-				Units:       []models.ArmyUnit{{UnitID: uniqueUnit, Quantity: 2}},
+				Units:       []models.ArmyUnit{{UnitID: uniqueUnit, Quantity: 1}, {UnitID: uniqueUnit, Quantity: 1}},
 			},
-			expectedValid: false,
-			expectedCount: 2,
+			expectedValid:  false,
+			expectedCount:  1,
+			expectedPoints: 200,
 		},
 		{
 			name: "Points Violation",
@@ -52,8 +59,9 @@ func TestValidateArmy_Logic(t *testing.T) {
 				PointsLimit: 500,
 				Units:       []models.ArmyUnit{{UnitID: unit, Quantity: 8}},
 			},
-			expectedValid: false,
-			expectedCount: 1,
+			expectedValid:  false,
+			expectedCount:  1,
+			expectedPoints: 800,
 		},
 		{
 			name: "Invalid Unit",
@@ -62,28 +70,42 @@ func TestValidateArmy_Logic(t *testing.T) {
 				PointsLimit: 2000,
 				Units:       []models.ArmyUnit{{UnitID: enemyUnit, Quantity: 4}},
 			},
-			expectedValid: false,
-			expectedCount: 1,
+			expectedValid:  false,
+			expectedCount:  1,
+			expectedPoints: 400,
 		},
 		{
-			name: "Size Violation (Below Min Limit)",
+			name: "Reinforced Unit Costs Double",
 			req: models.ArmyValidationRequest{
 				FactionID:   myFaction,
 				PointsLimit: 2000,
-				Units:       []models.ArmyUnit{{UnitID: unit, Quantity: 1}},
+				Units:       []models.ArmyUnit{{UnitID: unit, Quantity: 1, Reinforced: true}},
 			},
-			expectedValid: false,
-			expectedCount: 1,
+			expectedValid:  true,
+			expectedCount:  0,
+			expectedPoints: 200,
 		},
 		{
-			name: "Size Violation (Above max Limit)",
+			name: "Unit Can Not Be Reinforced",
 			req: models.ArmyValidationRequest{
 				FactionID:   myFaction,
 				PointsLimit: 2000,
-				Units:       []models.ArmyUnit{{UnitID: unit, Quantity: 9}},
+				Units:       []models.ArmyUnit{{UnitID: uniqueUnit, Quantity: 1, Reinforced: true}},
 			},
-			expectedValid: false,
-			expectedCount: 1,
+			expectedValid:  false,
+			expectedCount:  1,
+			expectedPoints: 200,
+		},
+		{
+			name: "Reinforcement Cap Exceeded",
+			req: models.ArmyValidationRequest{
+				FactionID:   myFaction,
+				PointsLimit: 1000,
+				Units:       []models.ArmyUnit{{UnitID: unit, Quantity: 3, Reinforced: true}},
+			},
+			expectedValid:  false,
+			expectedCount:  1,
+			expectedPoints: 600,
 		},
 	}
 
@@ -101,6 +123,584 @@ func TestValidateArmy_Logic(t *testing.T) {
 			if len(resp.Errors) != tt.expectedCount {
 				t.Errorf("expected %d errors, got %d: %v", tt.expectedCount, len(resp.Errors), resp.Errors)
 			}
+
+			if resp.TotalPoints != tt.expectedPoints {
+				t.Errorf("expected %d points, got %d", tt.expectedPoints, resp.TotalPoints)
+			}
+		})
+	}
+}
+
+func TestValidateArmy_Regiments(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+
+	lord := createTestHero(t, s, gameID, factionID, "Lord")
+	createTestRegimentOption(t, s, lord, "WARRIOR", false)
+	createTestRegimentOption(t, s, lord, "SIDEKICK", true)
+
+	warriors := createTestUnitWithName(t, s, factionID, "Warriors")
+	addTestKeyword(t, s, gameID, warriors, "WARRIOR")
+	outsiders := createTestUnitWithName(t, s, factionID, "Outsiders")
+
+	sidekick := createTestHero(t, s, gameID, factionID, "Sidekick")
+	addTestKeyword(t, s, gameID, sidekick, "SIDEKICK")
+	secondSidekick := createTestHero(t, s, gameID, factionID, "Second Sidekick")
+	addTestKeyword(t, s, gameID, secondSidekick, "SIDEKICK")
+
+	leader := models.ArmyUnit{UnitID: lord, Quantity: 1}
+	warriorUnit := models.ArmyUnit{UnitID: warriors, Quantity: 1}
+
+	tests := []struct {
+		name          string
+		regiments     []models.RegimentSelection
+		auxiliaries   []models.ArmyUnit
+		expectedCount int
+		expectedDrops int
+	}{
+		{
+			name: "Valid General's Regiment And Auxiliary",
+			regiments: []models.RegimentSelection{{
+				Leader:    leader,
+				Units:     []models.ArmyUnit{warriorUnit, {UnitID: sidekick, Quantity: 1}},
+				IsGeneral: true,
+			}},
+			auxiliaries:   []models.ArmyUnit{warriorUnit},
+			expectedCount: 0,
+			expectedDrops: 2,
+		},
+		{
+			name: "Unit Not In Regiment Options",
+			regiments: []models.RegimentSelection{{
+				Leader:    leader,
+				Units:     []models.ArmyUnit{{UnitID: outsiders, Quantity: 1}},
+				IsGeneral: true,
+			}},
+			expectedCount: 1,
+			expectedDrops: 1,
+		},
+		{
+			name: "Hero Option Used Twice",
+			regiments: []models.RegimentSelection{{
+				Leader:    leader,
+				Units:     []models.ArmyUnit{{UnitID: sidekick, Quantity: 1}, {UnitID: secondSidekick, Quantity: 1}},
+				IsGeneral: true,
+			}},
+			expectedCount: 1,
+			expectedDrops: 1,
+		},
+		{
+			name: "Only The General's Regiment Takes Four Units",
+			regiments: []models.RegimentSelection{
+				{
+					Leader:    leader,
+					Units:     []models.ArmyUnit{warriorUnit, warriorUnit, {UnitID: warriors, Quantity: 2}},
+					IsGeneral: true,
+				},
+				{
+					Leader: leader,
+					Units:  []models.ArmyUnit{warriorUnit, {UnitID: warriors, Quantity: 3, Reinforced: true}},
+				},
+			},
+			expectedCount: 1,
+			expectedDrops: 2,
+		},
+		{
+			name: "No General",
+			regiments: []models.RegimentSelection{{
+				Leader: leader,
+				Units:  []models.ArmyUnit{warriorUnit},
+			}},
+			expectedCount: 1,
+			expectedDrops: 1,
+		},
+		{
+			name: "Leader Is Not A Hero",
+			regiments: []models.RegimentSelection{{
+				Leader:    warriorUnit,
+				IsGeneral: true,
+			}},
+			expectedCount: 1,
+			expectedDrops: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ValidateArmy(s, ctx, models.ArmyValidationRequest{
+				FactionID:   factionID,
+				PointsLimit: 5000,
+				Regiments:   tt.regiments,
+				Auxiliaries: tt.auxiliaries,
+			})
+			if err != nil {
+				t.Fatalf("unexpected system error: %v", err)
+			}
+
+			if len(resp.Errors) != tt.expectedCount {
+				t.Errorf("expected %d errors, got %d: %v", tt.expectedCount, len(resp.Errors), resp.Errors)
+			}
+
+			if resp.IsValid != (tt.expectedCount == 0) {
+				t.Errorf("expected IsValid to be %v, got %v", tt.expectedCount == 0, resp.IsValid)
+			}
+
+			if resp.Drops != tt.expectedDrops {
+				t.Errorf("expected %d drops, got %d", tt.expectedDrops, resp.Drops)
+			}
+
+			if resp.AuxiliaryUnits != len(tt.auxiliaries) {
+				t.Errorf("expected %d auxiliary units, got %d", len(tt.auxiliaries), resp.AuxiliaryUnits)
+			}
+		})
+	}
+}
+
+func TestValidateArmy_Enhancements(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	otherFaction := createTestFactionWithName(t, s, gameID, "Other")
+
+	lord := createTestHero(t, s, gameID, factionID, "Lord")
+	warriors := createTestUnitWithName(t, s, factionID, "Warriors")
+	beast := createTestUnitWithName(t, s, factionID, "Beast")
+	addTestKeyword(t, s, gameID, beast, "MONSTER")
+
+	trait := createTestEnhancement(t, s, factionID, "Trait", "Heroic Trait", "[]")
+	secondTrait := createTestEnhancement(t, s, factionID, "Second Trait", "Heroic Trait", "[]")
+	artefact := createTestEnhancement(t, s, factionID, "Artefact", "Artefact of Power", "[]")
+	monstrous := createTestEnhancement(t, s, factionID, "Monstrous Trait", "Monstrous Trait", `[["MONSTER"]]`)
+	foreign := createTestEnhancement(t, s, otherFaction, "Foreign Trait", "Heroic Trait", "[]")
+
+	formation := createTestBattleFormation(t, s, gameID, factionID)
+	foreignFormation := createTestBattleFormation(t, s, gameID, otherFaction)
+
+	armyWith := func(formationID *uuid.UUID, units ...models.ArmyUnit) models.ArmyValidationRequest {
+		return models.ArmyValidationRequest{
+			FactionID:         factionID,
+			PointsLimit:       2000,
+			BattleFormationID: formationID,
+			Units:             units,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		req            models.ArmyValidationRequest
+		expectedCount  int
+		expectedPoints int
+	}{
+		{
+			name: "Enhancement Points Are Added",
+			req: armyWith(&formation,
+				models.ArmyUnit{UnitID: lord, Quantity: 1, EnhancementIDs: []uuid.UUID{trait, artefact}},
+				models.ArmyUnit{UnitID: beast, Quantity: 1, EnhancementIDs: []uuid.UUID{monstrous}},
+			),
+			expectedCount:  0,
+			expectedPoints: 150 + 100 + 60,
+		},
+		{
+			name: "Two Enhancements Of One Type",
+			req: armyWith(nil,
+				models.ArmyUnit{UnitID: lord, Quantity: 1, EnhancementIDs: []uuid.UUID{trait, secondTrait}},
+			),
+			expectedCount:  1,
+			expectedPoints: 150 + 40,
+		},
+		{
+			name: "Unique Enhancement Given Twice",
+			req: armyWith(nil,
+				models.ArmyUnit{UnitID: lord, Quantity: 1, EnhancementIDs: []uuid.UUID{trait}},
+				models.ArmyUnit{UnitID: lord, Quantity: 1, EnhancementIDs: []uuid.UUID{trait}},
+			),
+			expectedCount:  1,
+			expectedPoints: 300 + 40,
+		},
+		{
+			name: "Restrictions Not Met",
+			req: armyWith(nil,
+				models.ArmyUnit{UnitID: warriors, Quantity: 1, EnhancementIDs: []uuid.UUID{artefact}},
+				models.ArmyUnit{UnitID: lord, Quantity: 1, EnhancementIDs: []uuid.UUID{monstrous}},
+			),
+			expectedCount:  2,
+			expectedPoints: 100 + 150 + 40,
+		},
+		{
+			name: "Other Faction's Enhancement And Formation",
+			req: armyWith(&foreignFormation,
+				models.ArmyUnit{UnitID: lord, Quantity: 1, EnhancementIDs: []uuid.UUID{foreign}},
+			),
+			expectedCount:  2,
+			expectedPoints: 150 + 20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ValidateArmy(s, ctx, tt.req)
+			if err != nil {
+				t.Fatalf("unexpected system error: %v", err)
+			}
+
+			if len(resp.Errors) != tt.expectedCount {
+				t.Errorf("expected %d errors, got %d: %v", tt.expectedCount, len(resp.Errors), resp.Errors)
+			}
+
+			if resp.TotalPoints != tt.expectedPoints {
+				t.Errorf("expected %d points, got %d", tt.expectedPoints, resp.TotalPoints)
+			}
 		})
 	}
 }
+
+func TestValidateArmy_ArmyOfRenown(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID := createTestGame(t, s)
+	parentID := createTestFaction(t, s, gameID)
+	renownID := createTestArmyOfRenown(t, s, gameID, parentID, "Renowned Host")
+	openRenownID := createTestArmyOfRenown(t, s, gameID, parentID, "Open Host")
+
+	allowed := createTestUnitWithName(t, s, parentID, "Allowed")
+	forbidden := createTestUnitWithName(t, s, parentID, "Forbidden")
+	own := createTestUnitWithName(t, s, renownID, "Own")
+	createTestAllowedUnit(t, s, renownID, "Allowed")
+
+	tests := []struct {
+		name          string
+		factionID     uuid.UUID
+		units         []models.ArmyUnit
+		expectedCount int
+	}{
+		{
+			name:          "Allowed Parent Units And Own Units",
+			factionID:     renownID,
+			units:         []models.ArmyUnit{{UnitID: allowed, Quantity: 1}, {UnitID: own, Quantity: 1}},
+			expectedCount: 0,
+		},
+		{
+			name:          "Parent Unit Not On Allow-list",
+			factionID:     renownID,
+			units:         []models.ArmyUnit{{UnitID: forbidden, Quantity: 1}},
+			expectedCount: 1,
+		},
+		{
+			name:          "No Allow-list Permits Every Parent Unit",
+			factionID:     openRenownID,
+			units:         []models.ArmyUnit{{UnitID: forbidden, Quantity: 1}},
+			expectedCount: 0,
+		},
+		{
+			name:          "Parent Faction Can Not Take Army Of Renown Units",
+			factionID:     parentID,
+			units:         []models.ArmyUnit{{UnitID: own, Quantity: 1}},
+			expectedCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ValidateArmy(s, ctx, models.ArmyValidationRequest{
+				FactionID:   tt.factionID,
+				PointsLimit: 2000,
+				Units:       tt.units,
+			})
+			if err != nil {
+				t.Fatalf("unexpected system error: %v", err)
+			}
+
+			if len(resp.Errors) != tt.expectedCount {
+				t.Errorf("expected %d errors, got %d: %v", tt.expectedCount, len(resp.Errors), resp.Errors)
+			}
+		})
+	}
+}
+
+func TestValidateArmy_RegimentsOfRenown(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID := createTestGame(t, s)
+	hostID := createTestFaction(t, s, gameID)
+	renownID := createTestArmyOfRenown(t, s, gameID, hostID, "Renowned Host")
+
+	hired := createTestRegimentOfRenown(t, s, gameID, "Hired Blades", "Other Allegiance", 150)
+	createTestRegimentOfRenownHost(t, s, hired, "Test Faction")
+	elsewhere := createTestRegimentOfRenown(t, s, gameID, "Distant Blades", "Test Allegiance", 120)
+	createTestRegimentOfRenownHost(t, s, elsewhere, "Other Faction")
+	allied := createTestRegimentOfRenown(t, s, gameID, "Allied Blades", "Test Allegiance", 100)
+	foreign := createTestRegimentOfRenown(t, s, gameID, "Foreign Blades", "Other Allegiance", 100)
+
+	hiredUnit := createTestUnitWithName(t, s, hired, "Blade")
+	hostUnit := createTestUnitWithName(t, s, hostID, "Host Unit")
+
+	tests := []struct {
+		name           string
+		factionID      uuid.UUID
+		regiments      []models.RegimentOfRenownSelection
+		expectedPoints int
+		expectedCount  int
+	}{
+		{
+			name:           "Hired By Listed Host",
+			factionID:      hostID,
+			regiments:      []models.RegimentOfRenownSelection{{FactionID: hired, Units: []models.ArmyUnit{{UnitID: hiredUnit}}}},
+			expectedPoints: 150,
+			expectedCount:  0,
+		},
+		{
+			name:           "Army Of Renown Hires As Its Parent",
+			factionID:      renownID,
+			regiments:      []models.RegimentOfRenownSelection{{FactionID: hired}},
+			expectedPoints: 150,
+			expectedCount:  0,
+		},
+		{
+			name:           "Host Not Listed",
+			factionID:      hostID,
+			regiments:      []models.RegimentOfRenownSelection{{FactionID: elsewhere}},
+			expectedPoints: 120,
+			expectedCount:  1,
+		},
+		{
+			name:           "No Hosts Falls Back To Allegiance",
+			factionID:      hostID,
+			regiments:      []models.RegimentOfRenownSelection{{FactionID: allied}},
+			expectedPoints: 100,
+			expectedCount:  0,
+		},
+		{
+			name:           "Different Allegiance",
+			factionID:      hostID,
+			regiments:      []models.RegimentOfRenownSelection{{FactionID: foreign}},
+			expectedPoints: 100,
+			expectedCount:  1,
+		},
+		{
+			name:           "Only One Regiment Of Renown",
+			factionID:      hostID,
+			regiments:      []models.RegimentOfRenownSelection{{FactionID: hired}, {FactionID: allied}},
+			expectedPoints: 250,
+			expectedCount:  1,
+		},
+		{
+			name:           "Unit Not Part Of The Regiment",
+			factionID:      hostID,
+			regiments:      []models.RegimentOfRenownSelection{{FactionID: hired, Units: []models.ArmyUnit{{UnitID: hostUnit}}}},
+			expectedPoints: 150,
+			expectedCount:  1,
+		},
+		{
+			name:           "Not A Regiment Of Renown",
+			factionID:      hostID,
+			regiments:      []models.RegimentOfRenownSelection{{FactionID: renownID}},
+			expectedPoints: 0,
+			expectedCount:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ValidateArmy(s, ctx, models.ArmyValidationRequest{
+				FactionID:         tt.factionID,
+				PointsLimit:       2000,
+				RegimentsOfRenown: tt.regiments,
+			})
+			if err != nil {
+				t.Fatalf("unexpected system error: %v", err)
+			}
+
+			if resp.TotalPoints != tt.expectedPoints {
+				t.Errorf("expected %d points, got %d", tt.expectedPoints, resp.TotalPoints)
+			}
+			if len(resp.Errors) != tt.expectedCount {
+				t.Errorf("expected %d errors, got %d: %v", tt.expectedCount, len(resp.Errors), resp.Errors)
+			}
+		})
+	}
+}
+
+func TestCheckRulesets_Builtin(t *testing.T) {
+	reg, err := ruleset.Builtin()
+	if err != nil {
+		t.Fatalf("failed to load built-in rulesets: %v", err)
+	}
+
+	if err := CheckRulesets(reg); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = CheckRulesets(ruleset.New(ruleset.Ruleset{Name: "Test", Rules: []ruleset.Rule{{ID: "no_such_rule"}}}))
+	if err == nil {
+		t.Errorf("expected an error for an unknown rule")
+	}
+}
+
+func TestValidateArmy_Rulesets(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	aosGameID := createTestGame(t, s)
+	aosFaction := createTestFaction(t, s, aosGameID)
+	aosUnit := createTestUnit(t, s, aosFaction)
+
+	fortyKGameID := createTestGameWithName(t, s, "Warhammer 40,000")
+	fortyKFaction := createTestFaction(t, s, fortyKGameID)
+	fortyKUnit := createTestUnit(t, s, fortyKFaction)
+
+	tests := []struct {
+		name            string
+		req             models.ArmyValidationRequest
+		expectedRuleset string
+		expectedValid   bool
+		expectedRules   []string
+	}{
+		{
+			name: "Default Ruleset Has No Rule Of Three",
+			req: models.ArmyValidationRequest{
+				FactionID:   aosFaction,
+				PointsLimit: 2000,
+				Units:       []models.ArmyUnit{{UnitID: aosUnit, Quantity: 4}},
+			},
+			expectedRuleset: "Age of Sigmar",
+			expectedValid:   true,
+			expectedRules:   []string{},
+		},
+		{
+			name: "Rule Of Three",
+			req: models.ArmyValidationRequest{
+				GameID:      fortyKGameID,
+				FactionID:   fortyKFaction,
+				PointsLimit: 2000,
+				Units:       []models.ArmyUnit{{UnitID: fortyKUnit, Quantity: 4}},
+			},
+			expectedRuleset: "Warhammer 40,000",
+			expectedValid:   false,
+			expectedRules:   []string{"rule_of_three"},
+		},
+		{
+			name: "Fired Rules In Ruleset Order",
+			req: models.ArmyValidationRequest{
+				FactionID:   fortyKFaction,
+				PointsLimit: 100,
+				Units:       []models.ArmyUnit{{UnitID: fortyKUnit, Quantity: 4}, {UnitID: uuid.New()}},
+			},
+			expectedRuleset: "Warhammer 40,000",
+			expectedValid:   false,
+			expectedRules:   []string{"references", "rule_of_three", "points_limit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := ValidateArmy(s, ctx, tt.req)
+			if err != nil {
+				t.Fatalf("unexpected system error: %v", err)
+			}
+
+			if resp.Ruleset != tt.expectedRuleset {
+				t.Errorf("expected ruleset %s, got %s", tt.expectedRuleset, resp.Ruleset)
+			}
+			if resp.IsValid != tt.expectedValid {
+				t.Errorf("expected IsValid to be %v, got %v: %v", tt.expectedValid, resp.IsValid, resp.Errors)
+			}
+			if !slices.Equal(resp.FiredRules, tt.expectedRules) {
+				t.Errorf("expected fired rules %v, got %v", tt.expectedRules, resp.FiredRules)
+			}
+		})
+	}
+}
+
+func TestValidateArmy_WarningRule(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	rs, err := ruleset.Parse([]byte("name: Lenient\ndefault: true\nrules:\n  - id: points_limit\n    severity: warning\n"))
+	if err != nil {
+		t.Fatalf("failed to parse ruleset: %v", err)
+	}
+	s.Rulesets = ruleset.New(rs)
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	unitID := createTestUnit(t, s, factionID)
+
+	resp, err := ValidateArmy(s, ctx, models.ArmyValidationRequest{
+		FactionID:   factionID,
+		PointsLimit: 100,
+		Units:       []models.ArmyUnit{{UnitID: unitID, Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected system error: %v", err)
+	}
+
+	if !resp.IsValid {
+		t.Errorf("expected warnings to leave the army valid, got errors: %v", resp.Errors)
+	}
+	if len(resp.Warnings) != 1 {
+		t.Errorf("expected 1 warning, got %d: %v", len(resp.Warnings), resp.Warnings)
+	}
+	if !slices.Equal(resp.FiredRules, []string{"points_limit"}) {
+		t.Errorf("expected points_limit to fire, got %v", resp.FiredRules)
+	}
+}
+
+func TestValidateArmy_Issues(t *testing.T) {
+	s := setupTestDB(t)
+	ctx := context.Background()
+
+	gameID := createTestGame(t, s)
+	factionID := createTestFaction(t, s, gameID)
+	heroID := createTestHero(t, s, gameID, factionID, "Leader")
+	uniqueUnit := createTestUniqueUnit(t, s, factionID)
+	missingID := uuid.New()
+
+	resp, err := ValidateArmy(s, ctx, models.ArmyValidationRequest{
+		FactionID:   factionID,
+		PointsLimit: 100,
+		Regiments: []models.RegimentSelection{
+			{Leader: models.ArmyUnit{UnitID: heroID}, Units: []models.ArmyUnit{{UnitID: uniqueUnit}}, IsGeneral: true},
+		},
+		Auxiliaries: []models.ArmyUnit{{UnitID: uniqueUnit}, {UnitID: missingID}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected system error: %v", err)
+	}
+
+	issues := make(map[string]models.ValidationIssue)
+	for _, issue := range resp.Issues {
+		issues[issue.Code] = issue
+	}
+
+	if len(resp.Issues) != 3 || len(resp.Errors) != 3 {
+		t.Fatalf("expected 3 issues and errors, got %d and %d: %+v", len(resp.Issues), len(resp.Errors), resp.Issues)
+	}
+
+	missing := issues["unit_not_found"]
+	if missing.Rule != "references" || missing.Severity != "error" {
+		t.Errorf("expected an error from the references rule, got %+v", missing)
+	}
+	if !slices.Equal(missing.Paths, []string{"auxiliaries[1]"}) || !slices.Equal(missing.UnitIDs, []uuid.UUID{missingID}) {
+		t.Errorf("expected the missing unit at auxiliaries[1], got %+v", missing)
+	}
+
+	unique := issues["unique_unit_limit"]
+	if !slices.Equal(unique.Paths, []string{"regiments[0].units[0]", "auxiliaries[0]"}) {
+		t.Errorf("expected both unique entries, got %v", unique.Paths)
+	}
+	if unique.Params["limit"] != 1 || unique.Params["actual"] != 2 {
+		t.Errorf("expected limit 1 and actual 2, got %v", unique.Params)
+	}
+
+	points := issues["points_limit"]
+	if points.Rule != "points_limit" || points.Params["limit"] != 100 || points.Message == "" {
+		t.Errorf("expected a points limit issue with a message, got %+v", points)
+	}
+}
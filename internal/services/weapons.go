@@ -11,6 +11,7 @@ import (
 	appErr "github.com/JohnG-Dev/army_builder_api/internal/errors"
 	"github.com/JohnG-Dev/army_builder_api/internal/models"
 	"github.com/JohnG-Dev/army_builder_api/internal/state"
+	"github.com/JohnG-Dev/army_builder_api/internal/stats"
 )
 
 func mapDBWeaponToModel(w database.Weapon) models.Weapon {
@@ -18,39 +19,35 @@ func mapDBWeaponToModel(w database.Weapon) models.Weapon {
 		ID:            w.ID,
 		UnitID:        w.UnitID,
 		Name:          w.Name,
+		WeaponType:    w.WeaponType,
 		Range:         w.Range,
 		Attacks:       w.Attacks,
 		HitStats:      w.HitStats,
 		WoundStrength: w.WoundStrength,
 		RendAP:        w.RendAp,
 		Damage:        w.Damage,
+		Abilities:     w.Abilities,
 		Version:       w.Version,
 		Source:        w.Source,
 		CreatedAt:     w.CreatedAt,
 		UpdatedAt:     w.UpdatedAt,
-	}
-}
-
-func GetAllWeapons(s *state.State, ctx context.Context) ([]models.Weapon, error) {
-	dbWeapons, err := s.DB.GetAllWeapons(ctx)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return []models.Weapon{}, nil
-		}
-
-		return nil, err
-	}
-
-	if dbWeapons == nil {
-		return []models.Weapon{}, nil
-	}
 
-	weapons := make([]models.Weapon, len(dbWeapons))
-	for i, w := range dbWeapons {
-		weapons[i] = mapDBWeaponToModel(w)
+		Stats: parseWeaponStats(w),
 	}
+}
 
-	return weapons, nil
+// parseWeaponStats parses a weapon's profile. Values that do not parse are
+// kept as unknown; the seeder reports them when the data is loaded.
+func parseWeaponStats(w database.Weapon) stats.WeaponStats {
+	parsed, _ := stats.ParseWeapon(stats.WeaponLine{
+		Range:         w.Range,
+		Attacks:       w.Attacks,
+		HitStats:      w.HitStats,
+		WoundStrength: w.WoundStrength,
+		RendAP:        w.RendAp,
+		Damage:        w.Damage,
+	})
+	return parsed
 }
 
 func GetWeaponsForUnit(s *state.State, ctx context.Context, unitID *uuid.UUID) ([]models.Weapon, error) {
@@ -91,3 +88,32 @@ func GetWeaponByID(s *state.State, ctx context.Context, id uuid.UUID) (models.We
 
 	return weapon, nil
 }
+
+// ListWeapons returns a page of weapons.
+func ListWeapons(s *state.State, ctx context.Context, p models.ListParams) (models.Page[models.Weapon], error) {
+	lp, err := newListPage(p, "name", "created_at")
+	if err != nil {
+		return models.Page[models.Weapon]{}, err
+	}
+
+	f := p.Filters
+	dbWeapons, err := s.DB.ListWeapons(ctx, database.ListWeaponsParams{
+		UnitID:         nullUUID(f.UnitID),
+		FactionID:      nullUUID(f.FactionID),
+		GameID:         nullUUID(f.GameID),
+		WeaponType:     nullText(f.Type),
+		Allegiance:     nullText(f.Allegiance),
+		AfterID:        lp.afterID(),
+		Sort:           lp.sort,
+		AfterName:      lp.afterName(),
+		AfterCreatedAt: lp.afterCreatedAt(),
+		PageSize:       lp.pageSize(),
+	})
+	if err != nil {
+		return models.Page[models.Weapon]{}, err
+	}
+
+	return paginate(lp, dbWeapons, func(w database.Weapon) listCursor {
+		return listCursor{ID: w.ID, Name: w.Name, CreatedAt: w.CreatedAt}
+	}, mapDBWeaponToModel), nil
+}
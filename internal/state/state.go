@@ -6,6 +6,7 @@ import (
 
 	"github.com/JohnG-Dev/army_builder_api/internal/config"
 	"github.com/JohnG-Dev/army_builder_api/internal/database"
+	"github.com/JohnG-Dev/army_builder_api/internal/ruleset"
 )
 
 type State struct {
@@ -13,4 +14,7 @@ type State struct {
 	Cfg    *config.Config
 	Logger *zap.Logger
 	Pool   *pgxpool.Pool
+
+	// Rulesets used to validate armies; the built-in ones when nil.
+	Rulesets *ruleset.Registry
 }
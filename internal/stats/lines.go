@@ -0,0 +1,115 @@
+package stats
+
+import "strings"
+
+// Unparsed names a stat string no parser accepted.
+type Unparsed struct {
+	Field string
+	Raw   string
+}
+
+// UnitLine is the stat line of a unit as stored.
+type UnitLine struct {
+	Move         string
+	HealthWounds string
+	Save         string
+	WardFNP      string
+	InvulnSave   string
+	ControlOC    string
+	Toughness    string
+	Leadership   string
+}
+
+// UnitStats is the parsed stat line of a unit. The JSON names match the raw
+// fields of a unit.
+type UnitStats struct {
+	Move         Value `json:"move"`
+	HealthWounds Value `json:"health_wounds"`
+	Save         Value `json:"save"`
+	WardFNP      Value `json:"ward_fnp"`
+	InvulnSave   Value `json:"invuln_save"`
+	ControlOC    Value `json:"control_oc"`
+	Toughness    Value `json:"toughness"`
+	Leadership   Value `json:"leadership_bravery"`
+}
+
+// WeaponLine is the profile of a weapon as stored.
+type WeaponLine struct {
+	Range         string
+	Attacks       string
+	HitStats      string
+	WoundStrength string
+	RendAP        string
+	Damage        string
+}
+
+// WeaponStats is the parsed profile of a weapon. The JSON names match the raw
+// fields of a weapon.
+type WeaponStats struct {
+	Range         Value `json:"range"`
+	Attacks       Value `json:"attacks"`
+	HitStats      Value `json:"hit_stats"`
+	WoundStrength Value `json:"wound_strength"`
+	RendAP        Value `json:"rend_ap"`
+	Damage        Value `json:"damage"`
+}
+
+type parser struct {
+	unparsed []Unparsed
+}
+
+func (p *parser) parse(field, raw string, parse func(string) (Value, error)) Value {
+	v, err := parse(raw)
+	if err != nil {
+		p.unparsed = append(p.unparsed, Unparsed{Field: field, Raw: raw})
+	}
+	return v
+}
+
+// ParseUnit parses a unit's stat line. Values no parser accepts are kept as
+// unknown and listed in the order of the line.
+func ParseUnit(line UnitLine) (UnitStats, []Unparsed) {
+	var p parser
+	return UnitStats{
+		Move:         p.parse("move", line.Move, ParseDistance),
+		HealthWounds: p.parse("health_wounds", line.HealthWounds, ParseAmount),
+		Save:         p.parse("save", line.Save, ParseThreshold),
+		WardFNP:      p.parse("ward_fnp", line.WardFNP, ParseThreshold),
+		InvulnSave:   p.parse("invuln_save", line.InvulnSave, ParseThreshold),
+		ControlOC:    p.parse("control_oc", line.ControlOC, ParseAmount),
+		Toughness:    p.parse("toughness", line.Toughness, ParseAmount),
+		Leadership:   p.parse("leadership_bravery", line.Leadership, parseThresholdOrAmount),
+	}, p.unparsed
+}
+
+// ParseWeapon parses a weapon's profile. Values no parser accepts are kept as
+// unknown and listed in the order of the profile.
+func ParseWeapon(line WeaponLine) (WeaponStats, []Unparsed) {
+	var p parser
+	return WeaponStats{
+		Range:         p.parse("range", line.Range, parseRange),
+		Attacks:       p.parse("attacks", line.Attacks, ParseAmount),
+		HitStats:      p.parse("hit_stats", line.HitStats, ParseThreshold),
+		WoundStrength: p.parse("wound_strength", line.WoundStrength, parseThresholdOrAmount),
+		RendAP:        p.parse("rend_ap", line.RendAP, ParseRend),
+		Damage:        p.parse("damage", line.Damage, ParseAmount),
+	}, p.unparsed
+}
+
+// parseThresholdOrAmount reads stats stored as a roll in one game and a
+// number in another: leadership ("6+") against bravery ("7"), or a wound roll
+// ("4+") against strength ("5").
+func parseThresholdOrAmount(raw string) (Value, error) {
+	if strings.HasSuffix(clean(raw), "+") {
+		return ParseThreshold(raw)
+	}
+	return ParseAmount(raw)
+}
+
+// parseRange reads a shooting range; melee weapons have none.
+func parseRange(raw string) (Value, error) {
+	if strings.EqualFold(clean(raw), "melee") {
+		return none(raw), nil
+	}
+	return ParseDistance(raw)
+}
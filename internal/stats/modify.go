@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Modify returns the stat with modifier added, the way an ability that adds
+// to it reads: amounts, dice and distances grow by it, a roll needed becomes
+// easier by it and rend worsens saves by it more. Fixed values and rend stop
+// at 0 and thresholds at 1+. Stats of kind none or unknown cannot be
+// modified.
+//
+// Rend keeps the style it was written in, "-1" AP or "1" rend; no rend
+// at all becomes plain rend.
+func (v Value) Modify(modifier int) (Value, error) {
+	e := v.expr
+
+	switch v.Kind {
+	case KindFixed:
+		return ParseAmount(strconv.Itoa(max(e.Modifier+modifier, 0)))
+	case KindDice:
+		e.Modifier += modifier
+		return ParseAmount(e.String())
+	case KindInches:
+		e.Modifier += modifier
+		if e.IsFixed() {
+			e.Modifier = max(e.Modifier, 0)
+		}
+		return ParseDistance(e.String() + "\"")
+	case KindThreshold:
+		return ParseThreshold(strconv.Itoa(max(e.Modifier-modifier, 1)) + "+")
+	case KindRend:
+		n := max(e.Modifier+modifier, 0)
+		switch {
+		case n == 0:
+			return ParseRend("-")
+		case strings.HasPrefix(clean(v.Raw), "-") && v.Min > 0:
+			return ParseRend("-" + strconv.Itoa(n))
+		default:
+			return ParseRend(strconv.Itoa(n))
+		}
+	}
+	return v, fmt.Errorf("%w: cannot modify %q", ErrUnparseable, v.Raw)
+}
@@ -0,0 +1,160 @@
+// Package stats turns the stat strings stored on units and weapons ("5\"",
+// "D3+1", "3+", "-1") into typed values that can be computed with.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JohnG-Dev/army_builder_api/internal/dice"
+)
+
+// ErrUnparseable is returned for stat strings none of the parsers accept,
+// such as "See 'Bloodwrack Stare' ability".
+var ErrUnparseable = errors.New("unparseable stat")
+
+// Kinds of stat value.
+const (
+	// KindNone is an empty or "-" stat, such as the move of a faction terrain.
+	KindNone = "none"
+	// KindFixed is a plain number: health, control, attacks, damage.
+	KindFixed = "fixed"
+	// KindDice is a dice expression such as "D3+1".
+	KindDice = "dice"
+	// KindThreshold is a roll needed on a D6, such as a "3+" save.
+	KindThreshold = "threshold"
+	// KindInches is a distance, fixed or rolled: "5\"", "D6+8\"".
+	KindInches = "inches"
+	// KindRend is the amount a save is worsened by: "1" and "-1" are both 1.
+	KindRend = "rend"
+	// KindUnknown is a value no parser accepts. Raw still holds it.
+	KindUnknown = "unknown"
+)
+
+// Value is a parsed stat. Min, Max and Mean are set for every kind but none
+// and unknown; a threshold's are its target.
+type Value struct {
+	Raw          string            `json:"raw"`
+	Kind         string            `json:"kind"`
+	Dice         string            `json:"dice,omitempty"`
+	Min          int               `json:"min"`
+	Max          int               `json:"max"`
+	Mean         float64           `json:"mean"`
+	Distribution dice.Distribution `json:"distribution,omitempty"`
+	// Probability is the chance a threshold is met on one D6.
+	Probability float64 `json:"probability,omitempty"`
+
+	expr dice.Expr
+}
+
+// Expr returns the dice expression behind an amount or distance; a
+// threshold or rend gives its target or amount as a fixed expression.
+func (v Value) Expr() dice.Expr {
+	return v.expr
+}
+
+// IsNone reports whether the stat is empty or unknown.
+func (v Value) IsNone() bool {
+	return v.Kind == KindNone || v.Kind == KindUnknown
+}
+
+func isNone(s string) bool {
+	return s == "" || s == "-" || s == "–" || s == "—"
+}
+
+func clean(s string) string {
+	return strings.TrimSpace(s)
+}
+
+func none(raw string) Value {
+	return Value{Raw: raw, Kind: KindNone}
+}
+
+func unknown(raw string) (Value, error) {
+	return Value{Raw: raw, Kind: KindUnknown}, fmt.Errorf("%w: %q", ErrUnparseable, raw)
+}
+
+func fromExpr(raw, kind string, e dice.Expr) Value {
+	v := Value{Raw: raw, Kind: kind, Min: e.Min(), Max: e.Max(), Mean: e.Mean(), expr: e}
+	if !e.IsFixed() {
+		v.Dice = e.String()
+		v.Distribution = e.Distribution()
+	}
+	return v
+}
+
+// ParseAmount reads a fixed number or dice expression: attacks, damage,
+// health, control.
+func ParseAmount(raw string) (Value, error) {
+	s := clean(raw)
+	if isNone(s) {
+		return none(raw), nil
+	}
+
+	e, err := dice.Parse(s)
+	if err != nil {
+		return unknown(raw)
+	}
+	if e.IsFixed() {
+		return fromExpr(raw, KindFixed, e), nil
+	}
+	return fromExpr(raw, KindDice, e), nil
+}
+
+// ParseDistance reads a move or range in inches, fixed or rolled. The inch
+// mark may be left out.
+func ParseDistance(raw string) (Value, error) {
+	s := clean(raw)
+	if isNone(s) {
+		return none(raw), nil
+	}
+
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "\""), "″")
+	e, err := dice.Parse(s)
+	if err != nil {
+		return unknown(raw)
+	}
+	return fromExpr(raw, KindInches, e), nil
+}
+
+// ParseThreshold reads a roll needed on a D6 such as a "3+" save, ward or
+// hit roll.
+func ParseThreshold(raw string) (Value, error) {
+	s := clean(raw)
+	if isNone(s) {
+		return none(raw), nil
+	}
+
+	target, err := strconv.Atoi(strings.TrimSuffix(s, "+"))
+	if err != nil || !strings.HasSuffix(s, "+") || target < 1 {
+		return unknown(raw)
+	}
+
+	v := fromExpr(raw, KindThreshold, dice.Fixed(target))
+	v.Probability = ThresholdChance(target)
+	return v, nil
+}
+
+// ParseRend reads rend or AP. Age of Sigmar stores rend as "1" and
+// Warhammer 40,000 AP as "-1"; both worsen a save by 1. "-" is no rend.
+func ParseRend(raw string) (Value, error) {
+	s := clean(raw)
+	if isNone(s) || s == "0" {
+		return fromExpr(raw, KindRend, dice.Fixed(0)), nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return unknown(raw)
+	}
+	return fromExpr(raw, KindRend, dice.Fixed(max(n, -n))), nil
+}
+
+// ThresholdChance is the chance of rolling target or more on a D6. Unmodified
+// 1s always fail and 6s always succeed.
+func ThresholdChance(target int) float64 {
+	target = min(max(target, 2), 6)
+	return float64(7-target) / 6
+}
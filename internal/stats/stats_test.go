@@ -0,0 +1,182 @@
+package stats
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in       string
+		kind     string
+		min, max int
+		mean     float64
+		dice     string
+	}{
+		{in: "5", kind: KindFixed, min: 5, max: 5, mean: 5},
+		{in: "D3+1", kind: KindDice, min: 2, max: 4, mean: 3, dice: "D3+1"},
+		{in: "D3+0", kind: KindDice, min: 1, max: 3, mean: 2, dice: "D3"},
+		{in: "2D6", kind: KindDice, min: 2, max: 12, mean: 7, dice: "2D6"},
+		{in: "", kind: KindNone},
+		{in: "-", kind: KindNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, err := ParseAmount(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v.Raw != tt.in || v.Kind != tt.kind || v.Min != tt.min || v.Max != tt.max || v.Mean != tt.mean || v.Dice != tt.dice {
+				t.Errorf("unexpected value %+v", v)
+			}
+			if (v.Kind == KindDice) != (len(v.Distribution) > 0) {
+				t.Errorf("expected a distribution only for dice, got %+v", v.Distribution)
+			}
+		})
+	}
+}
+
+func TestParseDistance(t *testing.T) {
+	for in, expected := range map[string][2]int{`5"`: {5, 5}, `D6+8"`: {9, 14}, `2D6"`: {2, 12}, "12": {12, 12}} {
+		v, err := ParseDistance(in)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", in, err)
+		}
+		if v.Kind != KindInches || v.Min != expected[0] || v.Max != expected[1] {
+			t.Errorf("expected %v inches for %q, got %+v", expected, in, v)
+		}
+	}
+}
+
+func TestParseThreshold(t *testing.T) {
+	v, err := ParseThreshold("3+")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Kind != KindThreshold || v.Min != 3 || v.Probability != 4.0/6 || v.Expr().Modifier != 3 {
+		t.Errorf("unexpected value %+v", v)
+	}
+
+	if ThresholdChance(1) != 5.0/6 || ThresholdChance(7) != 1.0/6 {
+		t.Error("expected unmodified 1s to fail and 6s to succeed")
+	}
+}
+
+func TestParseRend(t *testing.T) {
+	for in, expected := range map[string]int{"1": 1, "2": 2, "-1": 1, "-": 0, "0": 0, "": 0} {
+		v, err := ParseRend(in)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", in, err)
+		}
+		if v.Kind != KindRend || v.Min != expected {
+			t.Errorf("expected rend %d for %q, got %+v", expected, in, v)
+		}
+	}
+}
+
+func TestParse_Unparseable(t *testing.T) {
+	parsers := map[string]func(string) (Value, error){
+		"amount":    ParseAmount,
+		"distance":  ParseDistance,
+		"threshold": ParseThreshold,
+		"rend":      ParseRend,
+	}
+
+	for name, parse := range parsers {
+		v, err := parse("⟝See 'Bloodwrack Stare' ability⟞")
+		if !errors.Is(err, ErrUnparseable) {
+			t.Errorf("%s: expected ErrUnparseable, got %v", name, err)
+		}
+		if v.Kind != KindUnknown || !v.IsNone() || v.Raw == "" {
+			t.Errorf("%s: expected an unknown value keeping the raw string, got %+v", name, v)
+		}
+	}
+
+	_, err := ParseThreshold("3")
+	if !errors.Is(err, ErrUnparseable) {
+		t.Errorf("expected a threshold to need its +, got %v", err)
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	parsed, unparsed := ParseUnit(UnitLine{Move: `5"`, HealthWounds: "12", Save: "4+", WardFNP: "6+", ControlOC: "5", Leadership: "7"})
+	if len(unparsed) != 0 {
+		t.Fatalf("expected every stat to parse, got %+v", unparsed)
+	}
+	if parsed.Move.Kind != KindInches || parsed.HealthWounds.Min != 12 || parsed.Save.Probability != 0.5 {
+		t.Errorf("unexpected stats %+v", parsed)
+	}
+	if parsed.InvulnSave.Kind != KindNone || parsed.Leadership.Kind != KindFixed {
+		t.Errorf("expected no invuln and bravery as a number, got %+v and %+v", parsed.InvulnSave, parsed.Leadership)
+	}
+}
+
+func TestParseWeapon(t *testing.T) {
+	parsed, unparsed := ParseWeapon(WeaponLine{
+		Range:         "Melee",
+		Attacks:       "D6",
+		HitStats:      "3+",
+		WoundStrength: "See 'Swordmasters' ability",
+		RendAP:        "-",
+		Damage:        "See 'Swordmasters' ability",
+	})
+	if parsed.Range.Kind != KindNone || parsed.Attacks.Kind != KindDice || parsed.RendAP.Min != 0 {
+		t.Errorf("unexpected profile %+v", parsed)
+	}
+	if len(unparsed) != 2 || unparsed[0].Field != "wound_strength" || unparsed[1].Field != "damage" {
+		t.Errorf("expected wound_strength and damage to be flagged, got %+v", unparsed)
+	}
+	if parsed.Damage.Kind != KindUnknown || parsed.Damage.Raw == "" {
+		t.Errorf("expected the raw damage to be kept, got %+v", parsed.Damage)
+	}
+}
+
+func TestModify(t *testing.T) {
+	tests := []struct {
+		name     string
+		parse    func(string) (Value, error)
+		in       string
+		modifier int
+		out      string
+		min      int
+	}{
+		{name: "fixed", parse: ParseAmount, in: "2", modifier: 1, out: "3", min: 3},
+		{name: "fixed floor", parse: ParseAmount, in: "1", modifier: -2, out: "0", min: 0},
+		{name: "dice", parse: ParseAmount, in: "D3", modifier: 1, out: "D3+1", min: 2},
+		{name: "dice down", parse: ParseAmount, in: "D6+1", modifier: -2, out: "D6-1", min: 0},
+		{name: "inches", parse: ParseDistance, in: `5"`, modifier: 2, out: `7"`, min: 7},
+		{name: "threshold", parse: ParseThreshold, in: "4+", modifier: 1, out: "3+", min: 3},
+		{name: "threshold worse", parse: ParseThreshold, in: "6+", modifier: -1, out: "7+", min: 7},
+		{name: "threshold floor", parse: ParseThreshold, in: "2+", modifier: 2, out: "1+", min: 1},
+		{name: "rend", parse: ParseRend, in: "1", modifier: 1, out: "2", min: 2},
+		{name: "ap", parse: ParseRend, in: "-1", modifier: 1, out: "-2", min: 2},
+		{name: "no rend", parse: ParseRend, in: "-", modifier: 1, out: "1", min: 1},
+		{name: "rend floor", parse: ParseRend, in: "1", modifier: -1, out: "-", min: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.parse(tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := v.Modify(tt.modifier)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Raw != tt.out || got.Kind != v.Kind || got.Min != tt.min {
+				t.Errorf("expected %q (min %d), got %+v", tt.out, tt.min, got)
+			}
+		})
+	}
+
+	for _, raw := range []string{"-", "See 'Swordmasters' ability"} {
+		v, _ := ParseAmount(raw)
+		_, err := v.Modify(1)
+		if !errors.Is(err, ErrUnparseable) {
+			t.Errorf("expected %q not to be modifiable, got %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS weapons
+  DROP COLUMN IF EXISTS weapon_type,
+  DROP COLUMN IF EXISTS abilities;
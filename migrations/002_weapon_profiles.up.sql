@@ -0,0 +1,4 @@
+-- Weapon type (melee/ranged) and the weapon ability column from BattleScribe
+ALTER TABLE weapons
+  ADD COLUMN IF NOT EXISTS weapon_type TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS abilities TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS units_battlescribe_idx;
+
+ALTER TABLE IF EXISTS enhancements
+  DROP COLUMN IF EXISTS battlescribe_id;
+
+ALTER TABLE IF EXISTS units
+  DROP COLUMN IF EXISTS battlescribe_id;
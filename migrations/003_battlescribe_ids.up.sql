@@ -0,0 +1,8 @@
+-- BattleScribe entry IDs give the seeder a stable key to upsert on
+ALTER TABLE units
+  ADD COLUMN IF NOT EXISTS battlescribe_id TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE enhancements
+  ADD COLUMN IF NOT EXISTS battlescribe_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS units_battlescribe_idx ON units (battlescribe_id) WHERE battlescribe_id <> '';
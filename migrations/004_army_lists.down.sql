@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS army_list_unit_enhancements;
+DROP TABLE IF EXISTS army_list_units;
+DROP TABLE IF EXISTS army_list_regiments;
+DROP TABLE IF EXISTS army_lists;
@@ -0,0 +1,41 @@
+-- Persisted army lists: regiments of units with their chosen enhancements
+CREATE TABLE IF NOT EXISTS army_lists (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  game_id UUID NOT NULL REFERENCES games(id) ON DELETE RESTRICT,
+  faction_id UUID NOT NULL REFERENCES factions(id) ON DELETE RESTRICT,
+  name TEXT NOT NULL,
+  points_limit INT NOT NULL DEFAULT 0,
+  battle_formation_id UUID REFERENCES battle_formations(id) ON DELETE RESTRICT,
+  total_points INT NOT NULL DEFAULT 0,
+  is_valid BOOLEAN NOT NULL DEFAULT false,
+  validation JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS army_lists_faction_idx ON army_lists (faction_id, name ASC);
+
+CREATE TABLE IF NOT EXISTS army_list_regiments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  army_list_id UUID NOT NULL REFERENCES army_lists(id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  name TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS army_list_regiments_list_idx ON army_list_regiments (army_list_id, position ASC);
+
+CREATE TABLE IF NOT EXISTS army_list_units (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  regiment_id UUID NOT NULL REFERENCES army_list_regiments(id) ON DELETE CASCADE,
+  unit_id UUID NOT NULL REFERENCES units(id) ON DELETE RESTRICT,
+  position INT NOT NULL,
+  quantity INT NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS army_list_units_regiment_idx ON army_list_units (regiment_id, position ASC);
+
+CREATE TABLE IF NOT EXISTS army_list_unit_enhancements (
+  army_list_unit_id UUID NOT NULL REFERENCES army_list_units(id) ON DELETE CASCADE,
+  enhancement_id UUID NOT NULL REFERENCES enhancements(id) ON DELETE RESTRICT,
+  PRIMARY KEY (army_list_unit_id, enhancement_id)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS army_list_regiments
+  DROP COLUMN IF EXISTS is_auxiliary,
+  DROP COLUMN IF EXISTS is_general;
+
+DROP TABLE IF EXISTS regiment_options;
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS enhancements
+  DROP COLUMN IF EXISTS required_keywords;
@@ -0,0 +1,3 @@
+-- Keyword groups parsed from the enhancement restrictions, checked by army validation
+ALTER TABLE enhancements
+  ADD COLUMN IF NOT EXISTS required_keywords JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS army_list_units
+  DROP COLUMN IF EXISTS is_reinforced;
+
+ALTER TABLE IF EXISTS units
+  DROP COLUMN IF EXISTS can_be_reinforced;
@@ -0,0 +1,6 @@
+-- Units the catalogue lets be reinforced, and which saved list units are
+ALTER TABLE units
+  ADD COLUMN IF NOT EXISTS can_be_reinforced BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE army_list_units
+  ADD COLUMN IF NOT EXISTS is_reinforced BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS faction_allowed_units;
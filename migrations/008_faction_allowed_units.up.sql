@@ -0,0 +1,9 @@
+-- Parent faction units an Army of Renown may take, captured from its catalogue
+CREATE TABLE IF NOT EXISTS faction_allowed_units (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  faction_id UUID NOT NULL REFERENCES factions(id) ON DELETE CASCADE,
+  unit_name TEXT NOT NULL,
+  battlescribe_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS faction_allowed_units_faction_idx ON faction_allowed_units (faction_id);
@@ -0,0 +1,10 @@
+ALTER TABLE IF EXISTS army_list_regiments
+  DROP COLUMN IF EXISTS is_regiment_of_renown;
+
+ALTER TABLE IF EXISTS army_lists
+  DROP COLUMN IF EXISTS regiment_of_renown_id;
+
+DROP TABLE IF EXISTS regiment_of_renown_hosts;
+
+ALTER TABLE IF EXISTS factions
+  DROP COLUMN IF EXISTS points;
@@ -0,0 +1,17 @@
+-- Fixed points and eligible hosts of Regiments of Renown, and the one a saved list hires with the units taken from it
+ALTER TABLE factions
+  ADD COLUMN IF NOT EXISTS points INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS regiment_of_renown_hosts (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  faction_id UUID NOT NULL REFERENCES factions(id) ON DELETE CASCADE,
+  host_faction_name TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS regiment_of_renown_hosts_faction_idx ON regiment_of_renown_hosts (faction_id);
+
+ALTER TABLE army_lists
+  ADD COLUMN IF NOT EXISTS regiment_of_renown_id UUID REFERENCES factions(id) ON DELETE RESTRICT;
+
+ALTER TABLE army_list_regiments
+  ADD COLUMN IF NOT EXISTS is_regiment_of_renown BOOLEAN NOT NULL DEFAULT false;
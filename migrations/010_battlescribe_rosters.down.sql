@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS factions
+  DROP COLUMN IF EXISTS battlescribe_force_id,
+  DROP COLUMN IF EXISTS battlescribe_id;
+
+ALTER TABLE IF EXISTS games
+  DROP COLUMN IF EXISTS battlescribe_forces,
+  DROP COLUMN IF EXISTS battlescribe_id;
@@ -0,0 +1,8 @@
+-- Game system, catalogue and force entry IDs needed to read and write BattleScribe rosters
+ALTER TABLE games
+  ADD COLUMN IF NOT EXISTS battlescribe_id TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS battlescribe_forces JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE factions
+  ADD COLUMN IF NOT EXISTS battlescribe_id TEXT NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS battlescribe_force_id TEXT NOT NULL DEFAULT '';
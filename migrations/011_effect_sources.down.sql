@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS ability_effects_battle_formation_idx;
+DROP INDEX IF EXISTS ability_effects_enhancement_idx;
+
+DELETE FROM ability_effects WHERE ability_id IS NULL;
+
+ALTER TABLE IF EXISTS ability_effects
+  DROP CONSTRAINT IF EXISTS chk_effect_source,
+  DROP COLUMN IF EXISTS battle_formation_id,
+  DROP COLUMN IF EXISTS enhancement_id,
+  ALTER COLUMN ability_id SET NOT NULL;
@@ -0,0 +1,16 @@
+-- Stat effects granted by enhancements and battle formations, alongside those of abilities
+ALTER TABLE ability_effects
+  ALTER COLUMN ability_id DROP NOT NULL,
+  ADD COLUMN IF NOT EXISTS enhancement_id UUID REFERENCES enhancements(id) ON DELETE CASCADE,
+  ADD COLUMN IF NOT EXISTS battle_formation_id UUID REFERENCES battle_formations(id) ON DELETE CASCADE;
+
+ALTER TABLE ability_effects DROP CONSTRAINT IF EXISTS chk_effect_source;
+ALTER TABLE ability_effects
+  ADD CONSTRAINT chk_effect_source CHECK (
+    (ability_id IS NOT NULL)::integer +
+    (enhancement_id IS NOT NULL)::integer +
+    (battle_formation_id IS NOT NULL)::integer = 1
+  );
+
+CREATE INDEX IF NOT EXISTS ability_effects_enhancement_idx ON ability_effects (enhancement_id, stat ASC) WHERE enhancement_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS ability_effects_battle_formation_idx ON ability_effects (battle_formation_id, stat ASC) WHERE battle_formation_id IS NOT NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS rules_name_id_idx;
+DROP INDEX IF EXISTS keywords_name_id_idx;
+DROP INDEX IF EXISTS abilities_name_id_idx;
+DROP INDEX IF EXISTS weapons_name_id_idx;
+DROP INDEX IF EXISTS units_created_at_id_idx;
+DROP INDEX IF EXISTS units_points_id_idx;
+DROP INDEX IF EXISTS units_name_id_idx;
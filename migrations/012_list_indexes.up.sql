@@ -0,0 +1,8 @@
+-- Keyset indexes for paging list endpoints by their sort column and id
+CREATE INDEX IF NOT EXISTS units_name_id_idx ON units (name, id);
+CREATE INDEX IF NOT EXISTS units_points_id_idx ON units (points, id);
+CREATE INDEX IF NOT EXISTS units_created_at_id_idx ON units (created_at, id);
+CREATE INDEX IF NOT EXISTS weapons_name_id_idx ON weapons (name, id);
+CREATE INDEX IF NOT EXISTS abilities_name_id_idx ON abilities (name, id);
+CREATE INDEX IF NOT EXISTS keywords_name_id_idx ON keywords (name, id);
+CREATE INDEX IF NOT EXISTS rules_name_id_idx ON rules (name, id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS battle_formations_search_idx;
+DROP INDEX IF EXISTS enhancements_search_idx;
+DROP INDEX IF EXISTS rules_search_idx;
+DROP INDEX IF EXISTS abilities_search_idx;
+DROP INDEX IF EXISTS units_search_idx;
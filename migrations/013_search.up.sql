@@ -0,0 +1,27 @@
+-- Full-text search indexes over names and descriptions, for GET /search
+CREATE INDEX IF NOT EXISTS units_search_idx ON units USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B')
+  ));
+
+CREATE INDEX IF NOT EXISTS abilities_search_idx ON abilities USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B')
+  ));
+
+CREATE INDEX IF NOT EXISTS rules_search_idx ON rules USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B') ||
+    setweight(to_tsvector('english', text), 'C')
+  ));
+
+CREATE INDEX IF NOT EXISTS enhancements_search_idx ON enhancements USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B') ||
+    setweight(to_tsvector('english', restrictions), 'C')
+  ));
+
+CREATE INDEX IF NOT EXISTS battle_formations_search_idx ON battle_formations USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B')
+  ));
@@ -10,6 +10,12 @@ FROM abilities
 WHERE faction_id = $1
 ORDER BY phase ASC, name ASC;
 
+-- name: GetAbilitiesForGame :many
+SELECT *
+FROM abilities
+WHERE game_id = $1
+ORDER BY phase ASC, name ASC;
+
 -- name: GetAbilityByID :one
 SELECT *
 FROM abilities
@@ -46,3 +52,29 @@ RETURNING *;
 -- name: DeleteAbility :exec
 DELETE FROM abilities
 WHERE id = $1;
+
+-- name: ListAbilities :many
+SELECT a.*
+FROM abilities a
+LEFT JOIN units u ON u.id = a.unit_id
+LEFT JOIN factions f ON f.id = COALESCE(a.faction_id, u.faction_id)
+WHERE (sqlc.narg(unit_id)::uuid IS NULL OR a.unit_id = sqlc.narg(unit_id))
+  AND (sqlc.narg(faction_id)::uuid IS NULL OR a.faction_id = sqlc.narg(faction_id))
+  AND (sqlc.narg(game_id)::uuid IS NULL OR a.game_id = sqlc.narg(game_id))
+  AND (sqlc.narg(type)::text IS NULL OR a.type ILIKE sqlc.narg(type))
+  AND (sqlc.narg(phase)::text IS NULL OR a.phase ILIKE sqlc.narg(phase))
+  AND (sqlc.narg(allegiance)::text IS NULL OR f.allegiance ILIKE sqlc.narg(allegiance))
+  AND (sqlc.narg(after_id)::uuid IS NULL OR CASE sqlc.arg(sort)::text
+    WHEN 'name' THEN (a.name, a.id) > (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN '-name' THEN (a.name, a.id) < (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN 'created_at' THEN (a.created_at, a.id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+    WHEN '-created_at' THEN (a.created_at, a.id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+  END)
+ORDER BY
+  CASE WHEN sqlc.arg(sort) = 'name' THEN a.name END ASC,
+  CASE WHEN sqlc.arg(sort) = '-name' THEN a.name END DESC,
+  CASE WHEN sqlc.arg(sort) = 'created_at' THEN a.created_at END ASC,
+  CASE WHEN sqlc.arg(sort) = '-created_at' THEN a.created_at END DESC,
+  CASE WHEN sqlc.arg(sort) LIKE '-%' THEN a.id END DESC,
+  a.id ASC
+LIMIT sqlc.arg(page_size);
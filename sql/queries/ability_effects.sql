@@ -4,6 +4,18 @@ FROM ability_effects
 WHERE ability_id = $1
 ORDER BY stat ASC;
 
+-- name: GetAbilityEffectsForEnhancement :many
+SELECT *
+FROM ability_effects
+WHERE enhancement_id = $1
+ORDER BY stat ASC;
+
+-- name: GetAbilityEffectsForBattleFormation :many
+SELECT *
+FROM ability_effects
+WHERE battle_formation_id = $1
+ORDER BY stat ASC;
+
 -- name: GetAbilityEffectByID :one
 SELECT *
 FROM ability_effects
@@ -12,11 +24,11 @@ WHERE id = $1;
 -- name: GetAllAbilityEffects :many
 SELECT *
 FROM ability_effects
-ORDER BY ability_id, stat ASC;
+ORDER BY ability_id, enhancement_id, battle_formation_id, stat ASC;
 
 -- name: CreateAbilityEffect :one
-INSERT INTO ability_effects (ability_id, stat, modifier, condition, description, version, source)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO ability_effects (ability_id, stat, modifier, condition, description, version, source, enhancement_id, battle_formation_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: UpdateAbilityEffect :one
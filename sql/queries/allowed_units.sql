@@ -0,0 +1,14 @@
+-- name: GetAllowedUnitsForFaction :many
+SELECT *
+FROM faction_allowed_units
+WHERE faction_id = $1
+ORDER BY unit_name ASC;
+
+-- name: CreateAllowedUnit :one
+INSERT INTO faction_allowed_units (faction_id, unit_name, battlescribe_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: DeleteAllowedUnit :exec
+DELETE FROM faction_allowed_units
+WHERE id = $1;
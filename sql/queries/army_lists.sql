@@ -0,0 +1,74 @@
+-- name: GetArmyLists :many
+SELECT *
+FROM army_lists
+ORDER BY faction_id, name ASC;
+
+-- name: GetArmyListsForFaction :many
+SELECT *
+FROM army_lists
+WHERE faction_id = $1
+ORDER BY name ASC;
+
+-- name: GetArmyListByID :one
+SELECT *
+FROM army_lists
+WHERE id = $1;
+
+-- name: CreateArmyList :one
+INSERT INTO army_lists (
+  game_id, faction_id, name, points_limit, battle_formation_id,
+  total_points, is_valid, validation, regiment_of_renown_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: UpdateArmyList :one
+UPDATE army_lists
+SET game_id = $2, faction_id = $3, name = $4, points_limit = $5,
+    battle_formation_id = $6, total_points = $7, is_valid = $8,
+    validation = $9, regiment_of_renown_id = $10, updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteArmyList :execrows
+DELETE FROM army_lists
+WHERE id = $1;
+
+-- name: GetArmyListRegiments :many
+SELECT *
+FROM army_list_regiments
+WHERE army_list_id = $1
+ORDER BY position ASC;
+
+-- name: CreateArmyListRegiment :one
+INSERT INTO army_list_regiments (army_list_id, position, name, is_general, is_auxiliary, is_regiment_of_renown)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: DeleteArmyListRegiments :exec
+DELETE FROM army_list_regiments
+WHERE army_list_id = $1;
+
+-- name: GetArmyListUnits :many
+SELECT alu.*
+FROM army_list_units alu
+JOIN army_list_regiments r ON r.id = alu.regiment_id
+WHERE r.army_list_id = $1
+ORDER BY r.position ASC, alu.position ASC;
+
+-- name: CreateArmyListUnit :one
+INSERT INTO army_list_units (regiment_id, unit_id, position, quantity, is_reinforced)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetArmyListUnitEnhancements :many
+SELECT ale.*
+FROM army_list_unit_enhancements ale
+JOIN army_list_units alu ON alu.id = ale.army_list_unit_id
+JOIN army_list_regiments r ON r.id = alu.regiment_id
+WHERE r.army_list_id = $1;
+
+-- name: AddEnhancementToArmyListUnit :exec
+INSERT INTO army_list_unit_enhancements (army_list_unit_id, enhancement_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
@@ -21,13 +21,14 @@ WHERE enhancement_type = $1
 ORDER BY faction_id, name ASC;
 
 -- name: CreateEnhancement :one
-INSERT INTO enhancements (faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+INSERT INTO enhancements (faction_id, name, enhancement_type, description, points, is_unique, restrictions, version, source, battlescribe_id, required_keywords)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 RETURNING *;
 
 -- name: UpdateEnhancement :one
 UPDATE enhancements
-SET name = $2, enhancement_type = $3, description = $4, points = $5, version = $6, source = $7, updated_at = now()
+SET name = $2, enhancement_type = $3, description = $4, points = $5, version = $6, source = $7,
+    is_unique = $8, restrictions = $9, battlescribe_id = $10, required_keywords = $11, updated_at = now()
 WHERE id = $1
 RETURNING *;
 
@@ -24,6 +24,11 @@ SET edition = $2, version = $3, source = $4, updated_at = now()
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateGameBattlescribe :exec
+UPDATE games
+SET battlescribe_id = $2, battlescribe_forces = $3, updated_at = now()
+WHERE id = $1;
+
 -- name: DeleteGame :exec
 DELETE FROM games
 WHERE id = $1;
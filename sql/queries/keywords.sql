@@ -59,3 +59,31 @@ WHERE unit_id = $1 AND keyword_id = $2;
 -- name: DeleteKeyword :exec
 DELETE FROM keywords
 WHERE id = $1;
+
+-- name: DeleteUnusedKeywords :execrows
+DELETE FROM keywords k
+WHERE k.game_id = $1
+  AND NOT EXISTS (
+    SELECT 1 FROM unit_keywords uk WHERE uk.keyword_id = k.id
+  );
+
+-- name: ListKeywords :many
+SELECT k.*, COALESCE(uk.value, '')::text AS value
+FROM keywords k
+LEFT JOIN unit_keywords uk ON uk.keyword_id = k.id AND uk.unit_id = sqlc.narg(unit_id)
+WHERE (sqlc.narg(unit_id)::uuid IS NULL OR uk.unit_id IS NOT NULL)
+  AND (sqlc.narg(game_id)::uuid IS NULL OR k.game_id = sqlc.narg(game_id))
+  AND (sqlc.narg(after_id)::uuid IS NULL OR CASE sqlc.arg(sort)::text
+    WHEN 'name' THEN (k.name, k.id) > (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN '-name' THEN (k.name, k.id) < (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN 'created_at' THEN (k.created_at, k.id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+    WHEN '-created_at' THEN (k.created_at, k.id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+  END)
+ORDER BY
+  CASE WHEN sqlc.arg(sort) = 'name' THEN k.name END ASC,
+  CASE WHEN sqlc.arg(sort) = '-name' THEN k.name END DESC,
+  CASE WHEN sqlc.arg(sort) = 'created_at' THEN k.created_at END ASC,
+  CASE WHEN sqlc.arg(sort) = '-created_at' THEN k.created_at END DESC,
+  CASE WHEN sqlc.arg(sort) LIKE '-%' THEN k.id END DESC,
+  k.id ASC
+LIMIT sqlc.arg(page_size);
@@ -0,0 +1,14 @@
+-- name: GetHostsForRegimentOfRenown :many
+SELECT *
+FROM regiment_of_renown_hosts
+WHERE faction_id = $1
+ORDER BY host_faction_name ASC;
+
+-- name: CreateRegimentOfRenownHost :one
+INSERT INTO regiment_of_renown_hosts (faction_id, host_faction_name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: DeleteRegimentOfRenownHost :exec
+DELETE FROM regiment_of_renown_hosts
+WHERE id = $1;
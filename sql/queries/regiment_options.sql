@@ -0,0 +1,14 @@
+-- name: GetRegimentOptionsForUnit :many
+SELECT *
+FROM regiment_options
+WHERE unit_id = $1
+ORDER BY is_hero, keyword, unit_name ASC;
+
+-- name: CreateRegimentOption :one
+INSERT INTO regiment_options (unit_id, keyword, unit_name, is_hero)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: DeleteRegimentOption :exec
+DELETE FROM regiment_options
+WHERE id = $1;
@@ -27,10 +27,30 @@ RETURNING *;
 
 -- name: UpdateRule :one
 UPDATE rules
-SET name = $2, description = $3, rule_type = $4, version = $5, source = $6, updated_at = now()
+SET name = $2, description = $3, rule_type = $4, version = $5, source = $6, text = $7, updated_at = now()
 WHERE id = $1
 RETURNING *;
 
 -- name: DeleteRule :exec
 DELETE FROM rules
 WHERE id = $1;
+
+-- name: ListRules :many
+SELECT r.*
+FROM rules r
+WHERE (sqlc.narg(game_id)::uuid IS NULL OR r.game_id = sqlc.narg(game_id))
+  AND (sqlc.narg(rule_type)::text IS NULL OR r.rule_type ILIKE sqlc.narg(rule_type))
+  AND (sqlc.narg(after_id)::uuid IS NULL OR CASE sqlc.arg(sort)::text
+    WHEN 'name' THEN (r.name, r.id) > (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN '-name' THEN (r.name, r.id) < (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN 'created_at' THEN (r.created_at, r.id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+    WHEN '-created_at' THEN (r.created_at, r.id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+  END)
+ORDER BY
+  CASE WHEN sqlc.arg(sort) = 'name' THEN r.name END ASC,
+  CASE WHEN sqlc.arg(sort) = '-name' THEN r.name END DESC,
+  CASE WHEN sqlc.arg(sort) = 'created_at' THEN r.created_at END ASC,
+  CASE WHEN sqlc.arg(sort) = '-created_at' THEN r.created_at END DESC,
+  CASE WHEN sqlc.arg(sort) LIKE '-%' THEN r.id END DESC,
+  r.id ASC
+LIMIT sqlc.arg(page_size);
@@ -0,0 +1,60 @@
+-- The documents searched are written out as in the GIN indexes of
+-- sql/schema/022_search.sql so the planner can use them; keep them the same.
+
+-- name: SearchUnits :many
+SELECT u.id, u.name, f.game_id, u.faction_id,
+  ts_rank((setweight(to_tsvector('english', u.name), 'A') || setweight(to_tsvector('english', u.description), 'B')), websearch_to_tsquery('english', sqlc.arg(query)::text))::real AS rank,
+  ts_headline('english', u.description, websearch_to_tsquery('english', sqlc.arg(query)::text), 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15')::text AS snippet
+FROM units u
+JOIN factions f ON f.id = u.faction_id
+WHERE (setweight(to_tsvector('english', u.name), 'A') || setweight(to_tsvector('english', u.description), 'B')) @@ websearch_to_tsquery('english', sqlc.arg(query)::text)
+  AND (sqlc.narg(game_id)::uuid IS NULL OR f.game_id = sqlc.narg(game_id))
+  AND (sqlc.narg(faction_id)::uuid IS NULL OR u.faction_id = sqlc.narg(faction_id))
+ORDER BY rank DESC, u.name ASC
+LIMIT sqlc.arg(result_limit);
+
+-- name: SearchAbilities :many
+SELECT a.id, a.name, COALESCE(a.game_id, f.game_id)::uuid AS game_id, f.id AS faction_id, a.unit_id,
+  ts_rank((setweight(to_tsvector('english', a.name), 'A') || setweight(to_tsvector('english', a.description), 'B')), websearch_to_tsquery('english', sqlc.arg(query)::text))::real AS rank,
+  ts_headline('english', a.description, websearch_to_tsquery('english', sqlc.arg(query)::text), 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15')::text AS snippet
+FROM abilities a
+LEFT JOIN units u ON u.id = a.unit_id
+LEFT JOIN factions f ON f.id = COALESCE(a.faction_id, u.faction_id)
+WHERE (setweight(to_tsvector('english', a.name), 'A') || setweight(to_tsvector('english', a.description), 'B')) @@ websearch_to_tsquery('english', sqlc.arg(query)::text)
+  AND (sqlc.narg(game_id)::uuid IS NULL OR COALESCE(a.game_id, f.game_id) = sqlc.narg(game_id))
+  AND (sqlc.narg(faction_id)::uuid IS NULL OR f.id = sqlc.narg(faction_id))
+ORDER BY rank DESC, a.name ASC
+LIMIT sqlc.arg(result_limit);
+
+-- name: SearchRules :many
+SELECT r.id, r.name, r.game_id,
+  ts_rank((setweight(to_tsvector('english', r.name), 'A') || setweight(to_tsvector('english', r.description), 'B') || setweight(to_tsvector('english', r.text), 'C')), websearch_to_tsquery('english', sqlc.arg(query)::text))::real AS rank,
+  ts_headline('english', concat_ws(' ', NULLIF(r.description, ''), NULLIF(r.text, '')), websearch_to_tsquery('english', sqlc.arg(query)::text), 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15')::text AS snippet
+FROM rules r
+WHERE (setweight(to_tsvector('english', r.name), 'A') || setweight(to_tsvector('english', r.description), 'B') || setweight(to_tsvector('english', r.text), 'C')) @@ websearch_to_tsquery('english', sqlc.arg(query)::text)
+  AND (sqlc.narg(game_id)::uuid IS NULL OR r.game_id = sqlc.narg(game_id))
+ORDER BY rank DESC, r.name ASC
+LIMIT sqlc.arg(result_limit);
+
+-- name: SearchEnhancements :many
+SELECT e.id, e.name, f.game_id, e.faction_id,
+  ts_rank((setweight(to_tsvector('english', e.name), 'A') || setweight(to_tsvector('english', e.description), 'B') || setweight(to_tsvector('english', e.restrictions), 'C')), websearch_to_tsquery('english', sqlc.arg(query)::text))::real AS rank,
+  ts_headline('english', e.description, websearch_to_tsquery('english', sqlc.arg(query)::text), 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15')::text AS snippet
+FROM enhancements e
+JOIN factions f ON f.id = e.faction_id
+WHERE (setweight(to_tsvector('english', e.name), 'A') || setweight(to_tsvector('english', e.description), 'B') || setweight(to_tsvector('english', e.restrictions), 'C')) @@ websearch_to_tsquery('english', sqlc.arg(query)::text)
+  AND (sqlc.narg(game_id)::uuid IS NULL OR f.game_id = sqlc.narg(game_id))
+  AND (sqlc.narg(faction_id)::uuid IS NULL OR e.faction_id = sqlc.narg(faction_id))
+ORDER BY rank DESC, e.name ASC
+LIMIT sqlc.arg(result_limit);
+
+-- name: SearchBattleFormations :many
+SELECT b.id, b.name, b.game_id, b.faction_id,
+  ts_rank((setweight(to_tsvector('english', b.name), 'A') || setweight(to_tsvector('english', b.description), 'B')), websearch_to_tsquery('english', sqlc.arg(query)::text))::real AS rank,
+  ts_headline('english', b.description, websearch_to_tsquery('english', sqlc.arg(query)::text), 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15')::text AS snippet
+FROM battle_formations b
+WHERE (setweight(to_tsvector('english', b.name), 'A') || setweight(to_tsvector('english', b.description), 'B')) @@ websearch_to_tsquery('english', sqlc.arg(query)::text)
+  AND (sqlc.narg(game_id)::uuid IS NULL OR b.game_id = sqlc.narg(game_id))
+  AND (sqlc.narg(faction_id)::uuid IS NULL OR b.faction_id = sqlc.narg(faction_id))
+ORDER BY rank DESC, b.name ASC
+LIMIT sqlc.arg(result_limit);
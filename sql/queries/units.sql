@@ -1,25 +1,19 @@
--- name: GetAllUnits :many
-SELECT *
-FROM units
-WHERE is_manifestation = false
-ORDER BY faction_id, name ASC;
-
 -- name: GetUnitsByFaction :many
 SELECT *
 FROM units
 WHERE faction_id = $1 AND is_manifestation = false
 ORDER BY name ASC;
 
--- name: GetUnitByID :one
+-- name: GetAllUnitsForFaction :many
 SELECT *
 FROM units
-WHERE id = $1;
+WHERE faction_id = $1
+ORDER BY name ASC;
 
--- name: GetUnitsByMatchedPlay :many
+-- name: GetUnitByID :one
 SELECT *
 FROM units
-WHERE faction_id = $1 AND matched_play = true AND is_manifestation = false
-ORDER BY name ASC;
+WHERE id = $1;
 
 -- name: GetNonManifestationUnits :many
 SELECT *
@@ -45,13 +39,15 @@ INSERT INTO units (
   control_oc, toughness, leadership_bravery, points,
   additional_stats,
   summon_cost, banishment,
-  min_unit_size, max_unit_size, matched_play, version, source
+  min_unit_size, max_unit_size, matched_play, version, source,
+  battlescribe_id, can_be_reinforced
 )
 VALUES (
   $1, $2, $3, $4, $5,
   $6, $7, $8, $9, $10, $11, $12, $13, $14, $15,
   $16, $17,
-  $18, $19, $20, $21, $22
+  $18, $19, $20, $21, $22,
+  $23, $24
 )
 RETURNING *;
 
@@ -61,10 +57,49 @@ SET name = $2, description = $3, move = $4, health_wounds = $5, save_stats = $6,
     ward_fnp = $7, invuln_save = $8, control_oc = $9, toughness = $10, 
     leadership_bravery = $11, points = $12, additional_stats = $13,
     summon_cost = $14, banishment = $15, min_unit_size = $16, max_unit_size = $17, 
-    matched_play = $18, version = $19, source = $20, updated_at = now()
+    matched_play = $18, version = $19, source = $20, is_manifestation = $21,
+    is_unique = $22, battlescribe_id = $23, can_be_reinforced = $24,
+    updated_at = now()
 WHERE id = $1
 RETURNING *;
 
 -- name: DeleteUnit :exec
 DELETE FROM units
 WHERE id = $1;
+
+-- name: ListUnits :many
+SELECT u.*
+FROM units u
+JOIN factions f ON f.id = u.faction_id
+WHERE u.is_manifestation = sqlc.arg(is_manifestation)
+  AND (sqlc.narg(faction_id)::uuid IS NULL OR u.faction_id = sqlc.narg(faction_id))
+  AND (sqlc.narg(game_id)::uuid IS NULL OR f.game_id = sqlc.narg(game_id))
+  AND (sqlc.narg(points_min)::int IS NULL OR u.points >= sqlc.narg(points_min))
+  AND (sqlc.narg(points_max)::int IS NULL OR u.points <= sqlc.narg(points_max))
+  AND (sqlc.narg(is_unique)::boolean IS NULL OR u.is_unique = sqlc.narg(is_unique))
+  AND (sqlc.narg(matched_play)::boolean IS NULL OR u.matched_play = sqlc.narg(matched_play))
+  AND (sqlc.narg(allegiance)::text IS NULL OR f.allegiance ILIKE sqlc.narg(allegiance))
+  AND (sqlc.narg(keywords)::text IS NULL OR array_to_tsvector(ARRAY(
+    SELECT upper(k.name)
+    FROM unit_keywords uk
+    JOIN keywords k ON k.id = uk.keyword_id
+    WHERE uk.unit_id = u.id
+  )) @@ sqlc.narg(keywords)::text::tsquery)
+  AND (sqlc.narg(after_id)::uuid IS NULL OR CASE sqlc.arg(sort)::text
+    WHEN 'name' THEN (u.name, u.id) > (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN '-name' THEN (u.name, u.id) < (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN 'points' THEN (u.points, u.id) > (sqlc.narg(after_points)::int, sqlc.narg(after_id))
+    WHEN '-points' THEN (u.points, u.id) < (sqlc.narg(after_points)::int, sqlc.narg(after_id))
+    WHEN 'created_at' THEN (u.created_at, u.id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+    WHEN '-created_at' THEN (u.created_at, u.id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+  END)
+ORDER BY
+  CASE WHEN sqlc.arg(sort) = 'name' THEN u.name END ASC,
+  CASE WHEN sqlc.arg(sort) = '-name' THEN u.name END DESC,
+  CASE WHEN sqlc.arg(sort) = 'points' THEN u.points END ASC,
+  CASE WHEN sqlc.arg(sort) = '-points' THEN u.points END DESC,
+  CASE WHEN sqlc.arg(sort) = 'created_at' THEN u.created_at END ASC,
+  CASE WHEN sqlc.arg(sort) = '-created_at' THEN u.created_at END DESC,
+  CASE WHEN sqlc.arg(sort) LIKE '-%' THEN u.id END DESC,
+  u.id ASC
+LIMIT sqlc.arg(page_size);
@@ -9,27 +9,49 @@ SELECT *
 FROM weapons
 WHERE id = $1;
 
--- name: GetAllWeapons :many
-SELECT *
-FROM weapons
-ORDER BY unit_id, name ASC;
-
 -- name: CreateWeapon :one
 INSERT INTO weapons (
   unit_id, name, range, attacks, hit_stats, 
-  wound_strength, rend_ap, damage, version, source
+  wound_strength, rend_ap, damage, version, source,
+  weapon_type, abilities
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 RETURNING *;
 
 -- name: UpdateWeapon :one
 UPDATE weapons
 SET name = $2, range = $3, attacks = $4, hit_stats = $5, 
     wound_strength = $6, rend_ap = $7, damage = $8, 
-    version = $9, source = $10, updated_at = now()
+    version = $9, source = $10, weapon_type = $11,
+    abilities = $12, updated_at = now()
 WHERE id = $1
 RETURNING *;
 
 -- name: DeleteWeapon :exec
 DELETE FROM weapons
 WHERE id = $1;
+
+-- name: ListWeapons :many
+SELECT w.*
+FROM weapons w
+JOIN units u ON u.id = w.unit_id
+JOIN factions f ON f.id = u.faction_id
+WHERE (sqlc.narg(unit_id)::uuid IS NULL OR w.unit_id = sqlc.narg(unit_id))
+  AND (sqlc.narg(faction_id)::uuid IS NULL OR u.faction_id = sqlc.narg(faction_id))
+  AND (sqlc.narg(game_id)::uuid IS NULL OR f.game_id = sqlc.narg(game_id))
+  AND (sqlc.narg(weapon_type)::text IS NULL OR w.weapon_type ILIKE sqlc.narg(weapon_type))
+  AND (sqlc.narg(allegiance)::text IS NULL OR f.allegiance ILIKE sqlc.narg(allegiance))
+  AND (sqlc.narg(after_id)::uuid IS NULL OR CASE sqlc.arg(sort)::text
+    WHEN 'name' THEN (w.name, w.id) > (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN '-name' THEN (w.name, w.id) < (sqlc.narg(after_name)::text, sqlc.narg(after_id))
+    WHEN 'created_at' THEN (w.created_at, w.id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+    WHEN '-created_at' THEN (w.created_at, w.id) < (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id))
+  END)
+ORDER BY
+  CASE WHEN sqlc.arg(sort) = 'name' THEN w.name END ASC,
+  CASE WHEN sqlc.arg(sort) = '-name' THEN w.name END DESC,
+  CASE WHEN sqlc.arg(sort) = 'created_at' THEN w.created_at END ASC,
+  CASE WHEN sqlc.arg(sort) = '-created_at' THEN w.created_at END DESC,
+  CASE WHEN sqlc.arg(sort) LIKE '-%' THEN w.id END DESC,
+  w.id ASC
+LIMIT sqlc.arg(page_size);
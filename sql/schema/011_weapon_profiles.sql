@@ -0,0 +1,3 @@
+ALTER TABLE weapons
+  ADD COLUMN weapon_type TEXT NOT NULL DEFAULT '',
+  ADD COLUMN abilities TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+ALTER TABLE units
+  ADD COLUMN battlescribe_id TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE enhancements
+  ADD COLUMN battlescribe_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX units_battlescribe_idx ON units (battlescribe_id) WHERE battlescribe_id <> '';
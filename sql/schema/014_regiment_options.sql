@@ -0,0 +1,13 @@
+CREATE TABLE regiment_options (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  unit_id UUID NOT NULL REFERENCES units(id) ON DELETE CASCADE,
+  keyword TEXT NOT NULL DEFAULT '',
+  unit_name TEXT NOT NULL DEFAULT '',
+  is_hero BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX regiment_options_unit_idx ON regiment_options (unit_id);
+
+ALTER TABLE army_list_regiments
+  ADD COLUMN is_general BOOLEAN NOT NULL DEFAULT false,
+  ADD COLUMN is_auxiliary BOOLEAN NOT NULL DEFAULT false;
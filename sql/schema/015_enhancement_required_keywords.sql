@@ -0,0 +1,2 @@
+ALTER TABLE enhancements
+  ADD COLUMN required_keywords JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,5 @@
+ALTER TABLE units
+  ADD COLUMN can_be_reinforced BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE army_list_units
+  ADD COLUMN is_reinforced BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,7 @@
+ALTER TABLE games
+  ADD COLUMN battlescribe_id TEXT NOT NULL DEFAULT '',
+  ADD COLUMN battlescribe_forces JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE factions
+  ADD COLUMN battlescribe_id TEXT NOT NULL DEFAULT '',
+  ADD COLUMN battlescribe_force_id TEXT NOT NULL DEFAULT '';
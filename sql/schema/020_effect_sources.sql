@@ -0,0 +1,12 @@
+ALTER TABLE ability_effects
+  ALTER COLUMN ability_id DROP NOT NULL,
+  ADD COLUMN enhancement_id UUID REFERENCES enhancements(id) ON DELETE CASCADE,
+  ADD COLUMN battle_formation_id UUID REFERENCES battle_formations(id) ON DELETE CASCADE,
+  ADD CONSTRAINT chk_effect_source CHECK (
+    (ability_id IS NOT NULL)::integer +
+    (enhancement_id IS NOT NULL)::integer +
+    (battle_formation_id IS NOT NULL)::integer = 1
+  );
+
+CREATE INDEX ability_effects_enhancement_idx ON ability_effects (enhancement_id, stat ASC) WHERE enhancement_id IS NOT NULL;
+CREATE INDEX ability_effects_battle_formation_idx ON ability_effects (battle_formation_id, stat ASC) WHERE battle_formation_id IS NOT NULL;
@@ -0,0 +1,7 @@
+CREATE INDEX units_name_id_idx ON units (name, id);
+CREATE INDEX units_points_id_idx ON units (points, id);
+CREATE INDEX units_created_at_id_idx ON units (created_at, id);
+CREATE INDEX weapons_name_id_idx ON weapons (name, id);
+CREATE INDEX abilities_name_id_idx ON abilities (name, id);
+CREATE INDEX keywords_name_id_idx ON keywords (name, id);
+CREATE INDEX rules_name_id_idx ON rules (name, id);
@@ -0,0 +1,26 @@
+CREATE INDEX units_search_idx ON units USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B')
+  ));
+
+CREATE INDEX abilities_search_idx ON abilities USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B')
+  ));
+
+CREATE INDEX rules_search_idx ON rules USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B') ||
+    setweight(to_tsvector('english', text), 'C')
+  ));
+
+CREATE INDEX enhancements_search_idx ON enhancements USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B') ||
+    setweight(to_tsvector('english', restrictions), 'C')
+  ));
+
+CREATE INDEX battle_formations_search_idx ON battle_formations USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', description), 'B')
+  ));